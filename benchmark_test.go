@@ -1,6 +1,8 @@
 package pg_query_test
 
 import (
+	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/tomaszjonak/pg_query_go"
@@ -114,6 +116,44 @@ func benchmarkNormalize(input string, b *testing.B) {
 	}
 }
 
+func benchmarkDeparse(input string, b *testing.B) {
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		b.Fatalf("Benchmark produced error %s\n\n", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		resultStr, err = pg_query.Deparse(tree)
+		if err != nil {
+			b.Errorf("Benchmark produced error %s\n\n", err)
+		}
+		if resultStr == "" {
+			b.Errorf("Benchmark produced empty result\n\n")
+		}
+	}
+}
+
+func benchmarkDeparseTo(input string, b *testing.B) {
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		b.Fatalf("Benchmark produced error %s\n\n", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		err = pg_query.DeparseTo(ioutil.Discard, tree)
+		if err != nil {
+			b.Errorf("Benchmark produced error %s\n\n", err)
+		}
+	}
+}
+
+// An INSERT of many rows, like a bulk load
+var insertValues = "INSERT INTO x (a, b, c) VALUES " + strings.TrimSuffix(strings.Repeat("(1, 'abc', now()), ", 10000), ", ")
+
 func BenchmarkParseSelect1(b *testing.B) {
 	benchmarkParse("SELECT 1", b)
 }
@@ -183,3 +223,23 @@ func BenchmarkNormalizeSelect2(b *testing.B) {
 func BenchmarkNormalizeCreateTable(b *testing.B) {
 	benchmarkNormalize("CREATE TABLE types (a float(2), b float(49), c NUMERIC(2, 3), d character(4), e char(5), f varchar(6), g character varying(7))", b)
 }
+
+func BenchmarkDeparseSelect1(b *testing.B) {
+	benchmarkDeparse("SELECT 1", b)
+}
+func BenchmarkDeparseSelect2(b *testing.B) {
+	benchmarkDeparse("SELECT 1 FROM x WHERE y IN ('a', 'b', 'c')", b)
+}
+func BenchmarkDeparseInsertValues(b *testing.B) {
+	benchmarkDeparse(insertValues, b)
+}
+
+func BenchmarkDeparseToSelect1(b *testing.B) {
+	benchmarkDeparseTo("SELECT 1", b)
+}
+func BenchmarkDeparseToSelect2(b *testing.B) {
+	benchmarkDeparseTo("SELECT 1 FROM x WHERE y IN ('a', 'b', 'c')", b)
+}
+func BenchmarkDeparseToInsertValues(b *testing.B) {
+	benchmarkDeparseTo(insertValues, b)
+}
@@ -0,0 +1,925 @@
+package catalog
+
+import (
+	"fmt"
+
+	pg_query "github.com/tomaszjonak/pg_query_go"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+func (c *Catalog) alterTable(stmt nodes.AlterTableStmt) error {
+	schemaName, name := rangeVarNames(stmt.Relation)
+	switch stmt.Relkind {
+	case nodes.OBJECT_SEQUENCE:
+		sequence := c.Sequence(schemaName, name)
+		if sequence == nil {
+			if stmt.MissingOk {
+				return nil
+			}
+			return fmt.Errorf("relation \"%s\" does not exist", qualifiedName(schemaName, name))
+		}
+		for _, item := range stmt.Cmds.Items {
+			if cmd, ok := item.(nodes.AlterTableCmd); ok && cmd.Subtype == nodes.AT_ChangeOwner {
+				sequence.Owner = roleName(cmd.Newowner)
+			}
+		}
+		return nil
+	case nodes.OBJECT_INDEX:
+		return nil
+	}
+
+	table := c.Table(schemaName, name)
+	if table == nil {
+		if stmt.MissingOk {
+			return nil
+		}
+		return fmt.Errorf("relation \"%s\" does not exist", qualifiedName(schemaName, name))
+	}
+	for _, item := range stmt.Cmds.Items {
+		if cmd, ok := item.(nodes.AlterTableCmd); ok {
+			if err := c.alterTableCmd(table, cmd); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) alterTableCmd(table *Table, cmd nodes.AlterTableCmd) error {
+	schema := c.Schema(table.Schema)
+	var column *Column
+	if cmd.Name != nil {
+		column = table.Column(*cmd.Name)
+	}
+	columnMissing := func() error {
+		return fmt.Errorf("column \"%s\" of relation \"%s\" does not exist", *cmd.Name, table.Name)
+	}
+
+	switch cmd.Subtype {
+	case nodes.AT_AddColumn, nodes.AT_AddColumnRecurse:
+		columnDef, ok := cmd.Def.(nodes.ColumnDef)
+		if !ok {
+			return nil
+		}
+		if table.Column(*columnDef.Colname) != nil {
+			if cmd.MissingOk {
+				return nil
+			}
+			return fmt.Errorf("column \"%s\" of relation \"%s\" already exists", *columnDef.Colname, table.Name)
+		}
+		newColumn, constraints, err := c.columnDef(table, columnDef)
+		if err != nil {
+			return err
+		}
+		table.Columns = append(table.Columns, newColumn)
+		for _, pending := range constraints {
+			if err := c.addConstraint(schema, table, pending.constraint, pending.column); err != nil {
+				return err
+			}
+		}
+		if isSerial(columnDef) {
+			addSerialSequence(schema, table, newColumn.Name)
+		}
+	case nodes.AT_DropColumn, nodes.AT_DropColumnRecurse:
+		if column == nil {
+			if cmd.MissingOk {
+				return nil
+			}
+			return columnMissing()
+		}
+		return c.dropColumn(table, column.Name, cmd.Behavior == nodes.DROP_CASCADE)
+	case nodes.AT_AlterColumnType:
+		if column == nil {
+			return columnMissing()
+		}
+		columnDef, ok := cmd.Def.(nodes.ColumnDef)
+		if !ok || columnDef.TypeName == nil {
+			return nil
+		}
+		typeName, err := c.ResolveType(*columnDef.TypeName)
+		if err != nil {
+			return err
+		}
+		column.Type = typeName
+	case nodes.AT_ColumnDefault:
+		if column == nil {
+			return columnMissing()
+		}
+		column.Default = ""
+		if cmd.Def != nil {
+			deparsed, err := pg_query.DeparseItem(cmd.Def)
+			if err != nil {
+				return err
+			}
+			column.Default = deparsed
+		}
+	case nodes.AT_SetNotNull:
+		if column == nil {
+			return columnMissing()
+		}
+		column.NotNull = true
+	case nodes.AT_DropNotNull:
+		if column == nil {
+			return columnMissing()
+		}
+		if primaryKey := table.PrimaryKey(); primaryKey != nil && containsString(primaryKey.Columns, column.Name) {
+			return fmt.Errorf("column \"%s\" is in a primary key", column.Name)
+		}
+		column.NotNull = false
+	case nodes.AT_AddConstraint, nodes.AT_AddConstraintRecurse:
+		if constraint, ok := cmd.Def.(nodes.Constraint); ok {
+			return c.addConstraint(schema, table, constraint, "")
+		}
+	case nodes.AT_ValidateConstraint, nodes.AT_ValidateConstraintRecurse:
+		constraint := table.Constraint(*cmd.Name)
+		if constraint == nil {
+			return fmt.Errorf("constraint \"%s\" of relation \"%s\" does not exist", *cmd.Name, table.Name)
+		}
+		constraint.NotValid = false
+	case nodes.AT_DropConstraint, nodes.AT_DropConstraintRecurse:
+		constraint := table.Constraint(*cmd.Name)
+		if constraint == nil {
+			if cmd.MissingOk {
+				return nil
+			}
+			return fmt.Errorf("constraint \"%s\" of relation \"%s\" does not exist", *cmd.Name, table.Name)
+		}
+		c.dropConstraint(table, constraint)
+	case nodes.AT_ChangeOwner:
+		table.Owner = roleName(cmd.Newowner)
+	}
+	return nil
+}
+
+// dropColumn drops a column of a table, together with the indexes and
+// constraints that use it. Views that read the column and foreign keys of
+// other tables that reference it are only dropped with CASCADE.
+func (c *Catalog) dropColumn(table *Table, name string, cascade bool) error {
+	object := fmt.Sprintf("column %s of table %s", name, table.Name)
+	if column := table.Column(name); column != nil {
+		for _, view := range c.dependentViews(table, column) {
+			if !cascade {
+				return fmt.Errorf("cannot drop %s because other objects depend on it", object)
+			}
+			if err := c.removeTable(view, cascade); err != nil {
+				return err
+			}
+		}
+	}
+	if err := c.dropReferences(table, func(constraint *Constraint) bool {
+		return containsString(constraint.RefColumns, name)
+	}, cascade, object); err != nil {
+		return err
+	}
+
+	columns := []*Column{}
+	for _, column := range table.Columns {
+		if column.Name != name {
+			columns = append(columns, column)
+		}
+	}
+	table.Columns = columns
+
+	constraints := []*Constraint{}
+	for _, constraint := range table.Constraints {
+		if !containsString(constraint.Columns, name) {
+			constraints = append(constraints, constraint)
+		}
+	}
+	table.Constraints = constraints
+
+	indexes := []*Index{}
+	for _, index := range table.Indexes {
+		if !containsString(index.Columns, name) {
+			indexes = append(indexes, index)
+		}
+	}
+	table.Indexes = indexes
+
+	schema := c.Schema(table.Schema)
+	sequences := []*Sequence{}
+	for _, sequence := range schema.Sequences {
+		if sequence.OwnedByTable != table.Name || sequence.OwnedByColumn != name {
+			sequences = append(sequences, sequence)
+		}
+	}
+	schema.Sequences = sequences
+	return nil
+}
+
+// dropReferences drops the foreign keys of other tables that reference the
+// given table and match fn, or fails without CASCADE if there are any
+func (c *Catalog) dropReferences(table *Table, fn func(constraint *Constraint) bool, cascade bool, object string) error {
+	for _, schema := range c.Schemas {
+		for _, other := range schema.Tables {
+			if other == table {
+				continue
+			}
+			for _, constraint := range other.Constraints {
+				if constraint.Kind != ConstraintForeignKey || constraint.RefSchema != table.Schema || constraint.RefTable != table.Name || !fn(constraint) {
+					continue
+				}
+				if !cascade {
+					return fmt.Errorf("cannot drop %s because other objects depend on it", object)
+				}
+				c.dropConstraint(other, constraint)
+			}
+		}
+	}
+	return nil
+}
+
+// dropConstraint drops a constraint and the index backing it
+func (c *Catalog) dropConstraint(table *Table, constraint *Constraint) {
+	constraints := []*Constraint{}
+	for _, other := range table.Constraints {
+		if other != constraint {
+			constraints = append(constraints, other)
+		}
+	}
+	table.Constraints = constraints
+
+	switch constraint.Kind {
+	case ConstraintPrimaryKey, ConstraintUnique, ConstraintExclusion:
+		indexes := []*Index{}
+		for _, index := range table.Indexes {
+			if index.Name != constraint.Name {
+				indexes = append(indexes, index)
+			}
+		}
+		table.Indexes = indexes
+	}
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// Object types of DROP, RENAME and similar statements that refer to tables,
+// by the kind of table they expect
+var relationObjectKinds = map[nodes.ObjectType]TableKind{
+	nodes.OBJECT_TABLE:         KindTable,
+	nodes.OBJECT_VIEW:          KindView,
+	nodes.OBJECT_MATVIEW:       KindMaterializedView,
+	nodes.OBJECT_FOREIGN_TABLE: KindForeignTable,
+}
+
+func (c *Catalog) drop(stmt nodes.DropStmt) error {
+	cascade := stmt.Behavior == nodes.DROP_CASCADE
+	for _, item := range stmt.Objects.Items {
+		var err error
+		if kind, ok := relationObjectKinds[stmt.RemoveType]; ok {
+			err = c.dropTable(item, kind, stmt.MissingOk, cascade)
+		} else {
+			switch stmt.RemoveType {
+			case nodes.OBJECT_INDEX:
+				err = c.dropIndex(item, stmt.MissingOk)
+			case nodes.OBJECT_SEQUENCE:
+				err = c.dropSequence(item, stmt.MissingOk)
+			case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+				err = c.dropType(item, stmt.MissingOk, cascade)
+			case nodes.OBJECT_SCHEMA:
+				err = c.dropSchema(item, stmt.MissingOk, cascade)
+			case nodes.OBJECT_FUNCTION:
+				err = c.dropFunction(item, stmt.MissingOk)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectNames returns the names of the object a statement refers to, which
+// is a list of String nodes, a single String or a TypeName
+func objectNames(node nodes.Node) []string {
+	switch node := node.(type) {
+	case nodes.List:
+		return stringList(node)
+	case nodes.String:
+		return []string{node.Str}
+	case nodes.TypeName:
+		return stringList(node.Names)
+	case nodes.ObjectWithArgs:
+		return stringList(node.Objname)
+	}
+	return nil
+}
+
+func (c *Catalog) dropTable(object nodes.Node, kind TableKind, missingOk bool, cascade bool) error {
+	schemaName, name := splitName(objectNames(object))
+	table := c.Table(schemaName, name)
+	if table == nil {
+		if missingOk {
+			return nil
+		}
+		return fmt.Errorf("%s \"%s\" does not exist", kind, qualifiedName(schemaName, name))
+	}
+	if table.Kind != kind {
+		return fmt.Errorf("\"%s\" is not a %s", name, kind)
+	}
+	return c.removeTable(table, cascade)
+}
+
+// removeTable removes a table, view or materialized view and the sequences
+// it owns. The views that read it and the foreign keys that reference it are
+// dropped with CASCADE, without it they make the drop fail.
+func (c *Catalog) removeTable(table *Table, cascade bool) error {
+	object := fmt.Sprintf("%s %s", table.Kind, table.Name)
+	for _, view := range c.dependentViews(table, nil) {
+		if !cascade {
+			return fmt.Errorf("cannot drop %s because other objects depend on it", object)
+		}
+		if err := c.removeTable(view, cascade); err != nil {
+			return err
+		}
+	}
+	if err := c.dropReferences(table, func(constraint *Constraint) bool { return true }, cascade, object); err != nil {
+		return err
+	}
+
+	schema := c.Schema(table.Schema)
+	tables := []*Table{}
+	for _, other := range schema.Tables {
+		if other != table {
+			tables = append(tables, other)
+		}
+	}
+	schema.Tables = tables
+
+	sequences := []*Sequence{}
+	for _, sequence := range schema.Sequences {
+		if sequence.OwnedByTable != table.Name {
+			sequences = append(sequences, sequence)
+		}
+	}
+	schema.Sequences = sequences
+	return nil
+}
+
+// dependentViews returns the views and materialized views that depend on the
+// table, or on the given column of it if column isn't nil
+func (c *Catalog) dependentViews(table *Table, column *Column) []*Table {
+	views := []*Table{}
+	for _, schema := range c.Schemas {
+		for _, view := range schema.Tables {
+			for _, dependency := range view.Dependencies {
+				if view != table && dependency.Table == table && (column == nil || dependency.Column == column) {
+					views = append(views, view)
+					break
+				}
+			}
+		}
+	}
+	return views
+}
+
+func (c *Catalog) dropIndex(object nodes.Node, missingOk bool) error {
+	schemaName, name := splitName(objectNames(object))
+	for _, schema := range c.searchSchemas(schemaName) {
+		index, table := schema.Index(name)
+		if index == nil {
+			continue
+		}
+		for _, constraint := range table.Constraints {
+			if constraint.Name == index.Name && constraint.Kind != ConstraintCheck && constraint.Kind != ConstraintForeignKey {
+				return fmt.Errorf("cannot drop index %s because constraint %s on table %s requires it", index.Name, constraint.Name, table.Name)
+			}
+		}
+		indexes := []*Index{}
+		for _, other := range table.Indexes {
+			if other != index {
+				indexes = append(indexes, other)
+			}
+		}
+		table.Indexes = indexes
+		return nil
+	}
+	if missingOk {
+		return nil
+	}
+	return fmt.Errorf("index \"%s\" does not exist", qualifiedName(schemaName, name))
+}
+
+func (c *Catalog) dropSequence(object nodes.Node, missingOk bool) error {
+	schemaName, name := splitName(objectNames(object))
+	sequence := c.Sequence(schemaName, name)
+	if sequence == nil {
+		if missingOk {
+			return nil
+		}
+		return fmt.Errorf("sequence \"%s\" does not exist", qualifiedName(schemaName, name))
+	}
+	schema := c.Schema(sequence.Schema)
+	sequences := []*Sequence{}
+	for _, other := range schema.Sequences {
+		if other != sequence {
+			sequences = append(sequences, other)
+		}
+	}
+	schema.Sequences = sequences
+	return nil
+}
+
+func (c *Catalog) dropType(object nodes.Node, missingOk bool, cascade bool) error {
+	schemaName, name := splitName(objectNames(object))
+	typ := c.Type(schemaName, name)
+	if typ == nil {
+		if missingOk {
+			return nil
+		}
+		return fmt.Errorf("type \"%s\" does not exist", qualifiedName(schemaName, name))
+	}
+
+	// Columns of the type are dropped with CASCADE
+	typeName := TypeName{Schema: typ.Schema, Name: typ.Name}
+	for _, schema := range c.Schemas {
+		for _, table := range schema.Tables {
+			for _, column := range table.Columns {
+				if !column.Type.Elem().Equal(typeName) && !column.Type.Equal(typeName) {
+					continue
+				}
+				if !cascade {
+					return fmt.Errorf("cannot drop type %s because other objects depend on it", typ.Name)
+				}
+				if err := c.dropColumn(table, column.Name, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	schema := c.Schema(typ.Schema)
+	types := []*Type{}
+	for _, other := range schema.Types {
+		if other != typ {
+			types = append(types, other)
+		}
+	}
+	schema.Types = types
+	return nil
+}
+
+func (c *Catalog) dropSchema(object nodes.Node, missingOk bool, cascade bool) error {
+	names := objectNames(object)
+	if len(names) != 1 {
+		return nil
+	}
+	schema := c.Schema(names[0])
+	if schema == nil {
+		if missingOk {
+			return nil
+		}
+		return fmt.Errorf("schema \"%s\" does not exist", names[0])
+	}
+	empty := len(schema.Tables) == 0 && len(schema.Types) == 0 && len(schema.Functions) == 0 && len(schema.Sequences) == 0
+	if !empty && !cascade {
+		return fmt.Errorf("cannot drop schema %s because other objects depend on it", schema.Name)
+	}
+	for _, table := range append([]*Table{}, schema.Tables...) {
+		if err := c.removeTable(table, true); err != nil {
+			return err
+		}
+	}
+
+	schemas := []*Schema{}
+	for _, other := range c.Schemas {
+		if other != schema {
+			schemas = append(schemas, other)
+		}
+	}
+	c.Schemas = schemas
+	return nil
+}
+
+func (c *Catalog) dropFunction(object nodes.Node, missingOk bool) error {
+	objectWithArgs, ok := object.(nodes.ObjectWithArgs)
+	if !ok {
+		return nil
+	}
+	function, err := c.findFunction(objectWithArgs)
+	if err != nil {
+		if missingOk {
+			return nil
+		}
+		return err
+	}
+	schema := c.Schema(function.Schema)
+	functions := []*Function{}
+	for _, other := range schema.Functions {
+		if other != function {
+			functions = append(functions, other)
+		}
+	}
+	schema.Functions = functions
+	return nil
+}
+
+func (c *Catalog) rename(stmt nodes.RenameStmt) error {
+	if stmt.Newname == nil {
+		return nil
+	}
+	newName := *stmt.Newname
+	missing := func(err error) error {
+		if stmt.MissingOk {
+			return nil
+		}
+		return err
+	}
+
+	if kind, ok := relationObjectKinds[stmt.RenameType]; ok {
+		table, err := c.findTable(stmt.Relation, kind)
+		if err != nil {
+			return missing(err)
+		}
+		schema := c.Schema(table.Schema)
+		if relationExists(schema, newName) {
+			return fmt.Errorf("relation \"%s\" already exists", newName)
+		}
+		c.forEachForeignKey(table, func(constraint *Constraint) {
+			constraint.RefTable = newName
+		})
+		for _, sequence := range schema.Sequences {
+			if sequence.OwnedByTable == table.Name {
+				sequence.OwnedByTable = newName
+			}
+		}
+		table.Name = newName
+		return nil
+	}
+
+	switch stmt.RenameType {
+	case nodes.OBJECT_COLUMN:
+		table, err := c.findTable(stmt.Relation)
+		if err != nil {
+			return missing(err)
+		}
+		column := table.Column(*stmt.Subname)
+		if column == nil {
+			return missing(fmt.Errorf("column \"%s\" does not exist", *stmt.Subname))
+		}
+		if table.Column(newName) != nil {
+			return fmt.Errorf("column \"%s\" of relation \"%s\" already exists", newName, table.Name)
+		}
+		for _, constraint := range table.Constraints {
+			replaceString(constraint.Columns, column.Name, newName)
+		}
+		for _, index := range table.Indexes {
+			replaceString(index.Columns, column.Name, newName)
+		}
+		c.forEachForeignKey(table, func(constraint *Constraint) {
+			replaceString(constraint.RefColumns, column.Name, newName)
+		})
+		for _, sequence := range c.Schema(table.Schema).Sequences {
+			if sequence.OwnedByTable == table.Name && sequence.OwnedByColumn == column.Name {
+				sequence.OwnedByColumn = newName
+			}
+		}
+		column.Name = newName
+	case nodes.OBJECT_TABCONSTRAINT:
+		table, err := c.findTable(stmt.Relation)
+		if err != nil {
+			return missing(err)
+		}
+		constraint := table.Constraint(*stmt.Subname)
+		if constraint == nil {
+			return fmt.Errorf("constraint \"%s\" for table \"%s\" does not exist", *stmt.Subname, table.Name)
+		}
+		if index := table.Index(constraint.Name); index != nil {
+			index.Name = newName
+		}
+		constraint.Name = newName
+	case nodes.OBJECT_INDEX:
+		schemaName, name := rangeVarNames(stmt.Relation)
+		for _, schema := range c.searchSchemas(schemaName) {
+			index, table := schema.Index(name)
+			if index == nil {
+				continue
+			}
+			if relationExists(schema, newName) {
+				return fmt.Errorf("relation \"%s\" already exists", newName)
+			}
+			if constraint := table.Constraint(index.Name); constraint != nil {
+				constraint.Name = newName
+			}
+			index.Name = newName
+			return nil
+		}
+		return missing(fmt.Errorf("relation \"%s\" does not exist", qualifiedName(schemaName, name)))
+	case nodes.OBJECT_SEQUENCE:
+		schemaName, name := rangeVarNames(stmt.Relation)
+		sequence := c.Sequence(schemaName, name)
+		if sequence == nil {
+			return missing(fmt.Errorf("relation \"%s\" does not exist", qualifiedName(schemaName, name)))
+		}
+		if relationExists(c.Schema(sequence.Schema), newName) {
+			return fmt.Errorf("relation \"%s\" already exists", newName)
+		}
+		sequence.Name = newName
+	case nodes.OBJECT_SCHEMA:
+		schema := c.Schema(*stmt.Subname)
+		if schema == nil {
+			return fmt.Errorf("schema \"%s\" does not exist", *stmt.Subname)
+		}
+		if c.Schema(newName) != nil {
+			return fmt.Errorf("schema \"%s\" already exists", newName)
+		}
+		c.renameSchema(schema, newName)
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		schemaName, name := splitName(objectNames(stmt.Object))
+		typ := c.Type(schemaName, name)
+		if typ == nil {
+			return missing(fmt.Errorf("type \"%s\" does not exist", qualifiedName(schemaName, name)))
+		}
+		if c.Schema(typ.Schema).Type(newName) != nil {
+			return fmt.Errorf("type \"%s\" already exists", newName)
+		}
+		c.moveType(typ, typ.Schema, newName)
+	case nodes.OBJECT_FUNCTION:
+		objectWithArgs, ok := stmt.Object.(nodes.ObjectWithArgs)
+		if !ok {
+			return nil
+		}
+		function, err := c.findFunction(objectWithArgs)
+		if err != nil {
+			return missing(err)
+		}
+		function.Name = newName
+	}
+	return nil
+}
+
+func replaceString(strs []string, old string, new string) {
+	for i, str := range strs {
+		if str == old {
+			strs[i] = new
+		}
+	}
+}
+
+// forEachForeignKey calls fn for every foreign key referencing the table
+func (c *Catalog) forEachForeignKey(table *Table, fn func(constraint *Constraint)) {
+	for _, schema := range c.Schemas {
+		for _, other := range schema.Tables {
+			for _, constraint := range other.Constraints {
+				if constraint.Kind == ConstraintForeignKey && constraint.RefSchema == table.Schema && constraint.RefTable == table.Name {
+					fn(constraint)
+				}
+			}
+		}
+	}
+}
+
+// forEachTypeName calls fn for every type name stored in the catalog
+func (c *Catalog) forEachTypeName(fn func(typeName *TypeName)) {
+	for _, schema := range c.Schemas {
+		for _, table := range schema.Tables {
+			for _, column := range table.Columns {
+				fn(&column.Type)
+			}
+		}
+		for _, typ := range schema.Types {
+			fn(&typ.BaseType)
+			for _, attribute := range typ.Attributes {
+				fn(&attribute.Type)
+			}
+		}
+		for _, function := range schema.Functions {
+			fn(&function.ReturnType)
+			for i := range function.Args {
+				fn(&function.Args[i].Type)
+			}
+		}
+	}
+}
+
+// moveType renames a type or moves it to another schema, updating the
+// columns, attributes and functions that use it
+func (c *Catalog) moveType(typ *Type, schemaName string, name string) {
+	c.forEachTypeName(func(typeName *TypeName) {
+		if typeName.Schema == typ.Schema && typeName.Name == typ.Name {
+			typeName.Schema, typeName.Name = schemaName, name
+		}
+	})
+	if schemaName != typ.Schema {
+		from := c.Schema(typ.Schema)
+		types := []*Type{}
+		for _, other := range from.Types {
+			if other != typ {
+				types = append(types, other)
+			}
+		}
+		from.Types = types
+		to := c.Schema(schemaName)
+		to.Types = append(to.Types, typ)
+	}
+	typ.Schema, typ.Name = schemaName, name
+}
+
+// renameSchema renames a schema and updates all references to it
+func (c *Catalog) renameSchema(schema *Schema, name string) {
+	c.forEachTypeName(func(typeName *TypeName) {
+		if typeName.Schema == schema.Name {
+			typeName.Schema = name
+		}
+	})
+	for _, other := range c.Schemas {
+		for _, table := range other.Tables {
+			for _, constraint := range table.Constraints {
+				if constraint.RefSchema == schema.Name {
+					constraint.RefSchema = name
+				}
+			}
+		}
+	}
+	for _, table := range schema.Tables {
+		table.Schema = name
+	}
+	for _, typ := range schema.Types {
+		typ.Schema = name
+	}
+	for _, function := range schema.Functions {
+		function.Schema = name
+	}
+	for _, sequence := range schema.Sequences {
+		sequence.Schema = name
+	}
+	schema.Name = name
+}
+
+func (c *Catalog) alterObjectSchema(stmt nodes.AlterObjectSchemaStmt) error {
+	if stmt.Newschema == nil {
+		return nil
+	}
+	to := c.Schema(*stmt.Newschema)
+	if to == nil {
+		return fmt.Errorf("schema \"%s\" does not exist", *stmt.Newschema)
+	}
+
+	if kind, ok := relationObjectKinds[stmt.ObjectType]; ok {
+		table, err := c.findTable(stmt.Relation, kind)
+		if err != nil {
+			if stmt.MissingOk {
+				return nil
+			}
+			return err
+		}
+		if relationExists(to, table.Name) {
+			return fmt.Errorf("relation \"%s\" already exists in schema \"%s\"", table.Name, to.Name)
+		}
+		c.forEachForeignKey(table, func(constraint *Constraint) {
+			constraint.RefSchema = to.Name
+		})
+		c.forEachTypeName(func(typeName *TypeName) {
+			if typeName.Schema == table.Schema && typeName.Name == table.Name {
+				typeName.Schema = to.Name
+			}
+		})
+
+		// Sequences owned by the table move with it
+		from := c.Schema(table.Schema)
+		tables := []*Table{}
+		for _, other := range from.Tables {
+			if other != table {
+				tables = append(tables, other)
+			}
+		}
+		from.Tables = tables
+		sequences := []*Sequence{}
+		for _, sequence := range from.Sequences {
+			if sequence.OwnedByTable == table.Name {
+				sequence.Schema = to.Name
+				to.Sequences = append(to.Sequences, sequence)
+			} else {
+				sequences = append(sequences, sequence)
+			}
+		}
+		from.Sequences = sequences
+		table.Schema = to.Name
+		to.Tables = append(to.Tables, table)
+		return nil
+	}
+
+	switch stmt.ObjectType {
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		schemaName, name := splitName(objectNames(stmt.Object))
+		typ := c.Type(schemaName, name)
+		if typ == nil {
+			if stmt.MissingOk {
+				return nil
+			}
+			return fmt.Errorf("type \"%s\" does not exist", qualifiedName(schemaName, name))
+		}
+		if to.Type(typ.Name) != nil {
+			return fmt.Errorf("type \"%s\" already exists in schema \"%s\"", typ.Name, to.Name)
+		}
+		c.moveType(typ, to.Name, typ.Name)
+	case nodes.OBJECT_FUNCTION:
+		objectWithArgs, ok := stmt.Object.(nodes.ObjectWithArgs)
+		if !ok {
+			return nil
+		}
+		function, err := c.findFunction(objectWithArgs)
+		if err != nil {
+			if stmt.MissingOk {
+				return nil
+			}
+			return err
+		}
+		from := c.Schema(function.Schema)
+		functions := []*Function{}
+		for _, other := range from.Functions {
+			if other != function {
+				functions = append(functions, other)
+			}
+		}
+		from.Functions = functions
+		function.Schema = to.Name
+		to.Functions = append(to.Functions, function)
+	}
+	return nil
+}
+
+func (c *Catalog) alterOwner(stmt nodes.AlterOwnerStmt) error {
+	owner := roleName(stmt.Newowner)
+	switch stmt.ObjectType {
+	case nodes.OBJECT_SCHEMA:
+		names := objectNames(stmt.Object)
+		if len(names) != 1 || c.Schema(names[0]) == nil {
+			return fmt.Errorf("schema \"%s\" does not exist", qualifiedName(names...))
+		}
+		c.Schema(names[0]).Owner = owner
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		schemaName, name := splitName(objectNames(stmt.Object))
+		typ := c.Type(schemaName, name)
+		if typ == nil {
+			return fmt.Errorf("type \"%s\" does not exist", qualifiedName(schemaName, name))
+		}
+		typ.Owner = owner
+	case nodes.OBJECT_FUNCTION:
+		objectWithArgs, ok := stmt.Object.(nodes.ObjectWithArgs)
+		if !ok {
+			return nil
+		}
+		function, err := c.findFunction(objectWithArgs)
+		if err != nil {
+			return err
+		}
+		function.Owner = owner
+	}
+	return nil
+}
+
+func (c *Catalog) comment(stmt nodes.CommentStmt) error {
+	comment := ""
+	if stmt.Comment != nil {
+		comment = *stmt.Comment
+	}
+	names := objectNames(stmt.Object)
+
+	if kind, ok := relationObjectKinds[stmt.Objtype]; ok {
+		schemaName, name := splitName(names)
+		table := c.Table(schemaName, name)
+		if table == nil || table.Kind != kind {
+			return fmt.Errorf("%s \"%s\" does not exist", kind, qualifiedName(schemaName, name))
+		}
+		table.Comment = comment
+		return nil
+	}
+
+	switch stmt.Objtype {
+	case nodes.OBJECT_COLUMN:
+		if len(names) < 2 {
+			return nil
+		}
+		schemaName, name := splitName(names[:len(names)-1])
+		table := c.Table(schemaName, name)
+		if table == nil {
+			return fmt.Errorf("relation \"%s\" does not exist", qualifiedName(schemaName, name))
+		}
+		column := table.Column(names[len(names)-1])
+		if column == nil {
+			return fmt.Errorf("column \"%s\" of relation \"%s\" does not exist", names[len(names)-1], table.Name)
+		}
+		column.Comment = comment
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		schemaName, name := splitName(names)
+		typ := c.Type(schemaName, name)
+		if typ == nil {
+			return fmt.Errorf("type \"%s\" does not exist", qualifiedName(schemaName, name))
+		}
+		typ.Comment = comment
+	case nodes.OBJECT_SCHEMA:
+		if len(names) != 1 || c.Schema(names[0]) == nil {
+			return fmt.Errorf("schema \"%s\" does not exist", qualifiedName(names...))
+		}
+		c.Schema(names[0]).Comment = comment
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package catalog
+
+import (
+	"fmt"
+
+	pg_query "github.com/tomaszjonak/pg_query_go"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// Error is an error applying a statement to the catalog, e.g. because it
+// refers to a table that doesn't exist
+type Error struct {
+	Message string
+
+	// Index of the statement within the applied SQL
+	Statement int
+
+	// Byte offset of the statement within the applied SQL
+	Location int
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Apply - Parses the given SQL and applies its statements to the catalog
+func (c *Catalog) Apply(input string) error {
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		return err
+	}
+	return c.ApplyTree(tree)
+}
+
+// ApplyTree applies the statements of a parse tree in order, stopping at the
+// first one that fails with an *Error. Statements that don't change the
+// schema, e.g. DML, are ignored.
+func (c *Catalog) ApplyTree(tree pg_query.ParsetreeList) error {
+	for i, item := range tree.Statements {
+		stmt, location := nodes.UnwrapRawStmt(item)
+		if err := c.ApplyStatement(stmt); err != nil {
+			return &Error{Message: err.Error(), Statement: i, Location: location}
+		}
+	}
+	return nil
+}
+
+// ApplyStatement applies a single statement to the catalog
+func (c *Catalog) ApplyStatement(node nodes.Node) error {
+	switch node := node.(type) {
+	case nodes.CreateSchemaStmt:
+		return c.createSchema(node)
+	case nodes.CreateStmt:
+		return c.createTable(node)
+	case nodes.CreateTableAsStmt:
+		return c.createTableAs(node)
+	case nodes.ViewStmt:
+		return c.createView(node)
+	case nodes.AlterTableStmt:
+		return c.alterTable(node)
+	case nodes.IndexStmt:
+		return c.createIndex(node)
+	case nodes.CreateSeqStmt:
+		return c.createSequence(node)
+	case nodes.AlterSeqStmt:
+		return c.alterSequence(node)
+	case nodes.CreateEnumStmt:
+		return c.createEnum(node)
+	case nodes.AlterEnumStmt:
+		return c.alterEnum(node)
+	case nodes.CompositeTypeStmt:
+		return c.createCompositeType(node)
+	case nodes.CreateDomainStmt:
+		return c.createDomain(node)
+	case nodes.CreateFunctionStmt:
+		return c.createFunction(node)
+	case nodes.DropStmt:
+		return c.drop(node)
+	case nodes.RenameStmt:
+		return c.rename(node)
+	case nodes.AlterObjectSchemaStmt:
+		return c.alterObjectSchema(node)
+	case nodes.AlterOwnerStmt:
+		return c.alterOwner(node)
+	case nodes.CommentStmt:
+		return c.comment(node)
+	case nodes.VariableSetStmt:
+		return c.setVariable(node)
+	}
+	return nil
+}
+
+// creationSchema returns the schema a new object is created in, which is the
+// first existing schema of the search path if the name is unqualified
+func (c *Catalog) creationSchema(schemaName *string) (*Schema, error) {
+	if schemaName != nil {
+		schema := c.Schema(*schemaName)
+		if schema == nil {
+			return nil, fmt.Errorf("schema \"%s\" does not exist", *schemaName)
+		}
+		return schema, nil
+	}
+	if c.createIn != "" {
+		return c.Schema(c.createIn), nil
+	}
+	for _, name := range c.SearchPath {
+		if schema := c.Schema(name); schema != nil {
+			return schema, nil
+		}
+	}
+	return nil, fmt.Errorf("no schema has been selected to create in")
+}
+
+// relationExists returns whether a table, view, sequence, index or composite
+// type with the given name exists in the schema, as they share a namespace
+func relationExists(schema *Schema, name string) bool {
+	if index, _ := schema.Index(name); index != nil {
+		return true
+	}
+	typ := schema.Type(name)
+	return schema.Table(name) != nil || schema.Sequence(name) != nil || (typ != nil && typ.Kind == TypeComposite)
+}
+
+// splitName splits a possibly qualified name into schema and name
+func splitName(names []string) (schema string, name string) {
+	switch len(names) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", names[0]
+	default:
+		return names[len(names)-2], names[len(names)-1]
+	}
+}
+
+func rangeVarNames(rangeVar *nodes.RangeVar) (schema string, name string) {
+	if rangeVar == nil || rangeVar.Relname == nil {
+		return "", ""
+	}
+	if rangeVar.Schemaname != nil {
+		schema = *rangeVar.Schemaname
+	}
+	return schema, *rangeVar.Relname
+}
+
+// findTable returns the relation a RangeVar refers to, or an error if it
+// doesn't exist or isn't of one of the given kinds
+func (c *Catalog) findTable(rangeVar *nodes.RangeVar, kinds ...TableKind) (*Table, error) {
+	schema, name := rangeVarNames(rangeVar)
+	table := c.Table(schema, name)
+	if table == nil {
+		return nil, fmt.Errorf("relation \"%s\" does not exist", qualifiedName(schema, name))
+	}
+	if len(kinds) == 0 {
+		return table, nil
+	}
+	for _, kind := range kinds {
+		if table.Kind == kind {
+			return table, nil
+		}
+	}
+	return nil, fmt.Errorf("\"%s\" is not a %s", name, kinds[0])
+}
+
+func (c *Catalog) createSchema(stmt nodes.CreateSchemaStmt) error {
+	owner := roleName(stmt.Authrole)
+	name := owner
+	if stmt.Schemaname != nil {
+		name = *stmt.Schemaname
+	}
+	if c.Schema(name) != nil {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("schema \"%s\" already exists", name)
+	}
+	c.Schemas = append(c.Schemas, &Schema{Name: name, Owner: owner})
+
+	createIn := c.createIn
+	c.createIn = name
+	defer func() { c.createIn = createIn }()
+	for _, item := range stmt.SchemaElts.Items {
+		if err := c.ApplyStatement(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func roleName(roleSpec *nodes.RoleSpec) string {
+	if roleSpec == nil {
+		return ""
+	}
+	switch roleSpec.Roletype {
+	case nodes.ROLESPEC_CURRENT_USER:
+		return "CURRENT_USER"
+	case nodes.ROLESPEC_SESSION_USER:
+		return "SESSION_USER"
+	case nodes.ROLESPEC_PUBLIC:
+		return "public"
+	}
+	if roleSpec.Rolename == nil {
+		return ""
+	}
+	return *roleSpec.Rolename
+}
+
+func (c *Catalog) createSequence(stmt nodes.CreateSeqStmt) error {
+	schema, err := c.creationSchema(stmt.Sequence.Schemaname)
+	if err != nil {
+		return err
+	}
+	name := *stmt.Sequence.Relname
+	if relationExists(schema, name) {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("relation \"%s\" already exists", name)
+	}
+	sequence := &Sequence{Schema: schema.Name, Name: name}
+	if err := c.setSequenceOptions(sequence, stmt.Options); err != nil {
+		return err
+	}
+	schema.Sequences = append(schema.Sequences, sequence)
+	return nil
+}
+
+func (c *Catalog) alterSequence(stmt nodes.AlterSeqStmt) error {
+	schemaName, name := rangeVarNames(stmt.Sequence)
+	sequence := c.Sequence(schemaName, name)
+	if sequence == nil {
+		if stmt.MissingOk {
+			return nil
+		}
+		return fmt.Errorf("relation \"%s\" does not exist", qualifiedName(schemaName, name))
+	}
+	return c.setSequenceOptions(sequence, stmt.Options)
+}
+
+// setSequenceOptions applies the OWNED BY option of a sequence
+func (c *Catalog) setSequenceOptions(sequence *Sequence, options nodes.List) error {
+	for _, item := range options.Items {
+		defElem, ok := item.(nodes.DefElem)
+		if !ok || defElem.Defname == nil || *defElem.Defname != "owned_by" {
+			continue
+		}
+		list, _ := defElem.Arg.(nodes.List)
+		names := stringList(list)
+		if len(names) == 1 && names[0] == "none" {
+			sequence.OwnedByTable, sequence.OwnedByColumn = "", ""
+			continue
+		}
+		if len(names) < 2 {
+			return fmt.Errorf("invalid OWNED BY option")
+		}
+		table := c.Table(splitName(names[:len(names)-1]))
+		if table == nil {
+			return fmt.Errorf("relation \"%s\" does not exist", qualifiedName(names[:len(names)-1]...))
+		}
+		column := names[len(names)-1]
+		if table.Column(column) == nil {
+			return fmt.Errorf("column \"%s\" of relation \"%s\" does not exist", column, table.Name)
+		}
+		if table.Schema != sequence.Schema {
+			return fmt.Errorf("sequence must be in same schema as table it is linked to")
+		}
+		sequence.OwnedByTable, sequence.OwnedByColumn = table.Name, column
+	}
+	return nil
+}
+
+func (c *Catalog) setVariable(stmt nodes.VariableSetStmt) error {
+	if stmt.Kind == nodes.VAR_RESET_ALL {
+		c.SearchPath = []string{"public"}
+		return nil
+	}
+	if stmt.Name == nil || *stmt.Name != "search_path" {
+		return nil
+	}
+	switch stmt.Kind {
+	case nodes.VAR_SET_VALUE:
+		searchPath := []string{}
+		for _, item := range stmt.Args.Items {
+			aConst, ok := item.(nodes.A_Const)
+			if !ok {
+				continue
+			}
+			if str, ok := aConst.Val.(nodes.String); ok && str.Str != "$user" {
+				searchPath = append(searchPath, str.Str)
+			}
+		}
+		c.SearchPath = searchPath
+	case nodes.VAR_SET_DEFAULT, nodes.VAR_RESET:
+		c.SearchPath = []string{"public"}
+	}
+	return nil
+}
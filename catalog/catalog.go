@@ -0,0 +1,452 @@
+// Package catalog builds an in-memory model of a database schema by replaying
+// DDL statements, e.g. to know the schema a folder of migrations produces
+// without running PostgreSQL.
+package catalog
+
+import (
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// Catalog is the schema of a database. Names are case-sensitive, as in
+// PostgreSQL after unquoted identifiers are folded to lower case.
+type Catalog struct {
+	Schemas []*Schema
+
+	// Schemas searched for unqualified names, and the first of them that
+	// exists is where unqualified objects are created
+	SearchPath []string
+
+	createIn string // schema of the CREATE SCHEMA statement being applied
+}
+
+// Schema is a namespace of tables, types, functions and sequences
+type Schema struct {
+	Name      string
+	Owner     string
+	Comment   string
+	Tables    []*Table
+	Types     []*Type
+	Functions []*Function
+	Sequences []*Sequence
+}
+
+// TableKind is the kind of relation a Table describes
+type TableKind int
+
+const (
+	KindTable TableKind = iota
+	KindView
+	KindMaterializedView
+	KindForeignTable
+)
+
+func (kind TableKind) String() string {
+	switch kind {
+	case KindView:
+		return "view"
+	case KindMaterializedView:
+		return "materialized view"
+	case KindForeignTable:
+		return "foreign table"
+	default:
+		return "table"
+	}
+}
+
+// Table is a table, view or materialized view
+type Table struct {
+	Schema      string
+	Name        string
+	Kind        TableKind
+	Owner       string
+	Comment     string
+	Columns     []*Column
+	Constraints []*Constraint
+	Indexes     []*Index
+
+	// The query of a view or materialized view
+	Query nodes.Node
+
+	// Tables and columns the query of a view or materialized view reads,
+	// recorded when it's created
+	Dependencies []Dependency
+}
+
+// Dependency is a table, or a column of it, a view or materialized view
+// depends on
+type Dependency struct {
+	Table *Table
+
+	// The column, nil for a dependency on the table as a whole
+	Column *Column
+}
+
+// Column is a column of a table or an attribute of a composite type
+type Column struct {
+	Name    string
+	Type    TypeName
+	NotNull bool
+	Comment string
+
+	// SQL expression of the default value, empty if there is none
+	Default string
+
+	// Columns of tables the values of a column of a view or materialized
+	// view are computed from, traced through the views its query reads and
+	// recorded when it's created
+	Origins []Origin
+}
+
+// Origin is a column of a table the values of a column of a view are
+// computed from
+type Origin struct {
+	Table  *Table
+	Column *Column
+
+	// Whether the value is a copy of the column, as opposed to being
+	// computed from it, e.g. by a function or an aggregate
+	Direct bool
+}
+
+// ConstraintKind is the kind of a table constraint
+type ConstraintKind int
+
+const (
+	ConstraintPrimaryKey ConstraintKind = iota
+	ConstraintUnique
+	ConstraintCheck
+	ConstraintForeignKey
+	ConstraintExclusion
+)
+
+func (kind ConstraintKind) String() string {
+	switch kind {
+	case ConstraintPrimaryKey:
+		return "PRIMARY KEY"
+	case ConstraintUnique:
+		return "UNIQUE"
+	case ConstraintCheck:
+		return "CHECK"
+	case ConstraintForeignKey:
+		return "FOREIGN KEY"
+	default:
+		return "EXCLUDE"
+	}
+}
+
+// Constraint is a table constraint. NOT NULL constraints are stored on the
+// columns instead.
+type Constraint struct {
+	Name    string
+	Kind    ConstraintKind
+	Columns []string
+
+	// SQL expression of a CHECK constraint
+	Expression string
+
+	// Table and columns referenced by a foreign key
+	RefSchema  string
+	RefTable   string
+	RefColumns []string
+
+	// Whether the constraint was added with NOT VALID and not validated yet
+	NotValid bool
+}
+
+// Index is an index of a table, including those backing primary key, unique
+// and exclusion constraints
+type Index struct {
+	Name    string
+	Unique  bool
+	Primary bool
+	Method  string
+
+	// Indexed column names, or SQL expressions for expression indexes
+	Columns []string
+
+	// SQL predicate of a partial index, empty otherwise
+	Where string
+}
+
+// TypeKind is the kind of a user-defined type
+type TypeKind int
+
+const (
+	TypeEnum TypeKind = iota
+	TypeComposite
+	TypeDomain
+)
+
+func (kind TypeKind) String() string {
+	switch kind {
+	case TypeEnum:
+		return "enum"
+	case TypeComposite:
+		return "composite"
+	default:
+		return "domain"
+	}
+}
+
+// Type is a user-defined enum, composite type or domain
+type Type struct {
+	Schema  string
+	Name    string
+	Kind    TypeKind
+	Owner   string
+	Comment string
+
+	// Labels of an enum, in sort order
+	EnumValues []string
+
+	// Attributes of a composite type
+	Attributes []*Column
+
+	// Base type and NOT NULL constraint of a domain
+	BaseType TypeName
+	NotNull  bool
+}
+
+// Function is a user-defined function
+type Function struct {
+	Schema     string
+	Name       string
+	Owner      string
+	Args       []FunctionArg
+	ReturnType TypeName
+	ReturnsSet bool
+	Language   string
+}
+
+// FunctionArg is an argument of a function, including OUT and TABLE
+// arguments that make up its result
+type FunctionArg struct {
+	Name       string
+	Type       TypeName
+	Mode       nodes.FunctionParameterMode
+	HasDefault bool
+}
+
+// Sequence is a sequence, including those created for serial columns
+type Sequence struct {
+	Schema string
+	Name   string
+	Owner  string
+
+	// Table and column the sequence is owned by, if any
+	OwnedByTable  string
+	OwnedByColumn string
+}
+
+// New returns an empty catalog with a public schema
+func New() *Catalog {
+	return &Catalog{
+		Schemas:    []*Schema{{Name: "public"}},
+		SearchPath: []string{"public"},
+	}
+}
+
+// Schema returns the schema with the given name, or nil
+func (c *Catalog) Schema(name string) *Schema {
+	for _, schema := range c.Schemas {
+		if schema.Name == name {
+			return schema
+		}
+	}
+	return nil
+}
+
+// searchSchemas returns the schemas to look in for a name, which is the
+// given schema if it's qualified and the search path otherwise
+func (c *Catalog) searchSchemas(schemaName string) []*Schema {
+	if schemaName != "" {
+		if schema := c.Schema(schemaName); schema != nil {
+			return []*Schema{schema}
+		}
+		return nil
+	}
+	schemas := []*Schema{}
+	for _, name := range c.SearchPath {
+		if schema := c.Schema(name); schema != nil {
+			schemas = append(schemas, schema)
+		}
+	}
+	return schemas
+}
+
+// Table returns the table, view or materialized view with the given name,
+// looking in the search path if schema is empty, or nil
+func (c *Catalog) Table(schema, name string) *Table {
+	for _, s := range c.searchSchemas(schema) {
+		if table := s.Table(name); table != nil {
+			return table
+		}
+	}
+	return nil
+}
+
+// Type returns the user-defined type with the given name, looking in the
+// search path if schema is empty, or nil
+func (c *Catalog) Type(schema, name string) *Type {
+	for _, s := range c.searchSchemas(schema) {
+		if typ := s.Type(name); typ != nil {
+			return typ
+		}
+	}
+	return nil
+}
+
+// Sequence returns the sequence with the given name, looking in the search
+// path if schema is empty, or nil
+func (c *Catalog) Sequence(schema, name string) *Sequence {
+	for _, s := range c.searchSchemas(schema) {
+		if sequence := s.Sequence(name); sequence != nil {
+			return sequence
+		}
+	}
+	return nil
+}
+
+// Functions returns the overloads of the function with the given name in the
+// first schema of the search path that has any, or in the given schema
+func (c *Catalog) Functions(schema, name string) []*Function {
+	for _, s := range c.searchSchemas(schema) {
+		if functions := s.FunctionsNamed(name); len(functions) > 0 {
+			return functions
+		}
+	}
+	return nil
+}
+
+// Table returns the table, view or materialized view with the given name, or
+// nil
+func (s *Schema) Table(name string) *Table {
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// Type returns the type with the given name, or nil
+func (s *Schema) Type(name string) *Type {
+	for _, typ := range s.Types {
+		if typ.Name == name {
+			return typ
+		}
+	}
+	return nil
+}
+
+// Sequence returns the sequence with the given name, or nil
+func (s *Schema) Sequence(name string) *Sequence {
+	for _, sequence := range s.Sequences {
+		if sequence.Name == name {
+			return sequence
+		}
+	}
+	return nil
+}
+
+// FunctionsNamed returns the overloads of the function with the given name
+func (s *Schema) FunctionsNamed(name string) []*Function {
+	var functions []*Function
+	for _, function := range s.Functions {
+		if function.Name == name {
+			functions = append(functions, function)
+		}
+	}
+	return functions
+}
+
+// Index returns the index with the given name on any table of the schema,
+// together with its table, or nil
+func (s *Schema) Index(name string) (*Index, *Table) {
+	for _, table := range s.Tables {
+		if index := table.Index(name); index != nil {
+			return index, table
+		}
+	}
+	return nil, nil
+}
+
+// Column returns the column with the given name, or nil
+func (t *Table) Column(name string) *Column {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+// Constraint returns the constraint with the given name, or nil
+func (t *Table) Constraint(name string) *Constraint {
+	for _, constraint := range t.Constraints {
+		if constraint.Name == name {
+			return constraint
+		}
+	}
+	return nil
+}
+
+// Index returns the index with the given name, or nil
+func (t *Table) Index(name string) *Index {
+	for _, index := range t.Indexes {
+		if index.Name == name {
+			return index
+		}
+	}
+	return nil
+}
+
+// PrimaryKey returns the primary key constraint of the table, or nil
+func (t *Table) PrimaryKey() *Constraint {
+	for _, constraint := range t.Constraints {
+		if constraint.Kind == ConstraintPrimaryKey {
+			return constraint
+		}
+	}
+	return nil
+}
+
+// InArgs returns the arguments that are passed to the function, which
+// identify it among its overloads
+func (f *Function) InArgs() []FunctionArg {
+	args := []FunctionArg{}
+	for _, arg := range f.Args {
+		switch arg.Mode {
+		case nodes.FUNC_PARAM_OUT, nodes.FUNC_PARAM_TABLE:
+		default:
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// OutArgs returns the OUT, INOUT and TABLE arguments, which are the columns
+// of the result of functions returning a record
+func (f *Function) OutArgs() []FunctionArg {
+	args := []FunctionArg{}
+	for _, arg := range f.Args {
+		switch arg.Mode {
+		case nodes.FUNC_PARAM_OUT, nodes.FUNC_PARAM_INOUT, nodes.FUNC_PARAM_TABLE:
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// qualifiedName joins the non-empty parts of a name with dots
+func qualifiedName(parts ...string) string {
+	nonEmpty := []string{}
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ".")
+}
@@ -0,0 +1,196 @@
+package catalog_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go/catalog"
+)
+
+// describe returns a compact description of the tables of a catalog, one
+// line per table, to compare catalogs in tests
+func describe(c *catalog.Catalog) string {
+	lines := []string{}
+	for _, schema := range c.Schemas {
+		for _, table := range schema.Tables {
+			columns := []string{}
+			for _, column := range table.Columns {
+				str := column.Name + " " + column.Type.String()
+				if column.NotNull {
+					str += " NOT NULL"
+				}
+				if column.Default != "" {
+					str += " DEFAULT " + column.Default
+				}
+				columns = append(columns, str)
+			}
+			constraints := []string{}
+			for _, constraint := range table.Constraints {
+				str := constraint.Name + " " + constraint.Kind.String() + " (" + strings.Join(constraint.Columns, ", ") + ")"
+				if constraint.Kind == catalog.ConstraintForeignKey {
+					str += " " + constraint.RefSchema + "." + constraint.RefTable + " (" + strings.Join(constraint.RefColumns, ", ") + ")"
+				}
+				constraints = append(constraints, str)
+			}
+			indexes := []string{}
+			for _, index := range table.Indexes {
+				indexes = append(indexes, index.Name+" ("+strings.Join(index.Columns, ", ")+")")
+			}
+			lines = append(lines, schema.Name+"."+table.Name+": "+strings.Join(columns, ", ")+
+				"; "+strings.Join(constraints, ", ")+"; "+strings.Join(indexes, ", "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var applyTests = []struct {
+	input    string
+	expected string
+}{
+	{
+		"CREATE TABLE t (id serial PRIMARY KEY, name varchar(20) NOT NULL UNIQUE, created timestamptz DEFAULT now())",
+		"public.t: id integer NOT NULL DEFAULT nextval('t_id_seq'::regclass), name character varying(20) NOT NULL, created timestamp with time zone DEFAULT now(); " +
+			"t_pkey PRIMARY KEY (id), t_name_key UNIQUE (name); t_pkey (id), t_name_key (name)",
+	},
+	{
+		"CREATE TABLE a (id int PRIMARY KEY); CREATE TABLE b (a_id int REFERENCES a, CHECK (a_id > 0))",
+		"public.a: id integer NOT NULL; a_pkey PRIMARY KEY (id); a_pkey (id)\n" +
+			"public.b: a_id integer; b_a_id_fkey FOREIGN KEY (a_id) public.a (id), b_a_id_check CHECK (a_id); ",
+	},
+	{
+		"CREATE TABLE t (a int, b text); ALTER TABLE t ADD COLUMN c bigint NOT NULL DEFAULT 0, DROP COLUMN b, ALTER COLUMN a TYPE bigint",
+		"public.t: a bigint, c bigint NOT NULL DEFAULT 0; ; ",
+	},
+	{
+		"CREATE TABLE t (a int, b int); CREATE UNIQUE INDEX ON t (a, b); CREATE INDEX ON t (a); CREATE INDEX ON t (a)",
+		"public.t: a integer, b integer; ; t_a_b_idx (a, b), t_a_idx (a), t_a_idx1 (a)",
+	},
+	{
+		"CREATE TABLE t (a int PRIMARY KEY); ALTER TABLE t RENAME a TO b; ALTER TABLE t RENAME TO u; ALTER TABLE u RENAME CONSTRAINT t_pkey TO u_pkey",
+		"public.u: b integer NOT NULL; u_pkey PRIMARY KEY (b); u_pkey (b)",
+	},
+	{
+		"CREATE TABLE a (id int PRIMARY KEY); CREATE TABLE b (a_id int REFERENCES a); ALTER TABLE a RENAME id TO a_id; DROP TABLE a CASCADE",
+		"public.b: a_id integer; ; ",
+	},
+	{
+		"CREATE SCHEMA s CREATE TABLE t (a int); SET search_path = s, public; CREATE TABLE u (LIKE t); ALTER TABLE u SET SCHEMA public",
+		"public.u: a integer; ; \ns.t: a integer; ; ",
+	},
+	{
+		"CREATE TYPE mood AS ENUM ('sad', 'happy'); CREATE DOMAIN email AS text NOT NULL; CREATE TABLE t (m mood[], e email); ALTER TYPE mood RENAME TO feeling",
+		"public.t: m public.feeling[], e public.email; ; ",
+	},
+	{
+		"CREATE TABLE t (a int); CREATE VIEW v AS SELECT a, a + 1 AS b, 'x'::text FROM t",
+		"public.t: a integer; ; \npublic.v: a integer, b unknown, text text; ; ",
+	},
+	{
+		"CREATE TABLE t (a int); CREATE TABLE u (b int); CREATE VIEW v AS SELECT a FROM t; CREATE VIEW w AS WITH t AS (SELECT 1) SELECT * FROM v, u; DROP TABLE t CASCADE",
+		"public.u: b integer; ; ",
+	},
+	{
+		"CREATE TABLE t (a int, b int); CREATE VIEW v AS SELECT a FROM t; CREATE VIEW w AS SELECT b FROM t; ALTER TABLE t DROP COLUMN a CASCADE",
+		"public.t: b integer; ; \npublic.w: b integer; ; ",
+	},
+	{
+		"CREATE SCHEMA s; CREATE TABLE s.t (a int); CREATE TABLE u (b int); CREATE VIEW v AS SELECT a, b FROM s.t, u; DROP SCHEMA s CASCADE",
+		"public.u: b integer; ; ",
+	},
+	{
+		"CREATE TABLE t (a int); CREATE VIEW v AS SELECT a FROM t; CREATE VIEW w AS SELECT a FROM v; CREATE OR REPLACE VIEW v AS SELECT a, 1 AS b FROM t; DROP TABLE t CASCADE",
+		"",
+	},
+}
+
+func TestApply(t *testing.T) {
+	for _, test := range applyTests {
+		c := catalog.New()
+		if err := c.Apply(test.input); err != nil {
+			t.Errorf("Apply(%s): %s", test.input, err)
+			continue
+		}
+		if actual := describe(c); actual != test.expected {
+			t.Errorf("Apply(%s):\nexpected %s\n     got %s", test.input, test.expected, actual)
+		}
+	}
+}
+
+var applyErrorTests = []struct {
+	input    string
+	expected string
+}{
+	{"CREATE TABLE t (a int); CREATE TABLE t (b int)", "relation \"t\" already exists"},
+	{"ALTER TABLE t ADD COLUMN a int", "relation \"t\" does not exist"},
+	{"CREATE TABLE t (a int); ALTER TABLE t DROP COLUMN b", "column \"b\" of relation \"t\" does not exist"},
+	{"CREATE TABLE t (a int PRIMARY KEY); ALTER TABLE t ALTER a DROP NOT NULL", "column \"a\" is in a primary key"},
+	{"CREATE TABLE a (id int PRIMARY KEY); CREATE TABLE b (a_id int REFERENCES a); DROP TABLE a", "cannot drop table a because other objects depend on it"},
+	{"CREATE TABLE t (a int); CREATE VIEW v AS SELECT a FROM t; DROP TABLE t", "cannot drop table t because other objects depend on it"},
+	{"CREATE TABLE t (a int); CREATE VIEW v AS SELECT * FROM t; ALTER TABLE t RENAME TO u; DROP TABLE u", "cannot drop table u because other objects depend on it"},
+	{"CREATE TABLE t (a int, b int); CREATE VIEW v AS SELECT b FROM t WHERE a > 0; ALTER TABLE t DROP COLUMN a", "cannot drop column a of table t because other objects depend on it"},
+	{"CREATE TABLE t (a int); CREATE VIEW v AS SELECT a FROM t; CREATE VIEW w AS SELECT a FROM v; ALTER TABLE t RENAME COLUMN a TO b; DROP VIEW v", "cannot drop view v because other objects depend on it"},
+	{"CREATE TABLE t (a int, b int); CREATE VIEW v AS SELECT a, b FROM t; CREATE OR REPLACE VIEW v AS SELECT a FROM t", "cannot drop columns from view"},
+	{"CREATE TABLE t (a int PRIMARY KEY); DROP INDEX t_pkey", "cannot drop index t_pkey because constraint t_pkey on table t requires it"},
+	{"CREATE TYPE e AS ENUM ('a'); ALTER TYPE e ADD VALUE 'a'", "enum label \"a\" already exists"},
+	{"CREATE FUNCTION f(int) RETURNS int AS 'SELECT 1' LANGUAGE sql; CREATE FUNCTION f(integer) RETURNS int AS 'SELECT 2' LANGUAGE sql", "function \"f\" already exists with same argument types"},
+	{"CREATE TABLE s.t (a int)", "schema \"s\" does not exist"},
+}
+
+func TestApplyError(t *testing.T) {
+	for _, test := range applyErrorTests {
+		err := catalog.New().Apply(test.input)
+		if err == nil {
+			t.Errorf("Apply(%s): expected error %q", test.input, test.expected)
+			continue
+		}
+		if err.Error() != test.expected {
+			t.Errorf("Apply(%s): expected error %q, got %q", test.input, test.expected, err.Error())
+		}
+		if applyErr, ok := err.(*catalog.Error); !ok || applyErr.Statement == 0 && strings.Contains(test.input, ";") {
+			t.Errorf("Apply(%s): expected *catalog.Error of a later statement, got %#v", test.input, err)
+		}
+	}
+}
+
+func TestApplyObjects(t *testing.T) {
+	c := catalog.New()
+	err := c.Apply(`CREATE TYPE mood AS ENUM ('sad', 'happy');
+		ALTER TYPE mood ADD VALUE 'ok' BEFORE 'happy';
+		ALTER TYPE mood RENAME VALUE 'sad' TO 'blue';
+		CREATE TYPE pair AS (x int, y int);
+		CREATE FUNCTION f(a int, OUT b text, OUT c int) AS 'SELECT 1' LANGUAGE sql;
+		CREATE FUNCTION g() RETURNS TABLE (x int) AS 'SELECT 1' LANGUAGE plpgsql;
+		CREATE SEQUENCE s;
+		COMMENT ON TYPE pair IS 'A pair'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mood := c.Type("", "mood")
+	if mood == nil || !reflect.DeepEqual(mood.EnumValues, []string{"blue", "ok", "happy"}) {
+		t.Errorf("expected enum values [blue ok happy], got %+v", mood)
+	}
+	pair := c.Type("public", "pair")
+	if pair == nil || pair.Kind != catalog.TypeComposite || len(pair.Attributes) != 2 || pair.Comment != "A pair" {
+		t.Errorf("expected composite type pair, got %+v", pair)
+	}
+
+	functions := c.Functions("", "f")
+	if len(functions) != 1 {
+		t.Fatalf("expected one function f, got %d", len(functions))
+	}
+	if f := functions[0]; f.ReturnType.String() != "record" || len(f.InArgs()) != 1 || len(f.OutArgs()) != 2 || f.Language != "sql" {
+		t.Errorf("unexpected function f: %+v", f)
+	}
+	if g := c.Functions("", "g"); len(g) != 1 || !g[0].ReturnsSet {
+		t.Errorf("expected set-returning function g, got %+v", g)
+	}
+
+	if err := c.Apply("DROP FUNCTION f(int); DROP TYPE pair; DROP SEQUENCE s"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Functions("", "f") != nil || c.Type("", "pair") != nil || c.Sequence("", "s") != nil {
+		t.Errorf("expected dropped objects to be gone")
+	}
+}
@@ -0,0 +1,275 @@
+package catalog
+
+import (
+	"fmt"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// createType returns the schema to create a type in, checking its name
+// isn't taken
+func (c *Catalog) createType(names []string) (*Schema, string, error) {
+	schemaName, name := splitName(names)
+	var schemaPtr *string
+	if schemaName != "" {
+		schemaPtr = &schemaName
+	}
+	schema, err := c.creationSchema(schemaPtr)
+	if err != nil {
+		return nil, "", err
+	}
+	if schema.Type(name) != nil || schema.Table(name) != nil {
+		return nil, "", fmt.Errorf("type \"%s\" already exists", name)
+	}
+	return schema, name, nil
+}
+
+func (c *Catalog) createEnum(stmt nodes.CreateEnumStmt) error {
+	schema, name, err := c.createType(stringList(stmt.TypeName))
+	if err != nil {
+		return err
+	}
+	schema.Types = append(schema.Types, &Type{
+		Schema:     schema.Name,
+		Name:       name,
+		Kind:       TypeEnum,
+		EnumValues: stringList(stmt.Vals),
+	})
+	return nil
+}
+
+func (c *Catalog) alterEnum(stmt nodes.AlterEnumStmt) error {
+	schemaName, name := splitName(stringList(stmt.TypeName))
+	typ := c.Type(schemaName, name)
+	if typ == nil || typ.Kind != TypeEnum {
+		return fmt.Errorf("type \"%s\" does not exist", qualifiedName(schemaName, name))
+	}
+	if stmt.NewVal == nil {
+		return nil
+	}
+	newVal := *stmt.NewVal
+
+	if stmt.OldVal != nil {
+		i := indexOfString(typ.EnumValues, *stmt.OldVal)
+		if i < 0 {
+			return fmt.Errorf("\"%s\" is not an existing enum label", *stmt.OldVal)
+		}
+		if indexOfString(typ.EnumValues, newVal) >= 0 {
+			return fmt.Errorf("enum label \"%s\" already exists", newVal)
+		}
+		typ.EnumValues[i] = newVal
+		return nil
+	}
+
+	if indexOfString(typ.EnumValues, newVal) >= 0 {
+		if stmt.SkipIfNewValExists {
+			return nil
+		}
+		return fmt.Errorf("enum label \"%s\" already exists", newVal)
+	}
+	i := len(typ.EnumValues)
+	if stmt.NewValNeighbor != nil {
+		i = indexOfString(typ.EnumValues, *stmt.NewValNeighbor)
+		if i < 0 {
+			return fmt.Errorf("\"%s\" is not an existing enum label", *stmt.NewValNeighbor)
+		}
+		if stmt.NewValIsAfter {
+			i++
+		}
+	}
+	values := append([]string{}, typ.EnumValues[:i]...)
+	values = append(values, newVal)
+	typ.EnumValues = append(values, typ.EnumValues[i:]...)
+	return nil
+}
+
+func indexOfString(strs []string, str string) int {
+	for i, s := range strs {
+		if s == str {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Catalog) createCompositeType(stmt nodes.CompositeTypeStmt) error {
+	schemaName, name := rangeVarNames(stmt.Typevar)
+	names := []string{name}
+	if schemaName != "" {
+		names = []string{schemaName, name}
+	}
+	schema, name, err := c.createType(names)
+	if err != nil {
+		return err
+	}
+	if relationExists(schema, name) {
+		return fmt.Errorf("relation \"%s\" already exists", name)
+	}
+
+	typ := &Type{Schema: schema.Name, Name: name, Kind: TypeComposite}
+	table := &Table{Schema: schema.Name, Name: name}
+	for _, item := range stmt.Coldeflist.Items {
+		columnDef, ok := item.(nodes.ColumnDef)
+		if !ok {
+			continue
+		}
+		attribute, _, err := c.columnDef(table, columnDef)
+		if err != nil {
+			return err
+		}
+		typ.Attributes = append(typ.Attributes, attribute)
+	}
+	schema.Types = append(schema.Types, typ)
+	return nil
+}
+
+func (c *Catalog) createDomain(stmt nodes.CreateDomainStmt) error {
+	schema, name, err := c.createType(stringList(stmt.Domainname))
+	if err != nil {
+		return err
+	}
+	typ := &Type{Schema: schema.Name, Name: name, Kind: TypeDomain}
+	if stmt.TypeName != nil {
+		if typ.BaseType, err = c.ResolveType(*stmt.TypeName); err != nil {
+			return err
+		}
+	}
+	for _, item := range stmt.Constraints.Items {
+		if constraint, ok := item.(nodes.Constraint); ok {
+			switch constraint.Contype {
+			case nodes.CONSTR_NOTNULL:
+				typ.NotNull = true
+			case nodes.CONSTR_NULL:
+				typ.NotNull = false
+			}
+		}
+	}
+	schema.Types = append(schema.Types, typ)
+	return nil
+}
+
+func (c *Catalog) createFunction(stmt nodes.CreateFunctionStmt) error {
+	schemaName, name := splitName(stringList(stmt.Funcname))
+	var schemaPtr *string
+	if schemaName != "" {
+		schemaPtr = &schemaName
+	}
+	schema, err := c.creationSchema(schemaPtr)
+	if err != nil {
+		return err
+	}
+
+	function := &Function{Schema: schema.Name, Name: name}
+	returnsTable := false
+	for _, item := range stmt.Parameters.Items {
+		parameter, ok := item.(nodes.FunctionParameter)
+		if !ok {
+			continue
+		}
+		arg := FunctionArg{Mode: parameter.Mode, HasDefault: parameter.Defexpr != nil}
+		if arg.Mode == 0 {
+			arg.Mode = nodes.FUNC_PARAM_IN
+		}
+		if parameter.Name != nil {
+			arg.Name = *parameter.Name
+		}
+		if parameter.ArgType != nil {
+			if arg.Type, err = c.ResolveType(*parameter.ArgType); err != nil {
+				return err
+			}
+		}
+		if arg.Mode == nodes.FUNC_PARAM_TABLE {
+			returnsTable = true
+		}
+		function.Args = append(function.Args, arg)
+	}
+
+	switch outArgs := function.OutArgs(); {
+	case returnsTable:
+		function.ReturnType = BuiltinType("record")
+		function.ReturnsSet = true
+	case stmt.ReturnType != nil:
+		if function.ReturnType, err = c.ResolveType(*stmt.ReturnType); err != nil {
+			return err
+		}
+		function.ReturnsSet = stmt.ReturnType.Setof
+	case len(outArgs) == 1:
+		function.ReturnType = outArgs[0].Type
+	case len(outArgs) > 1:
+		function.ReturnType = BuiltinType("record")
+	default:
+		function.ReturnType = BuiltinType("void")
+	}
+
+	for _, item := range stmt.Options.Items {
+		defElem, ok := item.(nodes.DefElem)
+		if !ok || defElem.Defname == nil || *defElem.Defname != "language" {
+			continue
+		}
+		if str, ok := defElem.Arg.(nodes.String); ok {
+			function.Language = str.Str
+		}
+	}
+
+	for _, existing := range schema.FunctionsNamed(name) {
+		if !sameArgTypes(existing.InArgs(), function.InArgs()) {
+			continue
+		}
+		if !stmt.Replace {
+			return fmt.Errorf("function \"%s\" already exists with same argument types", name)
+		}
+		function.Owner = existing.Owner
+		*existing = *function
+		return nil
+	}
+	schema.Functions = append(schema.Functions, function)
+	return nil
+}
+
+func sameArgTypes(a []FunctionArg, b []FunctionArg) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Type.Equal(b[i].Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// findFunction returns the function an ObjectWithArgs refers to. Without an
+// argument list the name must identify a single function.
+func (c *Catalog) findFunction(object nodes.ObjectWithArgs) (*Function, error) {
+	schemaName, name := splitName(stringList(object.Objname))
+	functions := c.Functions(schemaName, name)
+	if object.ArgsUnspecified {
+		switch len(functions) {
+		case 0:
+			return nil, fmt.Errorf("function %s does not exist", qualifiedName(schemaName, name))
+		case 1:
+			return functions[0], nil
+		default:
+			return nil, fmt.Errorf("function name \"%s\" is not unique", qualifiedName(schemaName, name))
+		}
+	}
+
+	args := []FunctionArg{}
+	for _, item := range object.Objargs.Items {
+		typeName, ok := item.(nodes.TypeName)
+		if !ok {
+			continue
+		}
+		argType, err := c.ResolveType(typeName)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, FunctionArg{Type: argType})
+	}
+	for _, function := range functions {
+		if sameArgTypes(function.InArgs(), args) {
+			return function, nil
+		}
+	}
+	return nil, fmt.Errorf("function %s does not exist", qualifiedName(schemaName, name))
+}
@@ -0,0 +1,723 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	pg_query "github.com/tomaszjonak/pg_query_go"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// TableLikeOption flags of LIKE clauses, see TableLikeOption in parsenodes.h
+const (
+	likeIncludingDefaults    = 1 << 0
+	likeIncludingConstraints = 1 << 1
+)
+
+func (c *Catalog) createTable(stmt nodes.CreateStmt) error {
+	schema, err := c.creationSchema(stmt.Relation.Schemaname)
+	if err != nil {
+		return err
+	}
+	name := *stmt.Relation.Relname
+	if relationExists(schema, name) {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("relation \"%s\" already exists", name)
+	}
+	table := &Table{Schema: schema.Name, Name: name, Kind: KindTable}
+
+	// Parent tables and partitioned tables come first, then the type of a
+	// typed table
+	for _, item := range stmt.InhRelations.Items {
+		rangeVar, ok := item.(nodes.RangeVar)
+		if !ok {
+			continue
+		}
+		parent, err := c.findTable(&rangeVar, KindTable)
+		if err != nil {
+			return err
+		}
+		for _, column := range parent.Columns {
+			if err := mergeColumn(table, copyColumn(column, true)); err != nil {
+				return err
+			}
+		}
+		for _, constraint := range parent.Constraints {
+			if constraint.Kind == ConstraintCheck {
+				copied := *constraint
+				table.Constraints = append(table.Constraints, &copied)
+			}
+		}
+	}
+	if stmt.OfTypename != nil {
+		typeName, err := c.ResolveType(*stmt.OfTypename)
+		if err != nil {
+			return err
+		}
+		typ := c.Type(typeName.Schema, typeName.Name)
+		if typ == nil || typ.Kind != TypeComposite {
+			return fmt.Errorf("type %s is not a composite type", typeName)
+		}
+		for _, attribute := range typ.Attributes {
+			table.Columns = append(table.Columns, copyColumn(attribute, false))
+		}
+	}
+
+	var constraints []pendingConstraint
+	for _, item := range stmt.TableElts.Items {
+		switch elt := item.(type) {
+		case nodes.ColumnDef:
+			column, columnConstraints, err := c.columnDef(table, elt)
+			if err != nil {
+				return err
+			}
+			if err := mergeColumn(table, column); err != nil {
+				return err
+			}
+			constraints = append(constraints, columnConstraints...)
+		case nodes.TableLikeClause:
+			like, err := c.findTable(elt.Relation)
+			if err != nil {
+				return err
+			}
+			for _, column := range like.Columns {
+				if err := mergeColumn(table, copyColumn(column, elt.Options&likeIncludingDefaults != 0)); err != nil {
+					return err
+				}
+			}
+			if elt.Options&likeIncludingConstraints != 0 {
+				for _, constraint := range like.Constraints {
+					if constraint.Kind == ConstraintCheck {
+						copied := *constraint
+						copied.Name = chooseConstraintName(table, constraint.Columns, "check")
+						table.Constraints = append(table.Constraints, &copied)
+					}
+				}
+			}
+		case nodes.Constraint:
+			constraints = append(constraints, pendingConstraint{constraint: elt})
+		}
+	}
+
+	for _, pending := range constraints {
+		if err := c.addConstraint(schema, table, pending.constraint, pending.column); err != nil {
+			return err
+		}
+	}
+	schema.Tables = append(schema.Tables, table)
+	for _, item := range stmt.TableElts.Items {
+		if columnDef, ok := item.(nodes.ColumnDef); ok && isSerial(columnDef) {
+			addSerialSequence(schema, table, *columnDef.Colname)
+		}
+	}
+	return nil
+}
+
+// pendingConstraint is a constraint to add once all columns are known, with
+// the column it was defined on for column constraints
+type pendingConstraint struct {
+	constraint nodes.Constraint
+	column     string
+}
+
+func copyColumn(column *Column, withDefault bool) *Column {
+	copied := *column
+	copied.Comment = ""
+	if !withDefault {
+		copied.Default = ""
+	}
+	return &copied
+}
+
+// mergeColumn adds a column to the table, merging it with an inherited
+// column of the same name, which must have the same type
+func mergeColumn(table *Table, column *Column) error {
+	existing := table.Column(column.Name)
+	if existing == nil {
+		table.Columns = append(table.Columns, column)
+		return nil
+	}
+	if !existing.Type.Equal(column.Type) {
+		return fmt.Errorf("column \"%s\" has a type conflict", column.Name)
+	}
+	existing.NotNull = existing.NotNull || column.NotNull
+	if column.Default != "" {
+		existing.Default = column.Default
+	}
+	return nil
+}
+
+// columnDef returns the column defined by a ColumnDef node, together with
+// the constraints to add to the table for it
+func (c *Catalog) columnDef(table *Table, columnDef nodes.ColumnDef) (*Column, []pendingConstraint, error) {
+	column := &Column{Name: *columnDef.Colname, NotNull: columnDef.IsNotNull}
+	if columnDef.TypeName != nil {
+		if integerType, ok := serialType(*columnDef.TypeName); ok {
+			column.Type = BuiltinType(integerType)
+			column.NotNull = true
+			column.Default = fmt.Sprintf("nextval('%s'::regclass)", serialSequenceName(table, column.Name))
+		} else {
+			typeName, err := c.ResolveType(*columnDef.TypeName)
+			if err != nil {
+				return nil, nil, err
+			}
+			column.Type = typeName
+		}
+	}
+	if columnDef.RawDefault != nil {
+		deparsed, err := pg_query.DeparseItem(columnDef.RawDefault)
+		if err != nil {
+			return nil, nil, err
+		}
+		column.Default = deparsed
+	}
+
+	var constraints []pendingConstraint
+	for _, item := range columnDef.Constraints.Items {
+		constraint, ok := item.(nodes.Constraint)
+		if !ok {
+			continue
+		}
+		switch constraint.Contype {
+		case nodes.CONSTR_NOTNULL, nodes.CONSTR_IDENTITY:
+			column.NotNull = true
+		case nodes.CONSTR_NULL:
+			column.NotNull = false
+		case nodes.CONSTR_DEFAULT:
+			deparsed, err := pg_query.DeparseItem(constraint.RawExpr)
+			if err != nil {
+				return nil, nil, err
+			}
+			column.Default = deparsed
+		case nodes.CONSTR_PRIMARY, nodes.CONSTR_UNIQUE, nodes.CONSTR_CHECK, nodes.CONSTR_FOREIGN, nodes.CONSTR_EXCLUSION:
+			constraints = append(constraints, pendingConstraint{constraint: constraint, column: column.Name})
+		}
+	}
+	return column, constraints, nil
+}
+
+// serialType returns the integer type a serial type name stands for
+func serialType(typeName nodes.TypeName) (string, bool) {
+	names := stringList(typeName.Names)
+	if len(names) != 1 || len(typeName.ArrayBounds.Items) > 0 {
+		return "", false
+	}
+	integerType, ok := serialTypes[names[0]]
+	return integerType, ok
+}
+
+func serialSequenceName(table *Table, column string) string {
+	return table.Name + "_" + column + "_seq"
+}
+
+func isSerial(columnDef nodes.ColumnDef) bool {
+	if columnDef.TypeName == nil {
+		return false
+	}
+	_, ok := serialType(*columnDef.TypeName)
+	return ok
+}
+
+// addSerialSequence creates the sequence of a serial column
+func addSerialSequence(schema *Schema, table *Table, column string) {
+	schema.Sequences = append(schema.Sequences, &Sequence{
+		Schema:        schema.Name,
+		Name:          serialSequenceName(table, column),
+		Owner:         table.Owner,
+		OwnedByTable:  table.Name,
+		OwnedByColumn: column,
+	})
+}
+
+// addConstraint adds a table constraint, or a constraint defined on the given
+// column
+func (c *Catalog) addConstraint(schema *Schema, table *Table, node nodes.Constraint, column string) error {
+	constraint := &Constraint{NotValid: node.SkipValidation}
+	if node.Conname != nil {
+		constraint.Name = *node.Conname
+		if table.Constraint(constraint.Name) != nil {
+			return fmt.Errorf("constraint \"%s\" for relation \"%s\" already exists", constraint.Name, table.Name)
+		}
+	}
+
+	columns := stringList(node.Keys)
+	if len(columns) == 0 && column != "" {
+		columns = []string{column}
+	}
+
+	switch node.Contype {
+	case nodes.CONSTR_PRIMARY, nodes.CONSTR_UNIQUE, nodes.CONSTR_EXCLUSION:
+		index := &Index{Unique: node.Contype != nodes.CONSTR_EXCLUSION, Primary: node.Contype == nodes.CONSTR_PRIMARY, Method: "btree"}
+		suffix := "key"
+		switch node.Contype {
+		case nodes.CONSTR_PRIMARY:
+			constraint.Kind = ConstraintPrimaryKey
+			suffix = "pkey"
+			if table.PrimaryKey() != nil {
+				return fmt.Errorf("multiple primary keys for table \"%s\" are not allowed", table.Name)
+			}
+		case nodes.CONSTR_UNIQUE:
+			constraint.Kind = ConstraintUnique
+		case nodes.CONSTR_EXCLUSION:
+			constraint.Kind = ConstraintExclusion
+			suffix = "excl"
+			index.Method = "gist"
+			if node.AccessMethod != nil {
+				index.Method = *node.AccessMethod
+			}
+			for _, item := range node.Exclusions.Items {
+				pair, ok := item.(nodes.List)
+				if !ok || len(pair.Items) == 0 {
+					continue
+				}
+				if indexElem, ok := pair.Items[0].(nodes.IndexElem); ok && indexElem.Name != nil {
+					columns = append(columns, *indexElem.Name)
+				}
+			}
+		}
+
+		if node.Indexname != nil {
+			// USING INDEX turns an existing unique index into the constraint,
+			// renaming it to the name of the constraint
+			existing := table.Index(*node.Indexname)
+			if existing == nil {
+				return fmt.Errorf("index \"%s\" does not exist", *node.Indexname)
+			}
+			if !existing.Unique {
+				return fmt.Errorf("\"%s\" is not a unique index", existing.Name)
+			}
+			index = existing
+			index.Primary = node.Contype == nodes.CONSTR_PRIMARY
+			columns = index.Columns
+		} else {
+			index.Columns = columns
+		}
+		if err := checkColumns(table, columns); err != nil {
+			return err
+		}
+
+		if constraint.Name == "" {
+			if node.Indexname != nil {
+				constraint.Name = index.Name
+			} else if node.Contype == nodes.CONSTR_PRIMARY {
+				constraint.Name = chooseRelationName(schema, table, nil, suffix)
+			} else {
+				constraint.Name = chooseRelationName(schema, table, columns, suffix)
+			}
+		} else if node.Indexname == nil && (relationExists(schema, constraint.Name) || table.Index(constraint.Name) != nil) {
+			return fmt.Errorf("relation \"%s\" already exists", constraint.Name)
+		}
+		index.Name = constraint.Name
+		constraint.Columns = columns
+		if node.Contype == nodes.CONSTR_PRIMARY {
+			for _, name := range columns {
+				table.Column(name).NotNull = true
+			}
+		}
+		if node.Indexname == nil {
+			table.Indexes = append(table.Indexes, index)
+		}
+	case nodes.CONSTR_CHECK:
+		constraint.Kind = ConstraintCheck
+		expression, err := pg_query.DeparseItem(node.RawExpr)
+		if err != nil {
+			return err
+		}
+		constraint.Expression = expression
+		if column != "" {
+			constraint.Columns = columns
+		} else {
+			constraint.Columns = referencedColumns(table, node.RawExpr)
+		}
+		if constraint.Name == "" {
+			constraint.Name = chooseConstraintName(table, constraint.Columns, "check")
+		}
+	case nodes.CONSTR_FOREIGN:
+		constraint.Kind = ConstraintForeignKey
+		columns = stringList(node.FkAttrs)
+		if len(columns) == 0 && column != "" {
+			columns = []string{column}
+		}
+		if err := checkColumns(table, columns); err != nil {
+			return err
+		}
+
+		// A table can reference itself while it's being created
+		refSchema, refName := rangeVarNames(node.Pktable)
+		refTable := table
+		if refName != table.Name || (refSchema != "" && refSchema != table.Schema) {
+			var err error
+			refTable, err = c.findTable(node.Pktable, KindTable)
+			if err != nil {
+				return err
+			}
+		}
+		refColumns := stringList(node.PkAttrs)
+		if len(refColumns) == 0 {
+			primaryKey := refTable.PrimaryKey()
+			if primaryKey == nil {
+				return fmt.Errorf("there is no primary key for referenced table \"%s\"", refTable.Name)
+			}
+			refColumns = primaryKey.Columns
+		}
+		if err := checkColumns(refTable, refColumns); err != nil {
+			return err
+		}
+		if len(refColumns) != len(columns) {
+			return fmt.Errorf("number of referencing and referenced columns for foreign key disagree")
+		}
+		constraint.Columns = columns
+		constraint.RefSchema, constraint.RefTable, constraint.RefColumns = refTable.Schema, refTable.Name, refColumns
+		if constraint.Name == "" {
+			constraint.Name = chooseConstraintName(table, columns, "fkey")
+		}
+	default:
+		return nil
+	}
+
+	table.Constraints = append(table.Constraints, constraint)
+	return nil
+}
+
+func checkColumns(table *Table, columns []string) error {
+	for _, name := range columns {
+		if table.Column(name) == nil {
+			return fmt.Errorf("column \"%s\" named in key does not exist", name)
+		}
+	}
+	return nil
+}
+
+// referencedColumns returns the columns of the table an expression refers
+// to, in order of their first appearance
+func referencedColumns(table *Table, node nodes.Node) []string {
+	columns := []string{}
+	seen := map[string]bool{}
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		if columnRef, ok := node.(nodes.ColumnRef); ok {
+			names := stringList(columnRef.Fields)
+			if len(names) > 0 {
+				name := names[len(names)-1]
+				if table.Column(name) != nil && !seen[name] {
+					seen[name] = true
+					columns = append(columns, name)
+				}
+			}
+		}
+		return true
+	})
+	return columns
+}
+
+// chooseName returns the default name of a constraint or index, made of the
+// table name, the first column name and a suffix, with a number appended to
+// the suffix if the name is taken, as ChooseRelationName in PostgreSQL
+func chooseName(tableName string, columns []string, suffix string, taken func(string) bool) string {
+	parts := []string{tableName}
+	if len(columns) > 0 {
+		parts = append(parts, strings.Join(columns, "_"))
+	}
+	base := strings.Join(parts, "_")
+	for i := 0; ; i++ {
+		name := base + "_" + suffix
+		if i > 0 {
+			name += fmt.Sprint(i)
+		}
+		if !taken(name) {
+			return name
+		}
+	}
+}
+
+// chooseRelationName returns the default name of an index, which must be
+// unique among the relations of the schema, including the indexes of a table
+// that is being created
+func chooseRelationName(schema *Schema, table *Table, columns []string, suffix string) string {
+	return chooseName(table.Name, columns, suffix, func(name string) bool {
+		return relationExists(schema, name) || table.Index(name) != nil
+	})
+}
+
+func chooseConstraintName(table *Table, columns []string, suffix string) string {
+	if len(columns) > 1 {
+		columns = columns[:1]
+	}
+	return chooseName(table.Name, columns, suffix, func(name string) bool {
+		return table.Constraint(name) != nil
+	})
+}
+
+func (c *Catalog) createIndex(stmt nodes.IndexStmt) error {
+	table, err := c.findTable(stmt.Relation, KindTable, KindMaterializedView)
+	if err != nil {
+		return err
+	}
+	schema := c.Schema(table.Schema)
+
+	index := &Index{Unique: stmt.Unique, Primary: stmt.Primary, Method: "btree"}
+	if stmt.AccessMethod != nil {
+		index.Method = *stmt.AccessMethod
+	}
+	nameColumns := []string{}
+	for _, item := range stmt.IndexParams.Items {
+		indexElem, ok := item.(nodes.IndexElem)
+		if !ok {
+			continue
+		}
+		if indexElem.Name != nil {
+			if table.Column(*indexElem.Name) == nil {
+				return fmt.Errorf("column \"%s\" does not exist", *indexElem.Name)
+			}
+			index.Columns = append(index.Columns, *indexElem.Name)
+			nameColumns = append(nameColumns, *indexElem.Name)
+			continue
+		}
+		expression, err := pg_query.DeparseItem(indexElem.Expr)
+		if err != nil {
+			return err
+		}
+		index.Columns = append(index.Columns, expression)
+		nameColumns = append(nameColumns, "expr")
+	}
+	if stmt.WhereClause != nil {
+		if index.Where, err = pg_query.DeparseItem(stmt.WhereClause); err != nil {
+			return err
+		}
+	}
+
+	if stmt.Idxname != nil {
+		index.Name = *stmt.Idxname
+		if relationExists(schema, index.Name) {
+			if stmt.IfNotExists {
+				return nil
+			}
+			return fmt.Errorf("relation \"%s\" already exists", index.Name)
+		}
+	} else {
+		index.Name = chooseRelationName(schema, table, nameColumns, "idx")
+	}
+	table.Indexes = append(table.Indexes, index)
+	return nil
+}
+
+func (c *Catalog) createView(stmt nodes.ViewStmt) error {
+	schema, err := c.creationSchema(stmt.View.Schemaname)
+	if err != nil {
+		return err
+	}
+	name := *stmt.View.Relname
+	columns, dependencies := c.analyzeQuery(stmt.Query)
+	if err := renameColumns(columns, stringList(stmt.Aliases)); err != nil {
+		return err
+	}
+
+	if existing := schema.Table(name); existing != nil && stmt.Replace {
+		if existing.Kind != KindView {
+			return fmt.Errorf("\"%s\" is not a view", name)
+		}
+		if err := replaceViewColumns(existing, columns); err != nil {
+			return err
+		}
+		existing.Query = stmt.Query
+		existing.Dependencies = dependencies
+		return nil
+	}
+	if relationExists(schema, name) {
+		return fmt.Errorf("relation \"%s\" already exists", name)
+	}
+	schema.Tables = append(schema.Tables, &Table{Schema: schema.Name, Name: name, Kind: KindView, Columns: columns, Query: stmt.Query, Dependencies: dependencies})
+	return nil
+}
+
+// replaceViewColumns updates the columns of a view for CREATE OR REPLACE
+// VIEW in place, so that the views depending on them keep doing so. As in
+// PostgreSQL, the new query can only add columns at the end.
+func replaceViewColumns(view *Table, columns []*Column) error {
+	if len(columns) < len(view.Columns) {
+		return fmt.Errorf("cannot drop columns from view")
+	}
+	for i, column := range view.Columns {
+		if column.Name != columns[i].Name {
+			return fmt.Errorf("cannot change name of view column \"%s\" to \"%s\"", column.Name, columns[i].Name)
+		}
+		if !column.Type.IsUnknown() && !columns[i].Type.IsUnknown() && !column.Type.Equal(columns[i].Type) {
+			return fmt.Errorf("cannot change data type of view column \"%s\" from %s to %s", column.Name, column.Type, columns[i].Type)
+		}
+	}
+	for i, column := range view.Columns {
+		column.Type = columns[i].Type
+		column.Origins = columns[i].Origins
+	}
+	view.Columns = append(view.Columns, columns[len(view.Columns):]...)
+	return nil
+}
+
+func (c *Catalog) createTableAs(stmt nodes.CreateTableAsStmt) error {
+	if stmt.Into == nil || stmt.Into.Rel == nil {
+		return nil
+	}
+	schema, err := c.creationSchema(stmt.Into.Rel.Schemaname)
+	if err != nil {
+		return err
+	}
+	name := *stmt.Into.Rel.Relname
+	if relationExists(schema, name) {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("relation \"%s\" already exists", name)
+	}
+	columns, dependencies := c.analyzeQuery(stmt.Query)
+	table := &Table{Schema: schema.Name, Name: name, Kind: KindTable, Columns: columns}
+	if stmt.Relkind == nodes.OBJECT_MATVIEW {
+		table.Kind = KindMaterializedView
+		table.Query = stmt.Query
+		table.Dependencies = dependencies
+	} else {
+		// The columns of a table hold their own values
+		for _, column := range columns {
+			column.Origins = nil
+		}
+	}
+	if err := renameColumns(table.Columns, stringList(stmt.Into.ColNames)); err != nil {
+		return err
+	}
+	schema.Tables = append(schema.Tables, table)
+	return nil
+}
+
+// renameColumns applies a list of column aliases to the first columns
+func renameColumns(columns []*Column, names []string) error {
+	if len(names) > len(columns) {
+		return fmt.Errorf("too many column names were specified")
+	}
+	for i, name := range names {
+		columns[i].Name = name
+	}
+	return nil
+}
+
+func isStar(columnRef nodes.ColumnRef) bool {
+	if len(columnRef.Fields.Items) == 0 {
+		return false
+	}
+	_, ok := columnRef.Fields.Items[len(columnRef.Fields.Items)-1].(nodes.A_Star)
+	return ok
+}
+
+// FigureColumnName returns the name PostgreSQL gives to a result column
+// without an alias: the name of a column, function or type, or "?column?"
+func FigureColumnName(node nodes.Node) string {
+	switch node := node.(type) {
+	case nodes.ColumnRef:
+		names := stringList(node.Fields)
+		if len(names) > 0 && !isStar(node) {
+			return names[len(names)-1]
+		}
+	case nodes.A_Indirection:
+		for i := len(node.Indirection.Items) - 1; i >= 0; i-- {
+			if str, ok := node.Indirection.Items[i].(nodes.String); ok {
+				return str.Str
+			}
+		}
+		return FigureColumnName(node.Arg)
+	case nodes.FuncCall:
+		names := stringList(node.Funcname)
+		if len(names) > 0 {
+			return names[len(names)-1]
+		}
+	case nodes.A_Expr:
+		if node.Kind == nodes.AEXPR_NULLIF {
+			return "nullif"
+		}
+		if node.Kind == nodes.AEXPR_PAREN {
+			return FigureColumnName(node.Lexpr)
+		}
+	case nodes.TypeCast:
+		if name := FigureColumnName(node.Arg); name != "?column?" {
+			return name
+		}
+		if node.TypeName != nil {
+			names := stringList(node.TypeName.Names)
+			if len(names) > 0 {
+				return names[len(names)-1]
+			}
+		}
+	case nodes.CollateClause:
+		return FigureColumnName(node.Arg)
+	case nodes.SubLink:
+		switch node.SubLinkType {
+		case nodes.EXISTS_SUBLINK:
+			return "exists"
+		case nodes.ARRAY_SUBLINK:
+			return "array"
+		case nodes.EXPR_SUBLINK:
+			if stmt, ok := node.Subselect.(nodes.SelectStmt); ok && len(stmt.TargetList.Items) == 1 {
+				if target, ok := stmt.TargetList.Items[0].(nodes.ResTarget); ok {
+					if target.Name != nil {
+						return *target.Name
+					}
+					return FigureColumnName(target.Val)
+				}
+			}
+		}
+	case nodes.CaseExpr:
+		if name := FigureColumnName(node.Defresult); name != "?column?" {
+			return name
+		}
+		return "case"
+	case nodes.A_ArrayExpr:
+		return "array"
+	case nodes.RowExpr:
+		return "row"
+	case nodes.CoalesceExpr:
+		return "coalesce"
+	case nodes.MinMaxExpr:
+		if node.Op == nodes.IS_GREATEST {
+			return "greatest"
+		}
+		return "least"
+	case nodes.SQLValueFunction:
+		return sqlValueFunctionNames[node.Op]
+	case nodes.XmlExpr:
+		return xmlExprNames[node.Op]
+	case nodes.XmlSerialize:
+		return "xmlserialize"
+	case nodes.GroupingFunc:
+		return "grouping"
+	}
+	return "?column?"
+}
+
+// Column names of SQL value functions, by their op
+var sqlValueFunctionNames = map[nodes.SQLValueFunctionOp]string{
+	nodes.SVFOP_CURRENT_DATE:        "current_date",
+	nodes.SVFOP_CURRENT_TIME:        "current_time",
+	nodes.SVFOP_CURRENT_TIME_N:      "current_time",
+	nodes.SVFOP_CURRENT_TIMESTAMP:   "current_timestamp",
+	nodes.SVFOP_CURRENT_TIMESTAMP_N: "current_timestamp",
+	nodes.SVFOP_LOCALTIME:           "localtime",
+	nodes.SVFOP_LOCALTIME_N:         "localtime",
+	nodes.SVFOP_LOCALTIMESTAMP:      "localtimestamp",
+	nodes.SVFOP_LOCALTIMESTAMP_N:    "localtimestamp",
+	nodes.SVFOP_CURRENT_ROLE:        "current_role",
+	nodes.SVFOP_CURRENT_USER:        "current_user",
+	nodes.SVFOP_USER:                "user",
+	nodes.SVFOP_SESSION_USER:        "session_user",
+	nodes.SVFOP_CURRENT_CATALOG:     "current_catalog",
+	nodes.SVFOP_CURRENT_SCHEMA:      "current_schema",
+}
+
+// Column names of XML functions, by their op
+var xmlExprNames = map[nodes.XmlExprOp]string{
+	nodes.IS_XMLCONCAT:    "xmlconcat",
+	nodes.IS_XMLELEMENT:   "xmlelement",
+	nodes.IS_XMLFOREST:    "xmlforest",
+	nodes.IS_XMLPARSE:     "xmlparse",
+	nodes.IS_XMLPI:        "xmlpi",
+	nodes.IS_XMLROOT:      "xmlroot",
+	nodes.IS_XMLSERIALIZE: "xmlserialize",
+	nodes.IS_DOCUMENT:     "?column?",
+}
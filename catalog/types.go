@@ -0,0 +1,249 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// TypeName identifies a type. Built-in types are in the pg_catalog schema and
+// use their internal names, e.g. int4 for integer and varchar for character
+// varying.
+type TypeName struct {
+	// Schema of the type, empty if it's neither a built-in type nor defined
+	// in the catalog, e.g. a type created by an extension
+	Schema string
+	Name   string
+
+	// Type modifiers, e.g. the length of varchar(20)
+	Modifiers []int
+
+	// Number of array dimensions, 0 if the type isn't an array
+	ArrayDims int
+}
+
+// BuiltinType returns the built-in type with the given internal name
+func BuiltinType(name string) TypeName {
+	return TypeName{Schema: "pg_catalog", Name: name}
+}
+
+// IsBuiltin returns whether the type is a built-in type
+func (t TypeName) IsBuiltin() bool {
+	return t.Schema == "pg_catalog"
+}
+
+// IsUnknown returns whether the type couldn't be determined at all, e.g. for
+// an expression
+func (t TypeName) IsUnknown() bool {
+	return t.Name == ""
+}
+
+// Elem returns the element type of an array type
+func (t TypeName) Elem() TypeName {
+	if t.ArrayDims > 0 {
+		t.ArrayDims--
+	}
+	return t
+}
+
+// Array returns the array type whose elements are of this type
+func (t TypeName) Array() TypeName {
+	t.ArrayDims++
+	return t
+}
+
+// Equal returns whether both names refer to the same type, ignoring type
+// modifiers
+func (t TypeName) Equal(other TypeName) bool {
+	return t.Schema == other.Schema && t.Name == other.Name && t.ArrayDims == other.ArrayDims
+}
+
+// SQL names of built-in types, by their internal name
+var sqlTypeNames = map[string]string{
+	"bool":        "boolean",
+	"bpchar":      "character",
+	"float4":      "real",
+	"float8":      "double precision",
+	"int2":        "smallint",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"timestamptz": "timestamp with time zone",
+	"timetz":      "time with time zone",
+	"varbit":      "bit varying",
+	"varchar":     "character varying",
+}
+
+// String returns the type as written in SQL, e.g. "character varying(20)[]"
+func (t TypeName) String() string {
+	if t.IsUnknown() {
+		return "unknown"
+	}
+
+	name := t.Name
+	modifiers := ""
+	if len(t.Modifiers) > 0 && t.Name != "interval" {
+		parts := make([]string, len(t.Modifiers))
+		for i, modifier := range t.Modifiers {
+			parts[i] = fmt.Sprint(modifier)
+		}
+		modifiers = "(" + strings.Join(parts, ",") + ")"
+	}
+	if t.IsBuiltin() {
+		if sqlName, ok := sqlTypeNames[t.Name]; ok {
+			name = sqlName
+		}
+		// The modifiers of time types go before WITH TIME ZONE
+		if strings.HasSuffix(name, " with time zone") {
+			name = strings.Replace(name, " with time zone", modifiers+" with time zone", 1)
+			modifiers = ""
+		}
+	} else {
+		name = qualifiedName(t.Schema, t.Name)
+	}
+	return name + modifiers + strings.Repeat("[]", t.ArrayDims)
+}
+
+// Internal names of the built-in types that can be used in table definitions
+var builtinTypes = map[string]bool{
+	"aclitem":       true,
+	"bit":           true,
+	"bool":          true,
+	"box":           true,
+	"bpchar":        true,
+	"bytea":         true,
+	"char":          true,
+	"cidr":          true,
+	"circle":        true,
+	"date":          true,
+	"daterange":     true,
+	"float4":        true,
+	"float8":        true,
+	"inet":          true,
+	"int2":          true,
+	"int4":          true,
+	"int4range":     true,
+	"int8":          true,
+	"int8range":     true,
+	"interval":      true,
+	"json":          true,
+	"jsonb":         true,
+	"line":          true,
+	"lseg":          true,
+	"macaddr":       true,
+	"macaddr8":      true,
+	"money":         true,
+	"name":          true,
+	"numeric":       true,
+	"numrange":      true,
+	"oid":           true,
+	"path":          true,
+	"pg_lsn":        true,
+	"point":         true,
+	"polygon":       true,
+	"record":        true,
+	"refcursor":     true,
+	"regclass":      true,
+	"regconfig":     true,
+	"regproc":       true,
+	"regprocedure":  true,
+	"regtype":       true,
+	"text":          true,
+	"tid":           true,
+	"time":          true,
+	"timestamp":     true,
+	"timestamptz":   true,
+	"timetz":        true,
+	"trigger":       true,
+	"tsquery":       true,
+	"tsrange":       true,
+	"tstzrange":     true,
+	"tsvector":      true,
+	"txid_snapshot": true,
+	"uuid":          true,
+	"varbit":        true,
+	"varchar":       true,
+	"void":          true,
+	"xid":           true,
+	"xml":           true,
+}
+
+// Serial types, by the internal name of the integer type they stand for
+var serialTypes = map[string]string{
+	"bigserial":   "int8",
+	"serial":      "int4",
+	"serial2":     "int2",
+	"serial4":     "int4",
+	"serial8":     "int8",
+	"smallserial": "int2",
+}
+
+// ResolveType returns the type a parsed type name refers to. Unqualified
+// names are looked up in the search path first and then among the built-in
+// types; names that are found in neither are kept without a schema. A %TYPE
+// reference resolves to the type of the column it names.
+func (c *Catalog) ResolveType(node nodes.TypeName) (TypeName, error) {
+	names := stringList(node.Names)
+	if node.PctType {
+		return c.resolvePctType(names)
+	}
+	if len(names) == 0 {
+		return TypeName{}, fmt.Errorf("invalid type name")
+	}
+
+	typeName := TypeName{ArrayDims: len(node.ArrayBounds.Items)}
+	for _, item := range node.Typmods.Items {
+		if aConst, ok := item.(nodes.A_Const); ok {
+			if integer, ok := aConst.Val.(nodes.Integer); ok {
+				typeName.Modifiers = append(typeName.Modifiers, int(integer.Ival))
+			}
+		}
+	}
+
+	name := names[len(names)-1]
+	schema := ""
+	if len(names) > 1 {
+		schema = names[len(names)-2]
+	}
+	if typ := c.Type(schema, name); typ != nil {
+		typeName.Schema, typeName.Name = typ.Schema, typ.Name
+	} else if table := c.Table(schema, name); table != nil {
+		typeName.Schema, typeName.Name = table.Schema, table.Name
+	} else if schema == "" && builtinTypes[name] {
+		typeName.Schema, typeName.Name = "pg_catalog", name
+	} else {
+		typeName.Schema, typeName.Name = schema, name
+	}
+	return typeName, nil
+}
+
+func (c *Catalog) resolvePctType(names []string) (TypeName, error) {
+	if len(names) < 2 || len(names) > 3 {
+		return TypeName{}, fmt.Errorf("improper %%TYPE reference: %s", strings.Join(names, "."))
+	}
+	schema := ""
+	if len(names) == 3 {
+		schema = names[0]
+	}
+	tableName := names[len(names)-2]
+	table := c.Table(schema, tableName)
+	if table == nil {
+		return TypeName{}, fmt.Errorf("relation \"%s\" does not exist", qualifiedName(schema, tableName))
+	}
+	column := table.Column(names[len(names)-1])
+	if column == nil {
+		return TypeName{}, fmt.Errorf("column \"%s\" of relation \"%s\" does not exist", names[len(names)-1], table.Name)
+	}
+	return column.Type, nil
+}
+
+// stringList returns the strings of a list of String nodes
+func stringList(list nodes.List) []string {
+	strs := []string{}
+	for _, item := range list.Items {
+		if str, ok := item.(nodes.String); ok {
+			strs = append(strs, str.Str)
+		}
+	}
+	return strs
+}
@@ -0,0 +1,414 @@
+package catalog
+
+import (
+	"fmt"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// queryAnalysis computes the result columns of the query of a view or CREATE
+// TABLE AS with the table columns they're computed from, and records the
+// tables and columns it reads, as PostgreSQL does when it creates a view
+type queryAnalysis struct {
+	catalog      *Catalog
+	dependencies []Dependency
+}
+
+// queryScope is the name space of one query level
+type queryScope struct {
+	parent *queryScope
+	items  []fromItem
+	ctes   []fromItem
+}
+
+// fromItem is a relation of a FROM clause or a CTE, by the name it's
+// referred to
+type fromItem struct {
+	name    string
+	schema  string
+	columns []fromColumn
+}
+
+// fromColumn is a column of a FROM item
+type fromColumn struct {
+	name string
+	typ  TypeName
+
+	// The catalog column the value is read from, the zero value for the
+	// columns of subqueries and CTEs
+	source Dependency
+
+	// Columns of tables the value is computed from
+	origins []Origin
+}
+
+// analyzeQuery returns the result columns of a query, and the tables and
+// columns it depends on
+func (c *Catalog) analyzeQuery(node nodes.Node) ([]*Column, []Dependency) {
+	q := &queryAnalysis{catalog: c}
+	return q.query(node, nil), q.dependencies
+}
+
+// depend records a dependency, unless it's already recorded
+func (q *queryAnalysis) depend(dependency Dependency) {
+	if dependency.Table == nil {
+		return
+	}
+	for _, other := range q.dependencies {
+		if other == dependency {
+			return
+		}
+	}
+	q.dependencies = append(q.dependencies, dependency)
+}
+
+// query analyzes a SELECT statement within the given outer scope and returns
+// its result columns
+func (q *queryAnalysis) query(node nodes.Node, parent *queryScope) []*Column {
+	stmt, ok := node.(nodes.SelectStmt)
+	if !ok {
+		return nil
+	}
+	s := &queryScope{parent: parent}
+	q.withClause(stmt.WithClause, s)
+
+	if stmt.Op != nodes.SETOP_NONE && stmt.Larg != nil && stmt.Rarg != nil {
+		// The columns are named after the left side
+		columns := q.query(*stmt.Larg, s)
+		right := q.query(*stmt.Rarg, s)
+		for i, column := range columns {
+			if i >= len(right) {
+				break
+			}
+			if column.Type.IsUnknown() {
+				column.Type = right[i].Type
+			}
+			column.Origins = addOrigins(column.Origins, right[i].Origins, true)
+		}
+		q.expr(stmt.SortClause, &queryScope{parent: s, items: []fromItem{resultItem(columns)}})
+		q.expr(stmt.LimitCount, s)
+		q.expr(stmt.LimitOffset, s)
+		return columns
+	}
+
+	if len(stmt.ValuesLists) > 0 {
+		columns := []*Column{}
+		for _, row := range stmt.ValuesLists {
+			for i, value := range row {
+				if i >= len(columns) {
+					columns = append(columns, &Column{Name: fmt.Sprintf("column%d", i+1), Type: q.exprType(value, s)})
+				}
+				columns[i].Origins = addOrigins(columns[i].Origins, q.expr(value, s), true)
+			}
+		}
+		return columns
+	}
+
+	for _, item := range stmt.FromClause.Items {
+		s.items = append(s.items, q.fromItem(item, s)...)
+	}
+	columns := q.targetList(stmt.TargetList, s)
+	q.expr(stmt.WhereClause, s)
+	q.expr(stmt.GroupClause, s)
+	q.expr(stmt.HavingClause, s)
+	q.expr(stmt.WindowClause, s)
+	q.expr(stmt.DistinctClause, s)
+	q.expr(stmt.SortClause, s)
+	q.expr(stmt.LimitCount, s)
+	q.expr(stmt.LimitOffset, s)
+	return columns
+}
+
+// resultItem returns an unnamed FROM item with the given result columns
+func resultItem(columns []*Column) fromItem {
+	item := fromItem{}
+	for _, column := range columns {
+		item.columns = append(item.columns, fromColumn{name: column.Name, typ: column.Type, origins: column.Origins})
+	}
+	return item
+}
+
+// withClause adds the CTEs of a WITH clause to the scope. The columns of a
+// recursive CTE are those of its non-recursive term.
+func (q *queryAnalysis) withClause(withClause *nodes.WithClause, s *queryScope) {
+	if withClause == nil {
+		return
+	}
+	for _, item := range withClause.Ctes.Items {
+		cte, ok := item.(nodes.CommonTableExpr)
+		if !ok || cte.Ctename == nil {
+			continue
+		}
+		selectStmt, ok := cte.Ctequery.(nodes.SelectStmt)
+		if withClause.Recursive && ok && selectStmt.Op == nodes.SETOP_UNION && selectStmt.Larg != nil && selectStmt.Rarg != nil {
+			cteScope := &queryScope{parent: s}
+			q.withClause(selectStmt.WithClause, cteScope)
+			columns := q.query(*selectStmt.Larg, cteScope)
+			s.ctes = append(s.ctes, q.alias(resultItem(columns), *cte.Ctename, cte.Aliascolnames))
+			q.query(*selectStmt.Rarg, cteScope)
+			continue
+		}
+		columns := q.query(cte.Ctequery, s)
+		s.ctes = append(s.ctes, q.alias(resultItem(columns), *cte.Ctename, cte.Aliascolnames))
+	}
+}
+
+// cte returns the CTE with the given name visible in the scope
+func (s *queryScope) cte(name string) (fromItem, bool) {
+	for ; s != nil; s = s.parent {
+		for i := len(s.ctes) - 1; i >= 0; i-- {
+			if s.ctes[i].name == name {
+				return s.ctes[i], true
+			}
+		}
+	}
+	return fromItem{}, false
+}
+
+// alias renames a FROM item and its first columns
+func (q *queryAnalysis) alias(item fromItem, name string, colnames nodes.List) fromItem {
+	item.name, item.schema = name, ""
+	columns := make([]fromColumn, len(item.columns))
+	copy(columns, item.columns)
+	for i, colname := range stringList(colnames) {
+		if i < len(columns) {
+			columns[i].name = colname
+		}
+	}
+	item.columns = columns
+	return item
+}
+
+// fromItem analyzes a FROM item and returns the items it makes visible. A
+// LATERAL subquery can refer to the items before it.
+func (q *queryAnalysis) fromItem(node nodes.Node, s *queryScope) []fromItem {
+	var item fromItem
+	var alias *nodes.Alias
+	switch node := node.(type) {
+	case nodes.RangeVar:
+		schema, name := rangeVarNames(&node)
+		alias = node.Alias
+		if cte, ok := s.cte(name); ok && schema == "" {
+			item = cte
+			break
+		}
+		item.name = name
+		table := q.catalog.Table(schema, name)
+		if table == nil {
+			break
+		}
+		q.depend(Dependency{Table: table})
+		item.schema = table.Schema
+		for _, column := range table.Columns {
+			origins := []Origin{{Table: table, Column: column, Direct: true}}
+			if table.Query != nil {
+				origins = column.Origins
+			}
+			item.columns = append(item.columns, fromColumn{
+				name:    column.Name,
+				typ:     column.Type,
+				source:  Dependency{Table: table, Column: column},
+				origins: origins,
+			})
+		}
+	case nodes.RangeSubselect:
+		alias = node.Alias
+		subScope := &queryScope{parent: s.parent, ctes: s.ctes}
+		if node.Lateral {
+			subScope = s
+		}
+		item = resultItem(q.query(node.Subquery, subScope))
+	case nodes.RangeFunction:
+		// The columns of functions don't come from catalog tables
+		alias = node.Alias
+		q.expr(node.Functions, s)
+	case nodes.RangeTableSample:
+		q.expr(node.Args, s)
+		q.expr(node.Repeatable, s)
+		return q.fromItem(node.Relation, s)
+	case nodes.JoinExpr:
+		items := q.fromItem(node.Larg, s)
+		items = append(items, q.fromItem(node.Rarg, &queryScope{parent: s.parent, ctes: s.ctes, items: append(append([]fromItem{}, s.items...), items...)})...)
+		q.expr(node.Quals, &queryScope{parent: s.parent, ctes: s.ctes, items: items})
+		if node.Alias == nil || node.Alias.Aliasname == nil {
+			return items
+		}
+		joined := fromItem{}
+		for _, item := range items {
+			joined.columns = append(joined.columns, item.columns...)
+		}
+		return []fromItem{q.alias(joined, *node.Alias.Aliasname, node.Alias.Colnames)}
+	default:
+		return nil
+	}
+	if alias != nil && alias.Aliasname != nil {
+		item = q.alias(item, *alias.Aliasname, alias.Colnames)
+	}
+	return []fromItem{item}
+}
+
+// targetList returns the result columns of a target list, with stars
+// expanded
+func (q *queryAnalysis) targetList(targetList nodes.List, s *queryScope) []*Column {
+	columns := []*Column{}
+	for _, item := range targetList.Items {
+		target, ok := item.(nodes.ResTarget)
+		if !ok {
+			continue
+		}
+		if columnRef, ok := target.Val.(nodes.ColumnRef); ok && isStar(columnRef) {
+			for _, column := range q.columnRef(columnRef, s) {
+				columns = append(columns, &Column{Name: column.name, Type: column.typ, Origins: column.origins})
+			}
+			continue
+		}
+		column := &Column{Name: FigureColumnName(target.Val), Type: q.exprType(target.Val, s), Origins: q.expr(target.Val, s)}
+		if target.Name != nil {
+			column.Name = *target.Name
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// expr records the columns an expression reads, analyzing the subqueries it
+// contains as inner query levels, and returns the table columns its value is
+// computed from. A bare column reference or scalar subquery copies the
+// origins of its column, anything else transforms the origins of all the
+// columns and subqueries it contains.
+func (q *queryAnalysis) expr(node nodes.Node, s *queryScope) []Origin {
+	switch node := node.(type) {
+	case nil:
+		return nil
+	case nodes.ColumnRef:
+		if columns := q.columnRef(node, s); len(columns) == 1 && !isStar(node) {
+			return addOrigins(nil, columns[0].origins, true)
+		}
+	case nodes.SubLink:
+		if node.SubLinkType == nodes.EXPR_SUBLINK {
+			if columns := q.query(node.Subselect, s); len(columns) == 1 {
+				return addOrigins(nil, columns[0].Origins, true)
+			}
+		}
+	}
+
+	var origins []Origin
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		switch node := node.(type) {
+		case nodes.ColumnRef:
+			for _, column := range q.columnRef(node, s) {
+				origins = addOrigins(origins, column.origins, false)
+			}
+			return false
+		case nodes.SubLink:
+			origins = addOrigins(origins, q.expr(node.Testexpr, s), false)
+			for _, column := range q.query(node.Subselect, s) {
+				origins = addOrigins(origins, column.Origins, false)
+			}
+			return false
+		case nodes.SelectStmt:
+			q.query(node, s)
+			return false
+		}
+		return true
+	})
+	return origins
+}
+
+// addOrigins returns the origins with the other ones appended, skipping
+// duplicates. The other ones become indirect unless direct is set.
+func addOrigins(origins []Origin, others []Origin, direct bool) []Origin {
+	for _, other := range others {
+		other.Direct = other.Direct && direct
+		found := false
+		for _, origin := range origins {
+			if origin == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			origins = append(origins, other)
+		}
+	}
+	return origins
+}
+
+// columnRef returns the columns a column reference refers to, which are all
+// columns of the matching FROM items for a star, and records that they're
+// read
+func (q *queryAnalysis) columnRef(columnRef nodes.ColumnRef, s *queryScope) []fromColumn {
+	names := stringList(columnRef.Fields)
+	star := isStar(columnRef)
+	if !star && len(names) == 0 {
+		return nil
+	}
+	qualifier := names
+	if !star {
+		qualifier = names[:len(names)-1]
+	}
+
+	for ; s != nil; s = s.parent {
+		columns := []fromColumn{}
+		for _, item := range s.items {
+			if !item.matches(qualifier) {
+				continue
+			}
+			for _, column := range item.columns {
+				if star || column.name == names[len(names)-1] {
+					columns = append(columns, column)
+				}
+			}
+			if len(columns) > 0 && !star {
+				break
+			}
+		}
+		if len(columns) > 0 {
+			for _, column := range columns {
+				q.depend(column.source)
+			}
+			return columns
+		}
+	}
+	return nil
+}
+
+// matches returns whether a FROM item is referred to by a possibly qualified
+// name, which is empty for unqualified column references
+func (item fromItem) matches(names []string) bool {
+	switch len(names) {
+	case 0:
+		return true
+	case 1:
+		return item.name == names[0]
+	default:
+		return item.name == names[len(names)-1] && item.schema == names[len(names)-2]
+	}
+}
+
+// exprType returns the type of plain column references, constants and
+// casts, and an unknown type for other expressions
+func (q *queryAnalysis) exprType(node nodes.Node, s *queryScope) TypeName {
+	switch node := node.(type) {
+	case nodes.TypeCast:
+		if node.TypeName != nil {
+			if typeName, err := q.catalog.ResolveType(*node.TypeName); err == nil {
+				return typeName
+			}
+		}
+	case nodes.A_Const:
+		switch node.Val.(type) {
+		case nodes.Integer:
+			return BuiltinType("int4")
+		case nodes.Float:
+			return BuiltinType("numeric")
+		case nodes.String:
+			return BuiltinType("text")
+		}
+	case nodes.ColumnRef:
+		if columns := q.columnRef(node, s); len(columns) == 1 {
+			return columns[0].typ
+		}
+	}
+	return TypeName{}
+}
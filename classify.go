@@ -0,0 +1,277 @@
+package pg_query
+
+import (
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// StatementCategory is the kind of effect a statement has, e.g. to decide
+// whether it can run on a read replica
+type StatementCategory int
+
+const (
+	// SELECT, SHOW, EXPLAIN and COPY ... TO that don't write
+	StatementReadOnly StatementCategory = iota
+	// INSERT, UPDATE, DELETE, TRUNCATE, COPY ... FROM and SELECT statements
+	// with data-modifying CTEs, locking clauses or INTO
+	StatementDataModifying
+	// Statements that change the schema, roles or privileges
+	StatementDDL
+	// BEGIN, COMMIT, ROLLBACK, savepoints and SET CONSTRAINTS
+	StatementTransactionControl
+	// SET, RESET, DISCARD, PREPARE, DEALLOCATE, LISTEN, UNLISTEN and LOAD
+	StatementSessionState
+	// Maintenance and other statements whose effect can't be told from the
+	// statement alone, e.g. VACUUM, LOCK, EXECUTE or DO
+	StatementOther
+)
+
+func (category StatementCategory) String() string {
+	switch category {
+	case StatementReadOnly:
+		return "read-only"
+	case StatementDataModifying:
+		return "data-modifying"
+	case StatementDDL:
+		return "DDL"
+	case StatementTransactionControl:
+		return "transaction control"
+	case StatementSessionState:
+		return "session state"
+	default:
+		return "other"
+	}
+}
+
+// StatementClassification describes the effect of a single statement
+type StatementClassification struct {
+	Category StatementCategory
+
+	// Names of the well-known volatile functions called by the statement, in
+	// order of their first call, e.g. nextval
+	VolatileFunctions []string
+}
+
+// Well-known built-in functions that are volatile, most of them because they
+// have side effects
+var volatileFunctions = map[string]bool{
+	"clock_timestamp":         true,
+	"currval":                 true,
+	"lastval":                 true,
+	"lo_creat":                true,
+	"lo_create":               true,
+	"lo_export":               true,
+	"lo_import":               true,
+	"lo_unlink":               true,
+	"nextval":                 true,
+	"pg_advisory_lock":        true,
+	"pg_advisory_lock_shared": true,
+	"pg_advisory_unlock":      true,
+	"pg_advisory_unlock_all":  true,
+	"pg_advisory_xact_lock":   true,
+	"pg_cancel_backend":       true,
+	"pg_create_restore_point": true,
+	"pg_notify":               true,
+	"pg_reload_conf":          true,
+	"pg_rotate_logfile":       true,
+	"pg_sleep":                true,
+	"pg_switch_wal":           true,
+	"pg_terminate_backend":    true,
+	"pg_try_advisory_lock":    true,
+	"random":                  true,
+	"set_config":              true,
+	"setseed":                 true,
+	"setval":                  true,
+	"timeofday":               true,
+	"txid_current":            true,
+}
+
+// Classify - Parses the given SQL and returns the category of each of its
+// statements
+func Classify(input string) (classifications []StatementClassification, err error) {
+	tree, err := Parse(input)
+	if err != nil {
+		return
+	}
+	return tree.Classify(), nil
+}
+
+// Classify returns the category of each statement of the tree
+func (input ParsetreeList) Classify() []StatementClassification {
+	classifications := make([]StatementClassification, len(input.Statements))
+	for i, stmt := range input.Statements {
+		stmt, _ = nodes.UnwrapRawStmt(stmt)
+		classifications[i] = StatementClassification{
+			Category:          classifyStatement(stmt),
+			VolatileFunctions: findVolatileFunctions(stmt),
+		}
+	}
+	return classifications
+}
+
+func classifyStatement(node nodes.Node) StatementCategory {
+	switch node := node.(type) {
+	case nodes.SelectStmt, nodes.VariableShowStmt:
+		return classifyQuery(node)
+	case nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt, nodes.TruncateStmt, nodes.RefreshMatViewStmt:
+		return StatementDataModifying
+	case nodes.CopyStmt:
+		if node.IsFrom {
+			return StatementDataModifying
+		}
+		return classifyQuery(node.Query)
+	case nodes.ExplainStmt:
+		// EXPLAIN ANALYZE runs the statement
+		for _, item := range node.Options.Items {
+			if defElem, ok := item.(nodes.DefElem); ok && defElem.Defname != nil && *defElem.Defname == "analyze" {
+				return classifyStatement(node.Query)
+			}
+		}
+		return StatementReadOnly
+	case nodes.DeclareCursorStmt:
+		return classifyQuery(node.Query)
+	case nodes.TransactionStmt, nodes.ConstraintsSetStmt:
+		return StatementTransactionControl
+	case nodes.VariableSetStmt,
+		nodes.DiscardStmt,
+		nodes.PrepareStmt,
+		nodes.DeallocateStmt,
+		nodes.ListenStmt,
+		nodes.UnlistenStmt,
+		nodes.LoadStmt:
+		return StatementSessionState
+	case nodes.AlterCollationStmt,
+		nodes.AlterDatabaseSetStmt,
+		nodes.AlterDatabaseStmt,
+		nodes.AlterDefaultPrivilegesStmt,
+		nodes.AlterDomainStmt,
+		nodes.AlterEnumStmt,
+		nodes.AlterEventTrigStmt,
+		nodes.AlterExtensionContentsStmt,
+		nodes.AlterExtensionStmt,
+		nodes.AlterFdwStmt,
+		nodes.AlterForeignServerStmt,
+		nodes.AlterFunctionStmt,
+		nodes.AlterObjectDependsStmt,
+		nodes.AlterObjectSchemaStmt,
+		nodes.AlterOpFamilyStmt,
+		nodes.AlterOperatorStmt,
+		nodes.AlterOwnerStmt,
+		nodes.AlterPolicyStmt,
+		nodes.AlterPublicationStmt,
+		nodes.AlterRoleSetStmt,
+		nodes.AlterRoleStmt,
+		nodes.AlterSeqStmt,
+		nodes.AlterSubscriptionStmt,
+		nodes.AlterSystemStmt,
+		nodes.AlterTableMoveAllStmt,
+		nodes.AlterTableSpaceOptionsStmt,
+		nodes.AlterTableStmt,
+		nodes.AlterTSConfigurationStmt,
+		nodes.AlterTSDictionaryStmt,
+		nodes.AlterUserMappingStmt,
+		nodes.CommentStmt,
+		nodes.CompositeTypeStmt,
+		nodes.CreateAmStmt,
+		nodes.CreateCastStmt,
+		nodes.CreateConversionStmt,
+		nodes.CreateDomainStmt,
+		nodes.CreateEnumStmt,
+		nodes.CreateEventTrigStmt,
+		nodes.CreateExtensionStmt,
+		nodes.CreateFdwStmt,
+		nodes.CreateForeignServerStmt,
+		nodes.CreateForeignTableStmt,
+		nodes.CreateFunctionStmt,
+		nodes.CreateOpClassStmt,
+		nodes.CreateOpFamilyStmt,
+		nodes.CreatePLangStmt,
+		nodes.CreatePolicyStmt,
+		nodes.CreatePublicationStmt,
+		nodes.CreateRangeStmt,
+		nodes.CreateRoleStmt,
+		nodes.CreateSchemaStmt,
+		nodes.CreateSeqStmt,
+		nodes.CreateStatsStmt,
+		nodes.CreateStmt,
+		nodes.CreateSubscriptionStmt,
+		nodes.CreateTableAsStmt,
+		nodes.CreateTableSpaceStmt,
+		nodes.CreateTransformStmt,
+		nodes.CreateTrigStmt,
+		nodes.CreateUserMappingStmt,
+		nodes.CreatedbStmt,
+		nodes.DefineStmt,
+		nodes.DropOwnedStmt,
+		nodes.DropRoleStmt,
+		nodes.DropStmt,
+		nodes.DropSubscriptionStmt,
+		nodes.DropTableSpaceStmt,
+		nodes.DropUserMappingStmt,
+		nodes.DropdbStmt,
+		nodes.GrantRoleStmt,
+		nodes.GrantStmt,
+		nodes.ImportForeignSchemaStmt,
+		nodes.IndexStmt,
+		nodes.ReassignOwnedStmt,
+		nodes.RenameStmt,
+		nodes.RuleStmt,
+		nodes.SecLabelStmt,
+		nodes.ViewStmt:
+		return StatementDDL
+	}
+	return StatementOther
+}
+
+// classifyQuery classifies a query that only reads unless it contains a
+// data-modifying statement, e.g. in a CTE, a locking clause or INTO
+func classifyQuery(node nodes.Node) StatementCategory {
+	category := StatementReadOnly
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		switch node := node.(type) {
+		case nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt:
+			category = StatementDataModifying
+		case nodes.SelectStmt:
+			if node.LockingClause.Items != nil || node.IntoClause != nil {
+				category = StatementDataModifying
+			}
+		}
+		return category == StatementReadOnly
+	})
+	return category
+}
+
+// findVolatileFunctions returns the names of the well-known volatile
+// functions called anywhere in node, unless they are qualified with a schema
+// other than pg_catalog
+func findVolatileFunctions(node nodes.Node) []string {
+	var names []string
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		funcCall, ok := node.(nodes.FuncCall)
+		if !ok {
+			return true
+		}
+		parts := []string{}
+		for _, item := range funcCall.Funcname.Items {
+			if str, ok := item.(nodes.String); ok {
+				parts = append(parts, strings.ToLower(str.Str))
+			}
+		}
+		if len(parts) == 0 || len(parts) > 2 || (len(parts) == 2 && parts[0] != "pg_catalog") {
+			return true
+		}
+		name := parts[len(parts)-1]
+		if !volatileFunctions[name] {
+			return true
+		}
+		for _, seen := range names {
+			if seen == name {
+				return true
+			}
+		}
+		names = append(names, name)
+		return true
+	})
+	return names
+}
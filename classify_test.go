@@ -0,0 +1,93 @@
+package pg_query_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go"
+)
+
+var classifyTests = []struct {
+	input             string
+	category          pg_query.StatementCategory
+	volatileFunctions []string
+}{
+	{"SELECT * FROM t WHERE a = 1", pg_query.StatementReadOnly, nil},
+	{"SELECT a FROM t UNION SELECT b FROM (SELECT b FROM u) x", pg_query.StatementReadOnly, nil},
+	{"SHOW search_path", pg_query.StatementReadOnly, nil},
+	{"EXPLAIN DELETE FROM t", pg_query.StatementReadOnly, nil},
+	{"COPY t TO STDOUT", pg_query.StatementReadOnly, nil},
+	{"VALUES (1), (2)", pg_query.StatementReadOnly, nil},
+	{"INSERT INTO t VALUES (1)", pg_query.StatementDataModifying, nil},
+	{"UPDATE t SET a = 1", pg_query.StatementDataModifying, nil},
+	{"DELETE FROM t", pg_query.StatementDataModifying, nil},
+	{"TRUNCATE t", pg_query.StatementDataModifying, nil},
+	{"COPY t FROM STDIN", pg_query.StatementDataModifying, nil},
+	{"WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d", pg_query.StatementDataModifying, nil},
+	{"SELECT * FROM t FOR UPDATE", pg_query.StatementDataModifying, nil},
+	{"SELECT * FROM (SELECT * FROM t FOR SHARE) x", pg_query.StatementDataModifying, nil},
+	{"SELECT * INTO u FROM t", pg_query.StatementDataModifying, nil},
+	{"EXPLAIN ANALYZE UPDATE t SET a = 1", pg_query.StatementDataModifying, nil},
+	{"COPY (SELECT * FROM t FOR UPDATE) TO STDOUT", pg_query.StatementDataModifying, nil},
+	{"CREATE TABLE t (a int)", pg_query.StatementDDL, nil},
+	{"ALTER TABLE t ADD COLUMN b int", pg_query.StatementDDL, nil},
+	{"DROP INDEX i", pg_query.StatementDDL, nil},
+	{"CREATE TABLE u AS SELECT * FROM t", pg_query.StatementDDL, nil},
+	{"GRANT SELECT ON t TO r", pg_query.StatementDDL, nil},
+	{"BEGIN", pg_query.StatementTransactionControl, nil},
+	{"COMMIT", pg_query.StatementTransactionControl, nil},
+	{"SAVEPOINT s", pg_query.StatementTransactionControl, nil},
+	{"SET CONSTRAINTS ALL DEFERRED", pg_query.StatementTransactionControl, nil},
+	{"SET search_path TO public", pg_query.StatementSessionState, nil},
+	{"RESET ALL", pg_query.StatementSessionState, nil},
+	{"DISCARD ALL", pg_query.StatementSessionState, nil},
+	{"PREPARE p AS SELECT 1", pg_query.StatementSessionState, nil},
+	{"VACUUM t", pg_query.StatementOther, nil},
+	{"EXECUTE p", pg_query.StatementOther, nil},
+	{"LOCK TABLE t", pg_query.StatementOther, nil},
+	{"SELECT nextval('s'), pg_catalog.setval('s', 1), nextval('s')", pg_query.StatementReadOnly, []string{"nextval", "setval"}},
+	{"SELECT myschema.nextval('s'), now()", pg_query.StatementReadOnly, nil},
+	{"INSERT INTO t VALUES (nextval('s'), random())", pg_query.StatementDataModifying, []string{"nextval", "random"}},
+}
+
+func TestClassify(t *testing.T) {
+	for _, test := range classifyTests {
+		classifications, err := pg_query.Classify(test.input)
+		if err != nil {
+			t.Errorf("Classify(%s): %s", test.input, err)
+			continue
+		}
+		if len(classifications) != 1 {
+			t.Errorf("Classify(%s): expected 1 statement, got %d", test.input, len(classifications))
+			continue
+		}
+		if classifications[0].Category != test.category {
+			t.Errorf("Classify(%s): expected %s, got %s", test.input, test.category, classifications[0].Category)
+		}
+		if !reflect.DeepEqual(classifications[0].VolatileFunctions, test.volatileFunctions) {
+			t.Errorf("Classify(%s): expected volatile functions %v, got %v", test.input, test.volatileFunctions, classifications[0].VolatileFunctions)
+		}
+	}
+}
+
+func TestClassifyMultipleStatements(t *testing.T) {
+	classifications, err := pg_query.Classify("BEGIN; SELECT 1; UPDATE t SET a = 1; COMMIT")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []pg_query.StatementCategory{
+		pg_query.StatementTransactionControl,
+		pg_query.StatementReadOnly,
+		pg_query.StatementDataModifying,
+		pg_query.StatementTransactionControl,
+	}
+	if len(classifications) != len(expected) {
+		t.Fatalf("expected %d statements, got %d", len(expected), len(classifications))
+	}
+	for i, classification := range classifications {
+		if classification.Category != expected[i] {
+			t.Errorf("statement %d: expected %s, got %s", i, expected[i], classification.Category)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package pg_query
+
+import (
+	"sort"
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// Comment is a comment in SQL text, which the parser otherwise discards
+type Comment struct {
+	Location int    // byte offset of the start of the comment
+	Text     string // the comment including its delimiters, e.g. "-- why"
+}
+
+// ScanComments returns all comments in the given SQL text, in order
+func ScanComments(input string) []Comment {
+	comments := []Comment{}
+	for pos := 0; pos < len(input); {
+		if end := skipComment(input, pos); end > pos {
+			comments = append(comments, Comment{pos, strings.TrimRight(input[pos:end], "\r")})
+			pos = end
+			continue
+		}
+
+		if isIdentStart(input[pos]) {
+			end := pos
+			for end < len(input) && isIdentChar(input[end]) {
+				end++
+			}
+			if end-pos == 1 && (input[pos] == 'E' || input[pos] == 'e') && end < len(input) && input[end] == '\'' {
+				end = skipEscapeString(input, end)
+			}
+			pos = end
+			continue
+		}
+
+		end := skipQuoted(input, pos)
+		if end == pos {
+			end++
+		}
+		pos = end
+	}
+	return comments
+}
+
+// ParseWithComments parses the given SQL statement like Parse, and also
+// collects its comments, so that Deparse and Format write them back out
+// next to the nodes they belong to
+func ParseWithComments(input string) (tree ParsetreeList, err error) {
+	tree, err = Parse(input)
+	if err != nil {
+		return
+	}
+	tree.Comments = ScanComments(input)
+	tree.source = input
+	return
+}
+
+// skipComment returns the end of the comment starting at pos, or pos if
+// there is none. Line comments end before the newline.
+func skipComment(sql string, pos int) int {
+	switch {
+	case strings.HasPrefix(sql[pos:], "--"):
+		end := strings.IndexByte(sql[pos:], '\n')
+		if end < 0 {
+			return len(sql)
+		}
+		return pos + end
+	case strings.HasPrefix(sql[pos:], "/*"):
+		// Block comments nest
+		depth := 0
+		for end := pos; end < len(sql)-1; end++ {
+			switch sql[end : end+2] {
+			case "/*":
+				depth++
+				end++
+			case "*/":
+				depth--
+				end++
+				if depth == 0 {
+					return end + 1
+				}
+			}
+		}
+		return len(sql)
+	}
+	return pos
+}
+
+// skipEscapeString returns the end of the E'...' string constant whose
+// opening quote is at pos
+func skipEscapeString(sql string, pos int) int {
+	for end := pos + 1; end < len(sql); end++ {
+		switch sql[end] {
+		case '\\':
+			end++
+		case '\'':
+			if end+1 < len(sql) && sql[end+1] == '\'' {
+				end++
+				continue
+			}
+			return end + 1
+		}
+	}
+	return len(sql)
+}
+
+// deparseComments holds the comments of a statement that still need to be
+// written, keyed by the location of the node they are attached to
+type deparseComments struct {
+	leading    []Comment // comments before the statement itself
+	byLocation map[int][]Comment
+	trailing   []Comment // comments after the last node of the statement
+}
+
+// attachComments assigns every comment to the statement it appears in, and
+// within that statement to the first node following it
+func attachComments(tree ParsetreeList) []*deparseComments {
+	result := make([]*deparseComments, len(tree.Statements))
+	if len(tree.Comments) == 0 {
+		return result
+	}
+
+	starts := make([]int, len(tree.Statements))
+	locations := make([][]int, len(tree.Statements))
+	for i, stmt := range tree.Statements {
+		result[i] = &deparseComments{byLocation: map[int][]Comment{}}
+		if rawStmt, ok := stmt.(nodes.RawStmt); ok && rawStmt.StmtLocation > 0 && rawStmt.StmtLocation <= len(tree.source) {
+			starts[i] = rawStmt.StmtLocation
+		}
+		nodes.Walk(stmt, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+			if location := nodes.Location(node); location >= 0 {
+				locations[i] = append(locations[i], location)
+			}
+			return true
+		})
+		sort.Ints(locations[i])
+	}
+
+	for _, comment := range tree.Comments {
+		i := sort.Search(len(starts), func(i int) bool { return starts[i] > comment.Location }) - 1
+		if i < 0 {
+			i = 0
+		}
+
+		end := comment.Location + len(comment.Text)
+		next := sort.SearchInts(locations[i], end)
+		leading := comment.Location < statementTokenStart(tree.source, starts[i])
+		switch {
+		case leading && i > 0 && tree.source != "" && !strings.Contains(tree.source[starts[i]:comment.Location], "\n"):
+			// A comment before the statement, on the same line as the end of
+			// the previous one, belongs to that statement, e.g. "SELECT 1; -- why"
+			result[i-1].trailing = append(result[i-1].trailing, comment)
+		case leading:
+			result[i].leading = append(result[i].leading, comment)
+		case next < len(locations[i]):
+			location := locations[i][next]
+			result[i].byLocation[location] = append(result[i].byLocation[location], comment)
+		default:
+			result[i].trailing = append(result[i].trailing, comment)
+		}
+	}
+
+	return result
+}
+
+// statementTokenStart returns the location of the first token of the
+// statement starting at start, skipping whitespace and comments
+func statementTokenStart(source string, start int) int {
+	pos := start
+	for pos < len(source) {
+		if end := skipComment(source, pos); end > pos {
+			pos = end
+		} else if strings.IndexByte(" \t\r\n\f", source[pos]) >= 0 {
+			pos++
+		} else {
+			break
+		}
+	}
+	return pos
+}
+
+// deparseItemWithComments deparses node, preceded by the comments attached to it
+func (c DeparseContext) deparseItemWithComments(node nodes.Node) (string, error) {
+	location := nodes.Location(node)
+	comments := c.comments.byLocation[location]
+	if len(comments) == 0 {
+		return c.deparseNode(node)
+	}
+
+	// Nested nodes can share the location, the outermost one gets the comments
+	delete(c.comments.byLocation, location)
+
+	result, err := c.deparseNode(node)
+	if err != nil {
+		return "", err
+	}
+	return c.leadingComments(comments) + result, nil
+}
+
+// deparseStatement deparses a top-level statement together with the comments
+// preceding it and inside it. Comments following the statement, and those
+// attached to nodes that weren't written, are returned separately.
+func (c DeparseContext) deparseStatement(node nodes.Node) (string, []Comment, error) {
+	result, err := c.deparseItem(node)
+	if err != nil || c.comments == nil {
+		return result, nil, err
+	}
+
+	trailing := c.comments.trailing
+	for _, comments := range c.comments.byLocation {
+		trailing = append(trailing, comments...)
+	}
+	sort.Slice(trailing, func(i, j int) bool {
+		return trailing[i].Location < trailing[j].Location
+	})
+
+	return c.leadingComments(c.comments.leading) + result, trailing, nil
+}
+
+// leadingComments writes comments that precede a node. Line comments are
+// followed by a line break, so they can't swallow the node.
+func (c DeparseContext) leadingComments(comments []Comment) string {
+	output := ""
+	for _, comment := range comments {
+		if strings.HasPrefix(comment.Text, "--") {
+			output += comment.Text + c.newline()
+		} else {
+			output += comment.Text + " "
+		}
+	}
+	return output
+}
+
+// trailingComments writes comments that follow a statement, and whether
+// they need to be followed by a line break
+func trailingComments(comments []Comment) (string, bool) {
+	output := ""
+	lineComment := false
+	for _, comment := range comments {
+		if lineComment {
+			output += "\n"
+		} else {
+			output += " "
+		}
+		output += comment.Text
+		lineComment = strings.HasPrefix(comment.Text, "--")
+	}
+	return output, lineComment
+}
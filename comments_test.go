@@ -0,0 +1,91 @@
+package pg_query_test
+
+import (
+	"reflect"
+	"testing"
+
+	pg_query "github.com/tomaszjonak/pg_query_go"
+)
+
+var scanCommentsTests = []struct {
+	input    string
+	expected []pg_query.Comment
+}{
+	{
+		"SELECT 1",
+		[]pg_query.Comment{},
+	},
+	{
+		"-- first\nSELECT /* second /* nested */ */ 1 -- third",
+		[]pg_query.Comment{{0, "-- first"}, {16, "/* second /* nested */ */"}, {44, "-- third"}},
+	},
+	{
+		"SELECT '-- no', \"/* no */\", E'\\' -- no', $$ -- no $$, $tag$ /* no */ $tag$ /* yes */",
+		[]pg_query.Comment{{75, "/* yes */"}},
+	},
+}
+
+func TestScanComments(t *testing.T) {
+	for _, test := range scanCommentsTests {
+		actual := pg_query.ScanComments(test.input)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("ScanComments(%s)\nexpected %v\nactual %v\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+var deparseCommentsTests = []struct {
+	input           string
+	expectedDeparse string
+	expectedFormat  string
+}{
+	{
+		"SELECT 1",
+		"SELECT 1",
+		"SELECT\n  1;\n",
+	},
+	{
+		"-- why we did this\nSELECT a, /* keep */ b FROM x",
+		"-- why we did this\nSELECT \"a\", /* keep */ \"b\" FROM \"x\"",
+		"-- why we did this\nSELECT\n  \"a\",\n  /* keep */ \"b\"\nFROM\n  \"x\";\n",
+	},
+	{
+		"SELECT a FROM x WHERE\n  -- only active\n  active = true\n  AND y = 1;\nSELECT 2; -- done",
+		"SELECT \"a\" FROM \"x\" WHERE -- only active\n\"active\" = true AND \"y\" = 1; SELECT 2 -- done\n",
+		"SELECT\n  \"a\"\nFROM\n  \"x\"\nWHERE\n  -- only active\n  \"active\" = TRUE\n  AND \"y\" = 1;\n\nSELECT\n  2; -- done\n",
+	},
+	{
+		"SELECT 1; SELECT a /* c */ FROM t",
+		"SELECT 1; SELECT \"a\" FROM /* c */ \"t\"",
+		"SELECT\n  1;\n\nSELECT\n  \"a\"\nFROM\n  /* c */ \"t\";\n",
+	},
+	{
+		"SELECT a FROM x WHERE a = 1 /* x */ AND b = 2",
+		"SELECT \"a\" FROM \"x\" WHERE /* x */ \"a\" = 1 AND \"b\" = 2",
+		"SELECT\n  \"a\"\nFROM\n  \"x\"\nWHERE\n  /* x */ \"a\" = 1\n  AND \"b\" = 2;\n",
+	},
+}
+
+func TestDeparseWithComments(t *testing.T) {
+	for _, test := range deparseCommentsTests {
+		tree, err := pg_query.ParseWithComments(test.input)
+		if err != nil {
+			t.Errorf("ParseWithComments(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual, err := pg_query.Deparse(tree)
+		if err != nil {
+			t.Errorf("Deparse(%s)\nerror %s\n\n", test.input, err)
+		} else if actual != test.expectedDeparse {
+			t.Errorf("Deparse(%s)\nexpected %q\nactual %q\n\n", test.input, test.expectedDeparse, actual)
+		}
+
+		actual, err = pg_query.Format(tree, pg_query.FormatOptions{})
+		if err != nil {
+			t.Errorf("Format(%s)\nerror %s\n\n", test.input, err)
+		} else if actual != test.expectedFormat {
+			t.Errorf("Format(%s)\nexpected %q\nactual %q\n\n", test.input, test.expectedFormat, actual)
+		}
+	}
+}
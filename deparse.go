@@ -2,6 +2,7 @@ package pg_query
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/kr/pretty"
@@ -10,18 +11,24 @@ import (
 
 type DeparseContext struct {
 	Context string
+
+	format   *FormatOptions   // set when pretty-printing, see Format
+	indent   int              // indentation level of the current line when pretty-printing
+	comments *deparseComments // comments still to be written, see ParseWithComments
+}
+
+// withContext returns a copy of c for deparsing nodes in the given context
+func (c DeparseContext) withContext(context string) DeparseContext {
+	c.Context = context
+	return c
 }
 
 func Deparse(tree ParsetreeList) (string, error) {
-	results := make([]string, len(tree.Statements))
-	for i, item := range tree.Statements {
-		result, err := DeparseItem(item)
-		if err != nil {
-			return "", err
-		}
-		results[i] = result
+	var output strings.Builder
+	if err := DeparseTo(&output, tree); err != nil {
+		return "", err
 	}
-	return strings.Join(results, "; "), nil
+	return output.String(), nil
 }
 
 func DeparseItem(item nodes.Node) (string, error) {
@@ -34,6 +41,13 @@ func DeparseItem(item nodes.Node) (string, error) {
 }
 
 func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
+	if c.comments != nil {
+		return c.deparseItemWithComments(node)
+	}
+	return c.deparseNode(node)
+}
+
+func (c DeparseContext) deparseNode(node nodes.Node) (string, error) {
 	switch node.(type) {
 	case nodes.A_ArrayExpr:
 		return c.deparseA_ArrayExpr(node.(nodes.A_ArrayExpr))
@@ -47,20 +61,18 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		switch node.(nodes.A_Expr).Kind {
 		case nodes.AEXPR_OP:
 			return c.deparseA_Expr(node.(nodes.A_Expr))
-		case nodes.AEXPR_OP_ANY:
+		case nodes.AEXPR_OP_ANY, nodes.AEXPR_OP_ALL:
 			return c.deparseA_ExprAny(node.(nodes.A_Expr))
-		//case nodes.AEXPR_OP_ALL:
-		//case nodes.AEXPR_DISTINCT:
-		//case nodes.AEXPR_NOT_DISTINCT:
+		case nodes.AEXPR_DISTINCT, nodes.AEXPR_NOT_DISTINCT:
+			return c.deparseA_ExprDistinct(node.(nodes.A_Expr))
 		case nodes.AEXPR_NULLIF:
 			return c.deparseA_ExprNullif(node.(nodes.A_Expr))
-		//case nodes.AEXPR_OF:
+		case nodes.AEXPR_OF:
+			return c.deparseA_ExprOf(node.(nodes.A_Expr))
 		case nodes.AEXPR_IN:
 			return c.deparseA_ExprIn(node.(nodes.A_Expr))
-		case nodes.AEXPR_LIKE:
+		case nodes.AEXPR_LIKE, nodes.AEXPR_ILIKE, nodes.AEXPR_SIMILAR:
 			return c.deparseA_ExprLike(node.(nodes.A_Expr))
-		//case nodes.AEXPR_ILIKE:
-		//case nodes.AEXPR_SIMILAR:
 		case nodes.AEXPR_BETWEEN,
 			nodes.AEXPR_NOT_BETWEEN,
 			nodes.AEXPR_BETWEEN_SYM,
@@ -74,20 +86,18 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		switch node.(*nodes.A_Expr).Kind {
 		case nodes.AEXPR_OP:
 			return c.deparseA_Expr(*node.(*nodes.A_Expr))
-		case nodes.AEXPR_OP_ANY:
+		case nodes.AEXPR_OP_ANY, nodes.AEXPR_OP_ALL:
 			return c.deparseA_ExprAny(*node.(*nodes.A_Expr))
-		//case nodes.AEXPR_OP_ALL:
-		//case nodes.AEXPR_DISTINCT:
-		//case nodes.AEXPR_NOT_DISTINCT:
+		case nodes.AEXPR_DISTINCT, nodes.AEXPR_NOT_DISTINCT:
+			return c.deparseA_ExprDistinct(*node.(*nodes.A_Expr))
 		case nodes.AEXPR_NULLIF:
 			return c.deparseA_ExprNullif(*node.(*nodes.A_Expr))
-		//case nodes.AEXPR_OF:
+		case nodes.AEXPR_OF:
+			return c.deparseA_ExprOf(*node.(*nodes.A_Expr))
 		case nodes.AEXPR_IN:
 			return c.deparseA_ExprIn(*node.(*nodes.A_Expr))
-		case nodes.AEXPR_LIKE:
+		case nodes.AEXPR_LIKE, nodes.AEXPR_ILIKE, nodes.AEXPR_SIMILAR:
 			return c.deparseA_ExprLike(*node.(*nodes.A_Expr))
-		//case nodes.AEXPR_ILIKE:
-		//case nodes.AEXPR_SIMILAR:
 		case nodes.AEXPR_BETWEEN,
 			nodes.AEXPR_NOT_BETWEEN,
 			nodes.AEXPR_BETWEEN_SYM,
@@ -109,17 +119,45 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseA_Star(node.(nodes.A_Star))
 	case *nodes.A_Star:
 		return c.deparseA_Star(*node.(*nodes.A_Star))
+	case nodes.AccessPriv:
+		return c.deparseAccessPriv(node.(nodes.AccessPriv))
+	case *nodes.AccessPriv:
+		return c.deparseAccessPriv(*node.(*nodes.AccessPriv))
 	case nodes.Alias:
 		return c.deparseAlias(node.(nodes.Alias))
 	case *nodes.Alias:
 		return c.deparseAlias(*(node.(*nodes.Alias)))
+	case nodes.AlterDefaultPrivilegesStmt:
+		return c.deparseAlterDefaultPrivilegesStmt(node.(nodes.AlterDefaultPrivilegesStmt))
+	case *nodes.AlterDefaultPrivilegesStmt:
+		return c.deparseAlterDefaultPrivilegesStmt(*node.(*nodes.AlterDefaultPrivilegesStmt))
+	case nodes.AlterOwnerStmt:
+		return c.deparseAlterOwnerStmt(node.(nodes.AlterOwnerStmt))
+	case *nodes.AlterOwnerStmt:
+		return c.deparseAlterOwnerStmt(*node.(*nodes.AlterOwnerStmt))
+	case nodes.AlterPolicyStmt:
+		return c.deparseAlterPolicyStmt(node.(nodes.AlterPolicyStmt))
+	case *nodes.AlterPolicyStmt:
+		return c.deparseAlterPolicyStmt(*node.(*nodes.AlterPolicyStmt))
+	case nodes.AlterRoleStmt:
+		return c.deparseAlterRoleStmt(node.(nodes.AlterRoleStmt))
+	case *nodes.AlterRoleStmt:
+		return c.deparseAlterRoleStmt(*node.(*nodes.AlterRoleStmt))
+	case nodes.AlterTableCmd:
+		return c.deparseAlterTableCmd(node.(nodes.AlterTableCmd))
+	case *nodes.AlterTableCmd:
+		return c.deparseAlterTableCmd(*node.(*nodes.AlterTableCmd))
+	case nodes.AlterTableStmt:
+		return c.deparseAlterTableStmt(node.(nodes.AlterTableStmt))
+	case *nodes.AlterTableStmt:
+		return c.deparseAlterTableStmt(*node.(*nodes.AlterTableStmt))
 	case nodes.BoolExpr:
 		switch node.(nodes.BoolExpr).Boolop {
 		case nodes.AND_EXPR:
 			return c.deparseBoolExprAnd(node.(nodes.BoolExpr))
 		case nodes.OR_EXPR:
 			return c.deparseBoolExprOr(node.(nodes.BoolExpr))
-		case 0x2: //NOT_EXPR
+		case nodes.NOT_EXPR:
 			return c.deparseBoolExprNot(node.(nodes.BoolExpr))
 		default:
 			return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
@@ -130,7 +168,7 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 			return c.deparseBoolExprAnd(*node.(*nodes.BoolExpr))
 		case nodes.OR_EXPR:
 			return c.deparseBoolExprOr(*node.(*nodes.BoolExpr))
-		case 0x2: //NOT_EXPR
+		case nodes.NOT_EXPR:
 			return c.deparseBoolExprNot(*node.(*nodes.BoolExpr))
 		default:
 			return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
@@ -151,6 +189,10 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseCoalesceExpr(node.(nodes.CoalesceExpr))
 	case *nodes.CoalesceExpr:
 		return c.deparseCoalesceExpr(*node.(*nodes.CoalesceExpr))
+	case nodes.CollateClause:
+		return c.deparseCollateClause(node.(nodes.CollateClause))
+	case *nodes.CollateClause:
+		return c.deparseCollateClause(*node.(*nodes.CollateClause))
 	case nodes.ColumnDef:
 		return c.deparseColumnDef(node.(nodes.ColumnDef))
 	case *nodes.ColumnDef:
@@ -163,6 +205,70 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseCommonTableExpr(node.(nodes.CommonTableExpr))
 	case *nodes.CommonTableExpr:
 		return c.deparseCommonTableExpr(*node.(*nodes.CommonTableExpr))
+	case nodes.CopyStmt:
+		return c.deparseCopyStmt(node.(nodes.CopyStmt))
+	case *nodes.CopyStmt:
+		return c.deparseCopyStmt(*node.(*nodes.CopyStmt))
+	case nodes.CreateFunctionStmt:
+		return c.deparseCreateFunctionStmt(node.(nodes.CreateFunctionStmt))
+	case *nodes.CreateFunctionStmt:
+		return c.deparseCreateFunctionStmt(*node.(*nodes.CreateFunctionStmt))
+	case nodes.CreatePolicyStmt:
+		return c.deparseCreatePolicyStmt(node.(nodes.CreatePolicyStmt))
+	case *nodes.CreatePolicyStmt:
+		return c.deparseCreatePolicyStmt(*node.(*nodes.CreatePolicyStmt))
+	case nodes.CreateRoleStmt:
+		return c.deparseCreateRoleStmt(node.(nodes.CreateRoleStmt))
+	case *nodes.CreateRoleStmt:
+		return c.deparseCreateRoleStmt(*node.(*nodes.CreateRoleStmt))
+	case nodes.CreateTableAsStmt:
+		return c.deparseCreateTableAsStmt(node.(nodes.CreateTableAsStmt))
+	case *nodes.CreateTableAsStmt:
+		return c.deparseCreateTableAsStmt(*node.(*nodes.CreateTableAsStmt))
+	case nodes.CreateTrigStmt:
+		return c.deparseCreateTrigStmt(node.(nodes.CreateTrigStmt))
+	case *nodes.CreateTrigStmt:
+		return c.deparseCreateTrigStmt(*node.(*nodes.CreateTrigStmt))
+	case nodes.CurrentOfExpr:
+		return c.deparseCurrentOfExpr(node.(nodes.CurrentOfExpr))
+	case *nodes.CurrentOfExpr:
+		return c.deparseCurrentOfExpr(*node.(*nodes.CurrentOfExpr))
+	case nodes.DeallocateStmt:
+		return c.deparseDeallocateStmt(node.(nodes.DeallocateStmt))
+	case *nodes.DeallocateStmt:
+		return c.deparseDeallocateStmt(*node.(*nodes.DeallocateStmt))
+	case nodes.DefElem:
+		return c.deparseDefElem(node.(nodes.DefElem))
+	case *nodes.DefElem:
+		return c.deparseDefElem(*node.(*nodes.DefElem))
+	case nodes.DeleteStmt:
+		return c.deparseDeleteStmt(node.(nodes.DeleteStmt))
+	case *nodes.DeleteStmt:
+		return c.deparseDeleteStmt(*node.(*nodes.DeleteStmt))
+	case nodes.DiscardStmt:
+		return c.deparseDiscardStmt(node.(nodes.DiscardStmt))
+	case *nodes.DiscardStmt:
+		return c.deparseDiscardStmt(*node.(*nodes.DiscardStmt))
+	case nodes.DoStmt:
+		return c.deparseDoStmt(node.(nodes.DoStmt))
+	case *nodes.DoStmt:
+		return c.deparseDoStmt(*node.(*nodes.DoStmt))
+	case nodes.DropOwnedStmt:
+		return c.deparseDropOwnedStmt(node.(nodes.DropOwnedStmt))
+	case *nodes.DropOwnedStmt:
+		return c.deparseDropOwnedStmt(*node.(*nodes.DropOwnedStmt))
+	case nodes.DropRoleStmt:
+		return c.deparseDropRoleStmt(node.(nodes.DropRoleStmt))
+	case *nodes.DropRoleStmt:
+		return c.deparseDropRoleStmt(*node.(*nodes.DropRoleStmt))
+	case nodes.ExecuteStmt:
+		return c.deparseExecuteStmt(node.(nodes.ExecuteStmt))
+	case *nodes.ExecuteStmt:
+		return c.deparseExecuteStmt(*node.(*nodes.ExecuteStmt))
+	case nodes.ExplainStmt:
+		return c.deparseExplainStmt(node.(nodes.ExplainStmt))
+	case *nodes.ExplainStmt:
+		return c.deparseExplainStmt(*node.(*nodes.ExplainStmt))
 	case nodes.Float:
 		return node.(nodes.Float).Str, nil
 	case *nodes.Float:
@@ -171,24 +277,96 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseFuncCall(node.(nodes.FuncCall))
 	case *nodes.FuncCall:
 		return c.deparseFuncCall(*node.(*nodes.FuncCall))
+	case nodes.FunctionParameter:
+		return c.deparseFunctionParameter(node.(nodes.FunctionParameter))
+	case *nodes.FunctionParameter:
+		return c.deparseFunctionParameter(*node.(*nodes.FunctionParameter))
+	case nodes.GrantRoleStmt:
+		return c.deparseGrantRoleStmt(node.(nodes.GrantRoleStmt))
+	case *nodes.GrantRoleStmt:
+		return c.deparseGrantRoleStmt(*node.(*nodes.GrantRoleStmt))
+	case nodes.GrantStmt:
+		return c.deparseGrantStmt(node.(nodes.GrantStmt))
+	case *nodes.GrantStmt:
+		return c.deparseGrantStmt(*node.(*nodes.GrantStmt))
+	case nodes.GroupingFunc:
+		return c.deparseGroupingFunc(node.(nodes.GroupingFunc))
+	case *nodes.GroupingFunc:
+		return c.deparseGroupingFunc(*node.(*nodes.GroupingFunc))
+	case nodes.GroupingSet:
+		return c.deparseGroupingSet(node.(nodes.GroupingSet))
+	case *nodes.GroupingSet:
+		return c.deparseGroupingSet(*node.(*nodes.GroupingSet))
+	case nodes.IndexElem:
+		return c.deparseIndexElem(node.(nodes.IndexElem))
+	case *nodes.IndexElem:
+		return c.deparseIndexElem(*node.(*nodes.IndexElem))
+	case nodes.InferClause:
+		return c.deparseInferClause(node.(nodes.InferClause))
+	case *nodes.InferClause:
+		return c.deparseInferClause(*node.(*nodes.InferClause))
+	case nodes.InsertStmt:
+		return c.deparseInsertStmt(node.(nodes.InsertStmt))
+	case *nodes.InsertStmt:
+		return c.deparseInsertStmt(*node.(*nodes.InsertStmt))
 	case nodes.Integer:
-		return fmt.Sprintf("%d", node.(nodes.Integer).Ival), nil
+		return strconv.FormatInt(node.(nodes.Integer).Ival, 10), nil
 	case *nodes.Integer:
-		return fmt.Sprintf("%d", node.(*nodes.Integer).Ival), nil
+		return strconv.FormatInt(node.(*nodes.Integer).Ival, 10), nil
+	case nodes.IntoClause:
+		return c.deparseIntoClause(node.(nodes.IntoClause))
+	case *nodes.IntoClause:
+		return c.deparseIntoClause(*node.(*nodes.IntoClause))
 	case nodes.JoinExpr:
 		return c.deparseJoinExpr(node.(nodes.JoinExpr))
 	case *nodes.JoinExpr:
 		return c.deparseJoinExpr(*node.(*nodes.JoinExpr))
+	case nodes.ListenStmt:
+		return c.deparseListenStmt(node.(nodes.ListenStmt))
+	case *nodes.ListenStmt:
+		return c.deparseListenStmt(*node.(*nodes.ListenStmt))
+	case nodes.LockingClause:
+		return c.deparseLockingClause(node.(nodes.LockingClause))
+	case *nodes.LockingClause:
+		return c.deparseLockingClause(*node.(*nodes.LockingClause))
+	case nodes.LockStmt:
+		return c.deparseLockStmt(node.(nodes.LockStmt))
+	case *nodes.LockStmt:
+		return c.deparseLockStmt(*node.(*nodes.LockStmt))
+	case nodes.MinMaxExpr:
+		return c.deparseMinMaxExpr(node.(nodes.MinMaxExpr))
+	case *nodes.MinMaxExpr:
+		return c.deparseMinMaxExpr(*node.(*nodes.MinMaxExpr))
+	case nodes.MultiAssignRef:
+		return c.deparseMultiAssignRef(node.(nodes.MultiAssignRef))
+	case *nodes.MultiAssignRef:
+		return c.deparseMultiAssignRef(*node.(*nodes.MultiAssignRef))
+	case nodes.NamedArgExpr:
+		return c.deparseNamedArgExpr(node.(nodes.NamedArgExpr))
+	case *nodes.NamedArgExpr:
+		return c.deparseNamedArgExpr(*node.(*nodes.NamedArgExpr))
+	case nodes.NotifyStmt:
+		return c.deparseNotifyStmt(node.(nodes.NotifyStmt))
+	case *nodes.NotifyStmt:
+		return c.deparseNotifyStmt(*node.(*nodes.NotifyStmt))
 	case nodes.Null:
 		return "NULL", nil
 	case nodes.NullTest:
 		return c.deparseNullTest(node.(nodes.NullTest))
 	case *nodes.NullTest:
 		return c.deparseNullTest(*node.(*nodes.NullTest))
+	case nodes.ObjectWithArgs:
+		return c.deparseObjectWithArgs(node.(nodes.ObjectWithArgs))
+	case *nodes.ObjectWithArgs:
+		return c.deparseObjectWithArgs(*node.(*nodes.ObjectWithArgs))
 	case nodes.ParamRef:
 		return c.deparseParamRef(node.(nodes.ParamRef))
 	case *nodes.ParamRef:
 		return c.deparseParamRef(*node.(*nodes.ParamRef))
+	case nodes.PrepareStmt:
+		return c.deparsePrepareStmt(node.(nodes.PrepareStmt))
+	case *nodes.PrepareStmt:
+		return c.deparsePrepareStmt(*node.(*nodes.PrepareStmt))
 	case nodes.RangeFunction:
 		return c.deparseRangeFunction(node.(nodes.RangeFunction))
 	case *nodes.RangeFunction:
@@ -197,6 +375,18 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseRangeSubselect(node.(nodes.RangeSubselect))
 	case *nodes.RangeSubselect:
 		return c.deparseRangeSubselect(*node.(*nodes.RangeSubselect))
+	case nodes.RangeTableFunc:
+		return c.deparseRangeTableFunc(node.(nodes.RangeTableFunc))
+	case *nodes.RangeTableFunc:
+		return c.deparseRangeTableFunc(*node.(*nodes.RangeTableFunc))
+	case nodes.RangeTableFuncCol:
+		return c.deparseRangeTableFuncCol(node.(nodes.RangeTableFuncCol))
+	case *nodes.RangeTableFuncCol:
+		return c.deparseRangeTableFuncCol(*node.(*nodes.RangeTableFuncCol))
+	case nodes.RangeTableSample:
+		return c.deparseRangeTableSample(node.(nodes.RangeTableSample))
+	case *nodes.RangeTableSample:
+		return c.deparseRangeTableSample(*node.(*nodes.RangeTableSample))
 	case nodes.RangeVar:
 		return c.deparseRangeVar(node.(nodes.RangeVar))
 	case *nodes.RangeVar:
@@ -205,22 +395,46 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseRawStmt(node.(nodes.RawStmt))
 	case *nodes.RawStmt:
 		return c.deparseRawStmt(*node.(*nodes.RawStmt))
+	case nodes.ReassignOwnedStmt:
+		return c.deparseReassignOwnedStmt(node.(nodes.ReassignOwnedStmt))
+	case *nodes.ReassignOwnedStmt:
+		return c.deparseReassignOwnedStmt(*node.(*nodes.ReassignOwnedStmt))
+	case nodes.RefreshMatViewStmt:
+		return c.deparseRefreshMatViewStmt(node.(nodes.RefreshMatViewStmt))
+	case *nodes.RefreshMatViewStmt:
+		return c.deparseRefreshMatViewStmt(*node.(*nodes.RefreshMatViewStmt))
 	case nodes.ResTarget:
 		return c.deparseResTarget(node.(nodes.ResTarget))
 	case *nodes.ResTarget:
 		return c.deparseResTarget(*node.(*nodes.ResTarget))
+	case nodes.RoleSpec:
+		return c.deparseRoleSpec(node.(nodes.RoleSpec))
+	case *nodes.RoleSpec:
+		return c.deparseRoleSpec(*node.(*nodes.RoleSpec))
 	case nodes.RowExpr:
 		return c.deparseRowExpr(node.(nodes.RowExpr))
 	case *nodes.RowExpr:
 		return c.deparseRowExpr(*node.(*nodes.RowExpr))
+	case nodes.RuleStmt:
+		return c.deparseRuleStmt(node.(nodes.RuleStmt))
+	case *nodes.RuleStmt:
+		return c.deparseRuleStmt(*node.(*nodes.RuleStmt))
 	case nodes.SelectStmt:
 		return c.deparseSelect(node.(nodes.SelectStmt))
 	case *nodes.SelectStmt:
 		return c.deparseSelect(*node.(*nodes.SelectStmt))
+	case nodes.SetToDefault:
+		return c.deparseSetToDefault(node.(nodes.SetToDefault))
+	case *nodes.SetToDefault:
+		return c.deparseSetToDefault(*node.(*nodes.SetToDefault))
 	case nodes.SortBy:
 		return c.deparseSortBy(node.(nodes.SortBy))
 	case *nodes.SortBy:
 		return c.deparseSortBy(*node.(*nodes.SortBy))
+	case nodes.SQLValueFunction:
+		return c.deparseSQLValueFunction(node.(nodes.SQLValueFunction))
+	case *nodes.SQLValueFunction:
+		return c.deparseSQLValueFunction(*node.(*nodes.SQLValueFunction))
 	case nodes.String:
 		switch c.Context {
 		case "select":
@@ -247,6 +461,18 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseSubLink(node.(nodes.SubLink))
 	case *nodes.SubLink:
 		return c.deparseSubLink(*node.(*nodes.SubLink))
+	case nodes.TransactionStmt:
+		return c.deparseTransactionStmt(node.(nodes.TransactionStmt))
+	case *nodes.TransactionStmt:
+		return c.deparseTransactionStmt(*node.(*nodes.TransactionStmt))
+	case nodes.TriggerTransition:
+		return c.deparseTriggerTransition(node.(nodes.TriggerTransition))
+	case *nodes.TriggerTransition:
+		return c.deparseTriggerTransition(*node.(*nodes.TriggerTransition))
+	case nodes.TruncateStmt:
+		return c.deparseTruncateStmt(node.(nodes.TruncateStmt))
+	case *nodes.TruncateStmt:
+		return c.deparseTruncateStmt(*node.(*nodes.TruncateStmt))
 	case nodes.TypeCast:
 		return c.deparseTypeCast(node.(nodes.TypeCast))
 	case *nodes.TypeCast:
@@ -255,6 +481,30 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseTypeName(node.(nodes.TypeName))
 	case *nodes.TypeName:
 		return c.deparseTypeName(*node.(*nodes.TypeName))
+	case nodes.UnlistenStmt:
+		return c.deparseUnlistenStmt(node.(nodes.UnlistenStmt))
+	case *nodes.UnlistenStmt:
+		return c.deparseUnlistenStmt(*node.(*nodes.UnlistenStmt))
+	case nodes.UpdateStmt:
+		return c.deparseUpdateStmt(node.(nodes.UpdateStmt))
+	case *nodes.UpdateStmt:
+		return c.deparseUpdateStmt(*node.(*nodes.UpdateStmt))
+	case nodes.VacuumStmt:
+		return c.deparseVacuumStmt(node.(nodes.VacuumStmt))
+	case *nodes.VacuumStmt:
+		return c.deparseVacuumStmt(*node.(*nodes.VacuumStmt))
+	case nodes.VariableSetStmt:
+		return c.deparseVariableSetStmt(node.(nodes.VariableSetStmt))
+	case *nodes.VariableSetStmt:
+		return c.deparseVariableSetStmt(*node.(*nodes.VariableSetStmt))
+	case nodes.VariableShowStmt:
+		return c.deparseVariableShowStmt(node.(nodes.VariableShowStmt))
+	case *nodes.VariableShowStmt:
+		return c.deparseVariableShowStmt(*node.(*nodes.VariableShowStmt))
+	case nodes.ViewStmt:
+		return c.deparseViewStmt(node.(nodes.ViewStmt))
+	case *nodes.ViewStmt:
+		return c.deparseViewStmt(*node.(*nodes.ViewStmt))
 	case nodes.WithClause:
 		return c.deparseWithClause(node.(nodes.WithClause))
 	case *nodes.WithClause:
@@ -263,6 +513,14 @@ func (c DeparseContext) deparseItem(node nodes.Node) (string, error) {
 		return c.deparseWindowDef(node.(nodes.WindowDef))
 	case *nodes.WindowDef:
 		return c.deparseWindowDef(*node.(*nodes.WindowDef))
+	case nodes.XmlExpr:
+		return c.deparseXmlExpr(node.(nodes.XmlExpr))
+	case *nodes.XmlExpr:
+		return c.deparseXmlExpr(*node.(*nodes.XmlExpr))
+	case nodes.XmlSerialize:
+		return c.deparseXmlSerialize(node.(nodes.XmlSerialize))
+	case *nodes.XmlSerialize:
+		return c.deparseXmlSerialize(*node.(*nodes.XmlSerialize))
 	default:
 		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
 	}
@@ -280,6 +538,498 @@ func (c DeparseContext) deparseItemList(list nodes.List) ([]string, error) {
 	return results, nil
 }
 
+// Names of the lock modes, indexed by their number (see storage/lockdefs.h)
+var lockModeNames = []string{
+	"",
+	"ACCESS SHARE",
+	"ROW SHARE",
+	"ROW EXCLUSIVE",
+	"SHARE UPDATE EXCLUSIVE",
+	"SHARE",
+	"SHARE ROW EXCLUSIVE",
+	"EXCLUSIVE",
+	"ACCESS EXCLUSIVE",
+}
+
+// SQL names of the built-in types without modifiers, by their internal name
+var sqlTypeNames = map[string]string{
+	"bool":   "boolean",
+	"int2":   "smallint",
+	"int4":   "int",
+	"int8":   "bigint",
+	"float4": "real",
+	"float8": "double precision",
+}
+
+// Interval field restrictions, as bit masks of the fields included (see
+// utils/datetime.h)
+const intervalFullRange = 0x7FFF
+
+var intervalFieldNames = map[int64]string{
+	1 << 2:                       "YEAR",
+	1 << 1:                       "MONTH",
+	1 << 3:                       "DAY",
+	1 << 10:                      "HOUR",
+	1 << 11:                      "MINUTE",
+	1 << 12:                      "SECOND",
+	1<<2 | 1<<1:                  "YEAR TO MONTH",
+	1<<3 | 1<<10:                 "DAY TO HOUR",
+	1<<3 | 1<<10 | 1<<11:         "DAY TO MINUTE",
+	1<<3 | 1<<10 | 1<<11 | 1<<12: "DAY TO SECOND",
+	1<<10 | 1<<11:                "HOUR TO MINUTE",
+	1<<10 | 1<<11 | 1<<12:        "HOUR TO SECOND",
+	1<<11 | 1<<12:                "MINUTE TO SECOND",
+}
+
+// deparseIntervalTypmods returns the field restriction (e.g. "HOUR TO
+// MINUTE") and the seconds precision of an interval type, if any
+func deparseIntervalTypmods(typmods nodes.List) (string, string, error) {
+	fields := ""
+	precision := ""
+	for i, item := range typmods.Items {
+		aConst, ok := item.(nodes.A_Const)
+		if !ok {
+			return "", "", fmt.Errorf("Can't deparse interval typmod: %# v", pretty.Formatter(item))
+		}
+		value, ok := aConst.Val.(nodes.Integer)
+		if !ok {
+			return "", "", fmt.Errorf("Can't deparse interval typmod: %# v", pretty.Formatter(item))
+		}
+		switch {
+		case i == 0 && value.Ival == intervalFullRange:
+		case i == 0:
+			fields, ok = intervalFieldNames[value.Ival]
+			if !ok {
+				return "", "", fmt.Errorf("Can't deparse interval fields %d", value.Ival)
+			}
+		default:
+			precision = fmt.Sprintf("%d", value.Ival)
+		}
+	}
+	return fields, precision, nil
+}
+
+// quoteIdentifier quotes name if it wouldn't be read back as the same
+// identifier otherwise
+func quoteIdentifier(name string) string {
+	needsQuotes := name == ""
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c == '_' || (i > 0 && (c >= '0' && c <= '9' || c == '$'))) {
+			needsQuotes = true
+			break
+		}
+	}
+	if category, ok := keywords[name]; ok && category != unreservedKeyword {
+		needsQuotes = true
+	}
+	if !needsQuotes {
+		return name
+	}
+	return fmt.Sprintf(`"%s"`, strings.Replace(name, `"`, `""`, -1))
+}
+
+// quoteQualifiedName quotes the parts of a dotted name, e.g. of a setting
+func quoteQualifiedName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func quoteString(value string) string {
+	return fmt.Sprintf(`'%s'`, strings.Replace(value, `'`, `''`, -1))
+}
+
+// quoteOptionValue writes the value of a generic option, which can be a word
+// or a string constant
+func quoteOptionValue(value string) string {
+	if value == "true" || value == "false" || quoteIdentifier(value) == value {
+		return value
+	}
+	return quoteString(value)
+}
+
+// Trigger type bits of CreateTrigStmt.Timing and Events (see
+// catalog/pg_trigger.h)
+const (
+	triggerTypeBefore   = 1 << 1
+	triggerTypeInsert   = 1 << 2
+	triggerTypeDelete   = 1 << 3
+	triggerTypeUpdate   = 1 << 4
+	triggerTypeTruncate = 1 << 5
+	triggerTypeInstead  = 1 << 6
+)
+
+// dollarQuote writes a string constant in dollar quotes, e.g. a function
+// body, with a tag that doesn't occur in the string itself
+func dollarQuote(value string) string {
+	tags := []string{"", "function", "body"}
+	for i := 1; ; i++ {
+		tag := fmt.Sprintf("body%d", i)
+		if i <= len(tags) {
+			tag = tags[i-1]
+		}
+		delimiter := "$" + tag + "$"
+		// The string mustn't contain the delimiter, nor end in a prefix of it
+		if strings.Index(value+delimiter, delimiter) == len(value) {
+			return delimiter + value + delimiter
+		}
+	}
+}
+
+// Bits of WindowDef.FrameOptions (see nodes/parsenodes.h)
+const (
+	frameOptionNonDefault              = 1 << 0
+	frameOptionRange                   = 1 << 1
+	frameOptionRows                    = 1 << 2
+	frameOptionBetween                 = 1 << 3
+	frameOptionStartUnboundedPreceding = 1 << 4
+	frameOptionEndUnboundedPreceding   = 1 << 5
+	frameOptionStartUnboundedFollowing = 1 << 6
+	frameOptionEndUnboundedFollowing   = 1 << 7
+	frameOptionStartCurrentRow         = 1 << 8
+	frameOptionEndCurrentRow           = 1 << 9
+	frameOptionStartValuePreceding     = 1 << 10
+	frameOptionEndValuePreceding       = 1 << 11
+	frameOptionStartValueFollowing     = 1 << 12
+	frameOptionEndValueFollowing       = 1 << 13
+)
+
+// Characters that operators consist of
+const operatorChars = "+-*/<>=~!@#%^&|`?"
+
+// deparseOperatorName writes a possibly qualified operator, e.g.
+// OPERATOR(pg_catalog.+)
+func deparseOperatorName(names nodes.List) string {
+	parts := []string{}
+	for _, item := range names.Items {
+		if str, ok := item.(nodes.String); ok {
+			parts = append(parts, str.Str)
+		}
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	for i := range parts[:len(parts)-1] {
+		parts[i] = quoteIdentifier(parts[i])
+	}
+	return fmt.Sprintf("OPERATOR(%s)", strings.Join(parts, "."))
+}
+
+// Pattern matching operators, by the name of the operator they're parsed to
+var likeOperators = map[string]string{
+	"~~":   "LIKE",
+	"!~~":  "NOT LIKE",
+	"~~*":  "ILIKE",
+	"!~~*": "NOT ILIKE",
+	"~":    "SIMILAR TO",
+	"!~":   "NOT SIMILAR TO",
+}
+
+// Operator precedence levels of the PostgreSQL grammar, from the loosest to
+// the tightest binding
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedenceIs         // IS NULL, IS TRUE, IS DISTINCT FROM, IS OF, IS DOCUMENT
+	precedenceComparison // < > = <= >= <>
+	precedenceLike       // LIKE, ILIKE, SIMILAR TO, BETWEEN, IN
+	precedenceEscape
+	precedencePostfix
+	precedenceOp // any other operator
+	precedenceAdd
+	precedenceMultiply
+	precedenceExponent
+	precedenceCollate
+	precedenceUnary
+	precedenceTypeCast
+	precedenceAtom // anything that never needs parentheses, e.g. a function call
+)
+
+// operatorPrecedence returns the precedence of a binary or prefix operator.
+// Qualified operators, e.g. OPERATOR(pg_catalog.+), always have the
+// precedence of generic operators
+func operatorPrecedence(names nodes.List, prefix bool) int {
+	if len(names.Items) != 1 {
+		return precedenceOp
+	}
+	str, _ := names.Items[0].(nodes.String)
+	if prefix {
+		if str.Str == "+" || str.Str == "-" {
+			return precedenceUnary
+		}
+		return precedenceOp
+	}
+	switch str.Str {
+	case "<", ">", "=", "<=", ">=", "<>", "!=":
+		return precedenceComparison
+	case "+", "-":
+		return precedenceAdd
+	case "*", "/", "%":
+		return precedenceMultiply
+	case "^":
+		return precedenceExponent
+	}
+	return precedenceOp
+}
+
+// exprPrecedence returns the precedence of the outermost operator of an
+// expression
+func exprPrecedence(node nodes.Node) int {
+	switch node := node.(type) {
+	case nodes.A_Const:
+		// Negative numbers are parsed from a unary minus, so -1::int is
+		// -(1::int)
+		switch val := node.Val.(type) {
+		case nodes.Integer:
+			if val.Ival < 0 {
+				return precedenceUnary
+			}
+		case nodes.Float:
+			if strings.HasPrefix(val.Str, "-") {
+				return precedenceUnary
+			}
+		}
+	case *nodes.A_Const:
+		return exprPrecedence(*node)
+	case nodes.A_Expr:
+		switch node.Kind {
+		case nodes.AEXPR_OP:
+			if node.Lexpr == nil {
+				return operatorPrecedence(node.Name, true)
+			}
+			if node.Rexpr == nil {
+				return precedencePostfix
+			}
+			return operatorPrecedence(node.Name, false)
+		case nodes.AEXPR_OP_ANY, nodes.AEXPR_OP_ALL:
+			return operatorPrecedence(node.Name, false)
+		case nodes.AEXPR_DISTINCT, nodes.AEXPR_NOT_DISTINCT, nodes.AEXPR_OF:
+			return precedenceIs
+		case nodes.AEXPR_IN,
+			nodes.AEXPR_LIKE,
+			nodes.AEXPR_ILIKE,
+			nodes.AEXPR_SIMILAR,
+			nodes.AEXPR_BETWEEN,
+			nodes.AEXPR_NOT_BETWEEN,
+			nodes.AEXPR_BETWEEN_SYM,
+			nodes.AEXPR_NOT_BETWEEN_SYM:
+			return precedenceLike
+		}
+	case *nodes.A_Expr:
+		return exprPrecedence(*node)
+	case nodes.BoolExpr:
+		switch node.Boolop {
+		case nodes.AND_EXPR:
+			return precedenceAnd
+		case nodes.OR_EXPR:
+			return precedenceOr
+		default:
+			return precedenceNot
+		}
+	case *nodes.BoolExpr:
+		return exprPrecedence(*node)
+	case nodes.BooleanTest, *nodes.BooleanTest, nodes.NullTest, *nodes.NullTest:
+		return precedenceIs
+	case nodes.CollateClause, *nodes.CollateClause:
+		return precedenceCollate
+	case nodes.SubLink:
+		switch node.SubLinkType {
+		case nodes.ANY_SUBLINK:
+			if node.OperName.Items == nil {
+				return precedenceLike
+			}
+			return operatorPrecedence(node.OperName, false)
+		case nodes.ALL_SUBLINK, nodes.ROWCOMPARE_SUBLINK:
+			return operatorPrecedence(node.OperName, false)
+		}
+	case *nodes.SubLink:
+		return exprPrecedence(*node)
+	case nodes.TypeCast, *nodes.TypeCast:
+		return precedenceTypeCast
+	case nodes.XmlExpr:
+		if node.Op == nodes.IS_DOCUMENT {
+			return precedenceIs
+		}
+	case *nodes.XmlExpr:
+		return exprPrecedence(*node)
+	}
+	return precedenceAtom
+}
+
+// isPrefixOperator returns whether node is a prefix operator expression,
+// e.g. -a
+func isPrefixOperator(node nodes.Node) bool {
+	switch node := node.(type) {
+	case nodes.A_Expr:
+		return node.Kind == nodes.AEXPR_OP && node.Lexpr == nil
+	case *nodes.A_Expr:
+		return node.Kind == nodes.AEXPR_OP && node.Lexpr == nil
+	}
+	return false
+}
+
+// deparseOperand deparses an operand of an operator with the given
+// precedence, adding parentheses if the operand binds less tightly. Operands
+// that bind as tightly as the operator are parenthesized when parenthesizeEqual
+// is set, e.g. the right operand of a - (b - c)
+func (c DeparseContext) deparseOperand(node nodes.Node, precedence int, parenthesizeEqual bool) (string, error) {
+	result, err := c.deparseItem(node)
+	if err != nil {
+		return "", err
+	}
+	operand := exprPrecedence(node)
+	if operand < precedence || (parenthesizeEqual && operand == precedence) {
+		return fmt.Sprintf("(%s)", result), nil
+	}
+	return result, nil
+}
+
+// Functions that are written like keywords, e.g. CURRENT_TIMESTAMP
+var sqlValueFunctionNames = map[nodes.SQLValueFunctionOp]string{
+	nodes.SVFOP_CURRENT_DATE:        "CURRENT_DATE",
+	nodes.SVFOP_CURRENT_TIME:        "CURRENT_TIME",
+	nodes.SVFOP_CURRENT_TIME_N:      "CURRENT_TIME",
+	nodes.SVFOP_CURRENT_TIMESTAMP:   "CURRENT_TIMESTAMP",
+	nodes.SVFOP_CURRENT_TIMESTAMP_N: "CURRENT_TIMESTAMP",
+	nodes.SVFOP_LOCALTIME:           "LOCALTIME",
+	nodes.SVFOP_LOCALTIME_N:         "LOCALTIME",
+	nodes.SVFOP_LOCALTIMESTAMP:      "LOCALTIMESTAMP",
+	nodes.SVFOP_LOCALTIMESTAMP_N:    "LOCALTIMESTAMP",
+	nodes.SVFOP_CURRENT_ROLE:        "CURRENT_ROLE",
+	nodes.SVFOP_CURRENT_USER:        "CURRENT_USER",
+	nodes.SVFOP_USER:                "USER",
+	nodes.SVFOP_SESSION_USER:        "SESSION_USER",
+	nodes.SVFOP_CURRENT_CATALOG:     "CURRENT_CATALOG",
+	nodes.SVFOP_CURRENT_SCHEMA:      "CURRENT_SCHEMA",
+}
+
+func deparseXmlOption(option nodes.XmlOptionType) string {
+	if option == nodes.XMLOPTION_DOCUMENT {
+		return "DOCUMENT"
+	}
+	return "CONTENT"
+}
+
+// deparseAnyName writes a possibly qualified name, e.g. of a function
+func deparseAnyName(names nodes.List) string {
+	return strings.Join(quoteNames(names), ".")
+}
+
+// quoteNames quotes a list of names, e.g. the schemas of a GRANT
+func quoteNames(names nodes.List) []string {
+	parts := []string{}
+	for _, item := range names.Items {
+		if str, ok := item.(nodes.String); ok {
+			parts = append(parts, quoteIdentifier(str.Str))
+		}
+	}
+	return parts
+}
+
+// isPublicRole returns whether node is the PUBLIC pseudo-role
+func isPublicRole(node nodes.Node) bool {
+	switch role := node.(type) {
+	case nodes.RoleSpec:
+		return role.Roletype == nodes.ROLESPEC_PUBLIC
+	case *nodes.RoleSpec:
+		return role.Roletype == nodes.ROLESPEC_PUBLIC
+	}
+	return false
+}
+
+// Keywords of the kinds of objects that can be altered, e.g. in ALTER ...
+// OWNER TO
+var objectTypeNames = map[nodes.ObjectType]string{
+	nodes.OBJECT_AGGREGATE:       "AGGREGATE",
+	nodes.OBJECT_COLLATION:       "COLLATION",
+	nodes.OBJECT_CONVERSION:      "CONVERSION",
+	nodes.OBJECT_DATABASE:        "DATABASE",
+	nodes.OBJECT_DOMAIN:          "DOMAIN",
+	nodes.OBJECT_EVENT_TRIGGER:   "EVENT TRIGGER",
+	nodes.OBJECT_FDW:             "FOREIGN DATA WRAPPER",
+	nodes.OBJECT_FOREIGN_SERVER:  "SERVER",
+	nodes.OBJECT_FUNCTION:        "FUNCTION",
+	nodes.OBJECT_LANGUAGE:        "LANGUAGE",
+	nodes.OBJECT_LARGEOBJECT:     "LARGE OBJECT",
+	nodes.OBJECT_OPCLASS:         "OPERATOR CLASS",
+	nodes.OBJECT_OPERATOR:        "OPERATOR",
+	nodes.OBJECT_OPFAMILY:        "OPERATOR FAMILY",
+	nodes.OBJECT_PUBLICATION:     "PUBLICATION",
+	nodes.OBJECT_SCHEMA:          "SCHEMA",
+	nodes.OBJECT_STATISTIC_EXT:   "STATISTICS",
+	nodes.OBJECT_SUBSCRIPTION:    "SUBSCRIPTION",
+	nodes.OBJECT_TABLESPACE:      "TABLESPACE",
+	nodes.OBJECT_TSCONFIGURATION: "TEXT SEARCH CONFIGURATION",
+	nodes.OBJECT_TSDICTIONARY:    "TEXT SEARCH DICTIONARY",
+	nodes.OBJECT_TYPE:            "TYPE",
+}
+
+// Keywords of the kinds of relations ALTER TABLE and its variants alter
+var relationTypeNames = map[nodes.ObjectType]string{
+	nodes.OBJECT_FOREIGN_TABLE: "FOREIGN TABLE",
+	nodes.OBJECT_INDEX:         "INDEX",
+	nodes.OBJECT_MATVIEW:       "MATERIALIZED VIEW",
+	nodes.OBJECT_SEQUENCE:      "SEQUENCE",
+	nodes.OBJECT_TABLE:         "TABLE",
+	nodes.OBJECT_VIEW:          "VIEW",
+}
+
+// Keywords of the kinds of objects privileges are granted on
+var grantObjectTypeNames = map[nodes.GrantObjectType]string{
+	nodes.ACL_OBJECT_RELATION:       "TABLE",
+	nodes.ACL_OBJECT_SEQUENCE:       "SEQUENCE",
+	nodes.ACL_OBJECT_DATABASE:       "DATABASE",
+	nodes.ACL_OBJECT_DOMAIN:         "DOMAIN",
+	nodes.ACL_OBJECT_FDW:            "FOREIGN DATA WRAPPER",
+	nodes.ACL_OBJECT_FOREIGN_SERVER: "FOREIGN SERVER",
+	nodes.ACL_OBJECT_FUNCTION:       "FUNCTION",
+	nodes.ACL_OBJECT_LANGUAGE:       "LANGUAGE",
+	nodes.ACL_OBJECT_LARGEOBJECT:    "LARGE OBJECT",
+	nodes.ACL_OBJECT_NAMESPACE:      "SCHEMA",
+	nodes.ACL_OBJECT_TABLESPACE:     "TABLESPACE",
+	nodes.ACL_OBJECT_TYPE:           "TYPE",
+}
+
+// Keywords of the kinds of objects in GRANT ... ON ALL ... IN SCHEMA and
+// ALTER DEFAULT PRIVILEGES
+var grantObjectTypePluralNames = map[nodes.GrantObjectType]string{
+	nodes.ACL_OBJECT_RELATION:  "TABLES",
+	nodes.ACL_OBJECT_SEQUENCE:  "SEQUENCES",
+	nodes.ACL_OBJECT_FUNCTION:  "FUNCTIONS",
+	nodes.ACL_OBJECT_TYPE:      "TYPES",
+	nodes.ACL_OBJECT_NAMESPACE: "SCHEMAS",
+}
+
+// Keywords of the boolean role options, which are negated with NO, e.g.
+// NOLOGIN
+var roleOptionKeywords = map[string]string{
+	"bypassrls":     "BYPASSRLS",
+	"canlogin":      "LOGIN",
+	"createdb":      "CREATEDB",
+	"createrole":    "CREATEROLE",
+	"inherit":       "INHERIT",
+	"isreplication": "REPLICATION",
+	"superuser":     "SUPERUSER",
+}
+
+// deparseRelPersistence returns the keyword for a temporary or unlogged relation
+func deparseRelPersistence(relation *nodes.RangeVar) string {
+	if relation == nil {
+		return ""
+	}
+	switch relation.Relpersistence {
+	case 't':
+		return "TEMPORARY"
+	case 'u':
+		return "UNLOGGED"
+	}
+	return ""
+}
+
 func (c DeparseContext) deparseA_ArrayExpr(node nodes.A_ArrayExpr) (string, error) {
 	elementItems, err := c.deparseItemList(node.Elements)
 	if err != nil {
@@ -289,58 +1039,67 @@ func (c DeparseContext) deparseA_ArrayExpr(node nodes.A_ArrayExpr) (string, erro
 }
 
 func (c DeparseContext) deparseA_Const(node nodes.A_Const) (string, error) {
-	ctx := DeparseContext{Context: "a_const"}
+	ctx := c.withContext("a_const")
 	return ctx.deparseItem(node.Val)
 }
 
 func (c DeparseContext) deparseA_Expr(node nodes.A_Expr) (string, error) {
-	output := []string{}
-	var ctx DeparseContext
-	if c.Context != "" {
-		ctx = c
-	} else {
-		ctx = DeparseContext{Context: "a_expr"}
+	operator := deparseOperatorName(node.Name)
+	precedence := exprPrecedence(node)
+	if node.Lexpr == nil {
+		// Prefix operator, e.g. -a. Nested prefix operators need no
+		// parentheses, e.g. - -a
+		rexpr, err := c.deparseOperand(node.Rexpr, precedence, !isPrefixOperator(node.Rexpr))
+		if err != nil {
+			return "", err
+		}
+		if strings.ContainsAny(rexpr[:1], operatorChars) || strings.HasPrefix(operator, "OPERATOR(") {
+			return operator + " " + rexpr, nil
+		}
+		return operator + rexpr, nil
 	}
 
-	lexpr, err := ctx.deparseItem(node.Lexpr)
-	if err != nil {
-		return "", err
+	if node.Rexpr == nil {
+		// Postfix operator, e.g. a !. Nested postfix operators can't be
+		// parsed without parentheses
+		lexpr, err := c.deparseOperand(node.Lexpr, precedence, true)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s", lexpr, operator), nil
 	}
-	output = append(output, lexpr)
-	rexpr, err := ctx.deparseItem(node.Rexpr)
+
+	// Comparison operators are not associative, the others associate to the
+	// left
+	lexpr, err := c.deparseOperand(node.Lexpr, precedence, precedence == precedenceComparison)
 	if err != nil {
 		return "", err
 	}
-	output = append(output, rexpr)
-	opctx := DeparseContext{Context: "operator"}
-	operator, err := opctx.deparseItem(node.Name.Items[0])
+	rexpr, err := c.deparseOperand(node.Rexpr, precedence, true)
 	if err != nil {
 		return "", err
 	}
-	result := strings.Join(output, fmt.Sprintf(" %s ", operator))
-	if c.Context == "a_expr" {
-		result = fmt.Sprintf("(%s)", result)
-	}
-	return result, nil
+	return fmt.Sprintf("%s %s %s", lexpr, operator, rexpr), nil
 }
 
 func (c DeparseContext) deparseA_ExprAny(node nodes.A_Expr) (string, error) {
 	output := []string{}
-	lexpr, err := c.deparseItem(node.Lexpr)
+	precedence := exprPrecedence(node)
+	lexpr, err := c.deparseOperand(node.Lexpr, precedence, precedence == precedenceComparison)
 	if err != nil {
 		return "", err
 	}
 	output = append(output, lexpr)
-	ctx := DeparseContext{Context: "operator"}
-	operator, err := ctx.deparseItem(node.Name.Items[0])
-	if err != nil {
-		return "", err
-	}
+	operator := deparseOperatorName(node.Name)
 	rexpr, err := c.deparseItem(node.Rexpr)
 	if err != nil {
 		return "", err
 	}
-	output = append(output, fmt.Sprintf("ANY(%s)", rexpr))
+	if node.Kind == nodes.AEXPR_OP_ALL {
+		output = append(output, fmt.Sprintf("ALL(%s)", rexpr))
+	} else {
+		output = append(output, fmt.Sprintf("ANY(%s)", rexpr))
+	}
 	return strings.Join(output, fmt.Sprintf(" %s ", operator)), nil
 }
 
@@ -356,7 +1115,7 @@ func (c DeparseContext) deparseA_ExprBetween(node nodes.A_Expr) (string, error)
 	case nodes.AEXPR_NOT_BETWEEN_SYM:
 		between = "NOT BETWEEN SYMMETRIC"
 	}
-	lexpr, err := c.deparseItem(node.Lexpr)
+	lexpr, err := c.deparseOperand(node.Lexpr, precedenceLike, true)
 	if err != nil {
 		return "", err
 	}
@@ -364,9 +1123,17 @@ func (c DeparseContext) deparseA_ExprBetween(node nodes.A_Expr) (string, error)
 	var rexpr string
 	switch node.Rexpr.(type) {
 	case nodes.List:
-		rexprItems, err := c.deparseItemList(node.Rexpr.(nodes.List))
-		if err != nil {
-			return "", err
+		rexprItems := []string{}
+		for _, item := range node.Rexpr.(nodes.List).Items {
+			// The bounds can't contain COLLATE either
+			rexprItem, err := c.deparseOperand(item, precedenceLike, true)
+			if err != nil {
+				return "", err
+			}
+			if exprPrecedence(item) == precedenceCollate {
+				rexprItem = fmt.Sprintf("(%s)", rexprItem)
+			}
+			rexprItems = append(rexprItems, rexprItem)
 		}
 		rexpr = strings.Join(rexprItems, " AND ")
 	case nodes.Node:
@@ -379,12 +1146,27 @@ func (c DeparseContext) deparseA_ExprBetween(node nodes.A_Expr) (string, error)
 	return fmt.Sprintf("%s %s %s", lexpr, between, rexpr), nil
 }
 
+func (c DeparseContext) deparseA_ExprDistinct(node nodes.A_Expr) (string, error) {
+	lexpr, err := c.deparseOperand(node.Lexpr, precedenceIs, true)
+	if err != nil {
+		return "", err
+	}
+	rexpr, err := c.deparseOperand(node.Rexpr, precedenceIs, true)
+	if err != nil {
+		return "", err
+	}
+	if node.Kind == nodes.AEXPR_NOT_DISTINCT {
+		return fmt.Sprintf("%s IS NOT DISTINCT FROM %s", lexpr, rexpr), nil
+	}
+	return fmt.Sprintf("%s IS DISTINCT FROM %s", lexpr, rexpr), nil
+}
+
 func (c DeparseContext) deparseA_ExprIn(node nodes.A_Expr) (string, error) {
-	lexpr, err := c.deparseItem(node.Lexpr)
+	lexpr, err := c.deparseOperand(node.Lexpr, precedenceLike, true)
 	if err != nil {
 		return "", err
 	}
-	ctx := DeparseContext{Context: "operator"}
+	ctx := c.withContext("operator")
 	nameItems, err := ctx.deparseItemList(node.Name)
 	if err != nil {
 		return "", err
@@ -414,26 +1196,45 @@ func (c DeparseContext) deparseA_ExprIn(node nodes.A_Expr) (string, error) {
 }
 
 func (c DeparseContext) deparseA_ExprLike(node nodes.A_Expr) (string, error) {
-	rexpr, err := c.deparseItem(node.Rexpr)
-	if err != nil {
-		return "", err
+	// The escape character is passed to a function, e.g. like_escape(b, 'x')
+	// for a LIKE b ESCAPE 'x'
+	pattern := node.Rexpr
+	var escape nodes.Node
+	if funcCall, ok := node.Rexpr.(nodes.FuncCall); ok && len(funcCall.Args.Items) == 2 {
+		switch strings.Join(quoteNames(funcCall.Funcname), ".") {
+		case "pg_catalog.like_escape", "pg_catalog.similar_escape":
+			pattern = funcCall.Args.Items[0]
+			escape = funcCall.Args.Items[1]
+			if aConst, ok := escape.(nodes.A_Const); ok {
+				if _, ok := aConst.Val.(nodes.Null); ok {
+					escape = nil
+				}
+			}
+		}
 	}
-	ctx := DeparseContext{Context: "operator"}
-	nameItems, err := ctx.deparseItemList(node.Name)
+
+	rexpr, err := c.deparseOperand(pattern, precedenceLike, true)
 	if err != nil {
 		return "", err
 	}
-	var operator string
-	if nameItems[0] == "~~" {
-		operator = "LIKE"
-	} else {
-		operator = "NOT LIKE"
+	operator, ok := likeOperators[deparseOperatorName(node.Name)]
+	if !ok {
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
 	}
-	lexpr, err := c.deparseItem(node.Lexpr)
+	lexpr, err := c.deparseOperand(node.Lexpr, precedenceLike, true)
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%s %s %s", lexpr, operator, rexpr), nil
+	output := fmt.Sprintf("%s %s %s", lexpr, operator, rexpr)
+
+	if escape != nil {
+		escapeItem, err := c.deparseOperand(escape, precedenceEscape, true)
+		if err != nil {
+			return "", err
+		}
+		output = fmt.Sprintf("%s ESCAPE %s", output, escapeItem)
+	}
+	return output, nil
 }
 
 func (c DeparseContext) deparseA_ExprNullif(node nodes.A_Expr) (string, error) {
@@ -448,26 +1249,83 @@ func (c DeparseContext) deparseA_ExprNullif(node nodes.A_Expr) (string, error) {
 	return fmt.Sprintf("NULLIF(%s, %s)", lexpr, rexpr), nil
 }
 
-func (c DeparseContext) deparseA_Indices(node nodes.A_Indices) (string, error) {
-	uidx, err := c.deparseItem(node.Uidx)
+func (c DeparseContext) deparseA_ExprOf(node nodes.A_Expr) (string, error) {
+	lexpr, err := c.deparseOperand(node.Lexpr, precedenceIs, true)
+	if err != nil {
+		return "", err
+	}
+	types, ok := node.Rexpr.(nodes.List)
+	if !ok {
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+	typeItems, err := c.deparseItemList(types)
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("[%s]", uidx), nil
+	operator := "IS OF"
+	if deparseOperatorName(node.Name) == "<>" {
+		operator = "IS NOT OF"
+	}
+	return fmt.Sprintf("%s %s (%s)", lexpr, operator, strings.Join(typeItems, ", ")), nil
+}
+
+func (c DeparseContext) deparseA_Indices(node nodes.A_Indices) (string, error) {
+	output := ""
+	if node.Lidx != nil {
+		lidx, err := c.deparseItem(node.Lidx)
+		if err != nil {
+			return "", err
+		}
+		output = lidx
+	}
+	if node.IsSlice {
+		output += ":"
+	}
+	if node.Uidx != nil {
+		uidx, err := c.deparseItem(node.Uidx)
+		if err != nil {
+			return "", err
+		}
+		output += uidx
+	}
+	return fmt.Sprintf("[%s]", output), nil
 }
 
 func (c DeparseContext) deparseA_Indirection(node nodes.A_Indirection) (string, error) {
-	output := []string{}
 	arg, err := c.deparseItem(node.Arg)
 	if err != nil {
 		return "", err
 	}
-	output = append(output, arg)
-	indirectionItems, err := c.deparseItemList(node.Indirection)
-	if err != nil {
-		return "", err
+	// Only columns and parameters can be subscripted without parentheses,
+	// and a field of a column would be read as part of its name
+	switch node.Arg.(type) {
+	case nodes.ParamRef, *nodes.ParamRef:
+	case nodes.ColumnRef, *nodes.ColumnRef:
+		if len(node.Indirection.Items) > 0 {
+			switch node.Indirection.Items[0].(type) {
+			case nodes.A_Indices, *nodes.A_Indices:
+			default:
+				arg = fmt.Sprintf("(%s)", arg)
+			}
+		}
+	default:
+		arg = fmt.Sprintf("(%s)", arg)
+	}
+
+	output := []string{arg}
+	for _, item := range node.Indirection.Items {
+		indirection, err := c.deparseItem(item)
+		if err != nil {
+			return "", err
+		}
+		switch item.(type) {
+		case nodes.A_Indices, *nodes.A_Indices:
+			output = append(output, indirection)
+		default:
+			// Field selection, e.g. (f(x)).a
+			output = append(output, "."+indirection)
+		}
 	}
-	output = append(output, indirectionItems...)
 	return strings.Join(output, ""), nil
 }
 
@@ -475,6 +1333,21 @@ func (c DeparseContext) deparseA_Star(node nodes.A_Star) (string, error) {
 	return "*", nil
 }
 
+func (c DeparseContext) deparseAccessPriv(node nodes.AccessPriv) (string, error) {
+	output := "ALL"
+	if node.PrivName != nil {
+		output = strings.ToUpper(*node.PrivName)
+	}
+	if node.Cols.Items != nil {
+		colItems, err := c.deparseItemList(node.Cols)
+		if err != nil {
+			return "", err
+		}
+		output += fmt.Sprintf(" (%s)", strings.Join(colItems, ", "))
+	}
+	return output, nil
+}
+
 func (c DeparseContext) deparseAlias(node nodes.Alias) (string, error) {
 	name := *node.Aliasname
 	if node.Colnames.Items != nil {
@@ -487,50 +1360,221 @@ func (c DeparseContext) deparseAlias(node nodes.Alias) (string, error) {
 	return name, nil
 }
 
-func (c DeparseContext) deparseBooleanTest(node nodes.BooleanTest) (string, error) {
-	arg, err := c.deparseItem(node.Arg)
+func (c DeparseContext) deparseAlterDefaultPrivilegesStmt(node nodes.AlterDefaultPrivilegesStmt) (string, error) {
+	output := []string{"ALTER DEFAULT PRIVILEGES"}
+	for _, item := range node.Options.Items {
+		option, ok := item.(nodes.DefElem)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse default privileges option: %# v", pretty.Formatter(item))
+		}
+		list, ok := option.Arg.(nodes.List)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse default privileges option: %# v", pretty.Formatter(item))
+		}
+		switch *option.Defname {
+		case "schemas":
+			output = append(output, "IN SCHEMA", strings.Join(quoteNames(list), ", "))
+		case "roles":
+			roleItems, err := c.deparseItemList(list)
+			if err != nil {
+				return "", err
+			}
+			output = append(output, "FOR ROLE", strings.Join(roleItems, ", "))
+		default:
+			return "", fmt.Errorf("Can't deparse default privileges option %s", *option.Defname)
+		}
+	}
+
+	action, err := c.deparseItem(node.Action)
 	if err != nil {
 		return "", err
 	}
-	var booltest string
-	switch node.Booltesttype {
-	case nodes.IS_TRUE:
-		booltest = "IS TRUE"
-	case nodes.IS_NOT_TRUE:
-		booltest = "IS NOT TRUE"
-	case 0x2:
-		booltest = "IS FALSE"
-	case 0x3:
-		booltest = "IS NOT FALSE"
-	case 0x4:
-		booltest = "IS UNKNOWN"
-	case 0x5:
+	output = append(output, action)
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseAlterOwnerStmt(node nodes.AlterOwnerStmt) (string, error) {
+	objectType, ok := objectTypeNames[node.ObjectType]
+	if !ok {
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+	output := []string{"ALTER", objectType}
+
+	if node.Relation != nil {
+		relation, err := c.deparseItem(node.Relation)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, relation)
+	} else {
+		object, err := c.deparseObjectName(node.ObjectType, node.Object)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, object)
+	}
+
+	newowner, err := c.deparseItem(node.Newowner)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, "OWNER TO", newowner)
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseAlterTableCmd(node nodes.AlterTableCmd) (string, error) {
+	switch node.Subtype {
+	case nodes.AT_ChangeOwner:
+		newowner, err := c.deparseItem(node.Newowner)
+		if err != nil {
+			return "", err
+		}
+		return "OWNER TO " + newowner, nil
+	}
+	return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+}
+
+func (c DeparseContext) deparseAlterTableStmt(node nodes.AlterTableStmt) (string, error) {
+	relationType, ok := relationTypeNames[node.Relkind]
+	if !ok {
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+	output := []string{"ALTER", relationType}
+	if node.MissingOk {
+		output = append(output, "IF EXISTS")
+	}
+
+	relation, err := c.deparseItem(node.Relation)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, relation)
+
+	cmdItems, err := c.deparseItemList(node.Cmds)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, strings.Join(cmdItems, ", "))
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseAlterPolicyStmt(node nodes.AlterPolicyStmt) (string, error) {
+	table, err := c.deparseItem(node.Table)
+	if err != nil {
+		return "", err
+	}
+	output := []string{"ALTER POLICY", quoteIdentifier(*node.PolicyName), "ON", table}
+
+	if node.Roles.Items != nil {
+		roleItems, err := c.deparseItemList(node.Roles)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "TO", strings.Join(roleItems, ", "))
+	}
+
+	policyExprs, err := c.deparsePolicyExprs(node.Qual, node.WithCheck)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, policyExprs...)
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseAlterRoleStmt(node nodes.AlterRoleStmt) (string, error) {
+	role, err := c.deparseItem(node.Role)
+	if err != nil {
+		return "", err
+	}
+
+	// Members can only be added or dropped with ALTER GROUP
+	if len(node.Options.Items) == 1 {
+		if option, ok := node.Options.Items[0].(nodes.DefElem); ok && *option.Defname == "rolemembers" {
+			members, ok := option.Arg.(nodes.List)
+			if !ok {
+				return "", fmt.Errorf("Can't deparse role option: %# v", pretty.Formatter(option))
+			}
+			memberItems, err := c.deparseItemList(members)
+			if err != nil {
+				return "", err
+			}
+			action := "ADD"
+			if node.Action < 0 {
+				action = "DROP"
+			}
+			return fmt.Sprintf("ALTER GROUP %s %s USER %s", role, action, strings.Join(memberItems, ", ")), nil
+		}
+	}
+
+	output := []string{"ALTER ROLE", role}
+	if node.Options.Items != nil {
+		options, err := c.deparseRoleOptions(node.Options)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "WITH", options)
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseBooleanTest(node nodes.BooleanTest) (string, error) {
+	arg, err := c.deparseOperand(node.Arg, precedenceIs, true)
+	if err != nil {
+		return "", err
+	}
+	var booltest string
+	switch node.Booltesttype {
+	case nodes.IS_TRUE:
+		booltest = "IS TRUE"
+	case nodes.IS_NOT_TRUE:
+		booltest = "IS NOT TRUE"
+	case 0x2:
+		booltest = "IS FALSE"
+	case 0x3:
+		booltest = "IS NOT FALSE"
+	case 0x4:
+		booltest = "IS UNKNOWN"
+	case 0x5:
 		booltest = "IS NOT UNKNOWN"
 	}
 	return fmt.Sprintf("%s %s", arg, booltest), nil
 }
 
 func (c DeparseContext) deparseBoolExprAnd(node nodes.BoolExpr) (string, error) {
+	output, err := c.deparseBoolExprArgs(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(output, " AND "), nil
+}
+
+// deparseBoolExprArgs deparses the arguments of an AND or OR expression,
+// parenthesizing looser expressions and nested expressions of the same kind
+// after the first argument, e.g. a AND (b AND c). AND inside OR isn't
+// ambiguous but is parenthesized for readability
+func (c DeparseContext) deparseBoolExprArgs(node nodes.BoolExpr) ([]string, error) {
 	output := []string{}
-	for _, item := range node.Args.Items {
-		result, err := c.deparseItem(item)
-		if err != nil {
-			return "", err
-		}
-		switch item.(type) {
-		case nodes.BoolExpr:
-			switch item.(nodes.BoolExpr).Boolop {
-			case nodes.OR_EXPR:
-				result = fmt.Sprintf("(%s)", result)
+	precedence := exprPrecedence(node)
+	for i, item := range node.Args.Items {
+		if precedence == precedenceOr && exprPrecedence(item) == precedenceAnd {
+			result, err := c.deparseItem(item)
+			if err != nil {
+				return []string{}, err
 			}
+			output = append(output, fmt.Sprintf("(%s)", result))
+			continue
+		}
+		result, err := c.deparseOperand(item, precedence, i > 0)
+		if err != nil {
+			return []string{}, err
 		}
 		output = append(output, result)
 	}
-	return strings.Join(output, " AND "), nil
+	return output, nil
 }
 
 func (c DeparseContext) deparseBoolExprNot(node nodes.BoolExpr) (string, error) {
-	arg, err := c.deparseItem(node.Args.Items[0])
+	arg, err := c.deparseOperand(node.Args.Items[0], precedenceNot, false)
 	if err != nil {
 		return "", err
 	}
@@ -538,21 +1582,9 @@ func (c DeparseContext) deparseBoolExprNot(node nodes.BoolExpr) (string, error)
 }
 
 func (c DeparseContext) deparseBoolExprOr(node nodes.BoolExpr) (string, error) {
-	output := []string{}
-	for _, item := range node.Args.Items {
-		result, err := c.deparseItem(item)
-		if err != nil {
-			return "", err
-		}
-		//TODO parentheses
-		switch item.(type) {
-		case nodes.BoolExpr:
-			switch item.(nodes.BoolExpr).Boolop {
-			case nodes.AND_EXPR, nodes.OR_EXPR:
-				result = fmt.Sprintf("(%s)", result)
-			}
-		}
-		output = append(output, result)
+	output, err := c.deparseBoolExprArgs(node)
+	if err != nil {
+		return "", err
 	}
 	return strings.Join(output, " OR "), nil
 }
@@ -601,6 +1633,20 @@ func (c DeparseContext) deparseCaseWhen(node nodes.CaseWhen) (string, error) {
 	return strings.Join(output, " "), nil
 }
 
+// deparseCExpr deparses an expression that the grammar only accepts in
+// parentheses unless it's a column, constant, parameter or function call
+func (c DeparseContext) deparseCExpr(node nodes.Node) (string, error) {
+	result, err := c.withContext("select").deparseItem(node)
+	if err != nil {
+		return "", err
+	}
+	switch node.(type) {
+	case nodes.ColumnRef, *nodes.ColumnRef, nodes.A_Const, *nodes.A_Const, nodes.ParamRef, *nodes.ParamRef, nodes.FuncCall, *nodes.FuncCall:
+		return result, nil
+	}
+	return fmt.Sprintf("(%s)", result), nil
+}
+
 func (c DeparseContext) deparseCoalesceExpr(node nodes.CoalesceExpr) (string, error) {
 	argItems, err := c.deparseItemList(node.Args)
 	if err != nil {
@@ -610,6 +1656,14 @@ func (c DeparseContext) deparseCoalesceExpr(node nodes.CoalesceExpr) (string, er
 	return fmt.Sprintf("COALESCE(%s)", args), nil
 }
 
+func (c DeparseContext) deparseCollateClause(node nodes.CollateClause) (string, error) {
+	arg, err := c.deparseOperand(node.Arg, precedenceCollate, false)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s COLLATE %s", arg, deparseAnyName(node.Collname)), nil
+}
+
 func (c DeparseContext) deparseColumnDef(node nodes.ColumnDef) (string, error) {
 	output := []string{}
 	output = append(output, *node.Colname)
@@ -658,495 +1712,2964 @@ func (c DeparseContext) deparseCommonTableExpr(node nodes.CommonTableExpr) (stri
 		}
 		output = append(output, fmt.Sprintf("(%s)", strings.Join(aliascolnameItems, ", ")))
 	}
-	ctequery, err := c.deparseItem(node.Ctequery)
+	ctequery, err := c.deparseSubquery(node.Ctequery)
 	if err != nil {
 		return "", err
 	}
-	output = append(output, fmt.Sprintf("AS (%s)", ctequery))
+	output = append(output, fmt.Sprintf("AS %s", ctequery))
 	return strings.Join(output, " "), nil
 }
 
-func (c DeparseContext) deparseFuncCall(node nodes.FuncCall) (string, error) {
-	output := []string{}
-
-	argItems, err := c.deparseItemList(node.Args)
-	if err != nil {
-		return "", err
-	}
-	if node.AggStar {
-		argItems = append(argItems, "*")
-	}
-	args := strings.Join(argItems, ", ")
-
-	ctx := DeparseContext{Context: "func_call"}
-	funcnameItemsPre, err := ctx.deparseItemList(node.Funcname)
-	if err != nil {
-		return "", err
-	}
-	funcnameItems := funcnameItemsPre[:0]
-	for _, fn := range funcnameItemsPre {
-		if fn != "pg_catalog" {
-			funcnameItems = append(funcnameItems, fn)
+func (c DeparseContext) deparseCopyStmt(node nodes.CopyStmt) (string, error) {
+	output := []string{"COPY"}
+	if node.Relation != nil {
+		relation, err := c.deparseItem(node.Relation)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, relation)
+		if node.Attlist.Items != nil {
+			attlistItems, err := c.deparseItemList(node.Attlist)
+			if err != nil {
+				return "", err
+			}
+			output = append(output, fmt.Sprintf("(%s)", strings.Join(attlistItems, ", ")))
+		}
+	} else {
+		query, err := c.deparseSubquery(node.Query)
+		if err != nil {
+			return "", err
 		}
+		output = append(output, query)
 	}
-	funcname := strings.Join(funcnameItems, ".")
 
-	var distinct string
-	if node.AggDistinct {
-		distinct = "DISTINCT "
+	if node.IsFrom {
+		output = append(output, "FROM")
+	} else {
+		output = append(output, "TO")
+	}
+	switch {
+	case node.IsProgram:
+		output = append(output, "PROGRAM", quoteString(*node.Filename))
+	case node.Filename != nil:
+		output = append(output, quoteString(*node.Filename))
+	case node.IsFrom:
+		output = append(output, "STDIN")
+	default:
+		output = append(output, "STDOUT")
 	}
 
-	output = append(output, fmt.Sprintf("%s(%s%s)", funcname, distinct, args))
-	if node.Over != nil {
-		over, err := c.deparseItem(node.Over)
+	if node.Options.Items != nil {
+		options, err := c.deparseOptions(node.Options)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, fmt.Sprintf("OVER (%s)", over))
+		output = append(output, "WITH", options)
 	}
 
 	return strings.Join(output, " "), nil
 }
 
-func (c DeparseContext) deparseJoinExpr(node nodes.JoinExpr) (string, error) {
-	output := []string{}
-	larg, err := c.deparseItem(node.Larg)
-	if err != nil {
-		return "", err
+func (c DeparseContext) deparseCreateFunctionStmt(node nodes.CreateFunctionStmt) (string, error) {
+	output := []string{"CREATE"}
+	if node.Replace {
+		output = append(output, "OR REPLACE")
 	}
-	output = append(output, larg)
-	switch node.Jointype {
-	case nodes.JOIN_INNER:
-		if node.IsNatural {
-			output = append(output, "NATURAL")
-		} else if node.Quals == nil && node.UsingClause.Items == nil {
-			output = append(output, "CROSS")
+
+	parameters := []string{}
+	tableColumns := []string{}
+	for _, item := range node.Parameters.Items {
+		parameter, err := c.deparseItem(item)
+		if err != nil {
+			return "", err
+		}
+		if param, ok := item.(nodes.FunctionParameter); ok && param.Mode == nodes.FUNC_PARAM_TABLE {
+			tableColumns = append(tableColumns, parameter)
+		} else {
+			parameters = append(parameters, parameter)
 		}
-	case nodes.JOIN_LEFT:
-		output = append(output, "LEFT")
-	case nodes.JOIN_FULL:
-		output = append(output, "FULL")
-	case nodes.JOIN_RIGHT:
-		output = append(output, "RIGHT")
 	}
-	output = append(output, "JOIN")
-	rarg, err := c.deparseItem(node.Rarg)
-	if err != nil {
-		return "", err
+	output = append(output, "FUNCTION", fmt.Sprintf("%s(%s)", deparseAnyName(node.Funcname), strings.Join(parameters, ", ")))
+
+	if len(tableColumns) > 0 {
+		output = append(output, fmt.Sprintf("RETURNS TABLE (%s)", strings.Join(tableColumns, ", ")))
+	} else if node.ReturnType != nil {
+		returnType, err := c.deparseItem(node.ReturnType)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "RETURNS", returnType)
 	}
-	output = append(output, rarg)
 
-	if node.Quals != nil {
-		output = append(output, "ON")
-		quals, err := c.deparseItem(node.Quals)
+	for _, item := range node.Options.Items {
+		option, ok := item.(nodes.DefElem)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse function option: %# v", pretty.Formatter(item))
+		}
+		result, err := c.deparseFunctionOption(option)
 		if err != nil {
-			return "", nil
+			return "", err
 		}
-		output = append(output, quals)
+		output = append(output, result)
 	}
 
-	if node.UsingClause.Items != nil {
-		usingClauseItems, err := c.deparseItemList(node.UsingClause)
+	if node.WithClause.Items != nil {
+		withClause, err := c.deparseRelOptions(node.WithClause)
 		if err != nil {
 			return "", err
 		}
-		usingClause := strings.Join(usingClauseItems, ", ")
-		output = append(output, fmt.Sprintf("USING (%s)", usingClause))
+		output = append(output, "WITH", withClause)
 	}
 
 	return strings.Join(output, " "), nil
 }
 
-func (c DeparseContext) deparseNullTest(node nodes.NullTest) (string, error) {
-	output := []string{}
-	arg, err := c.deparseItem(node.Arg)
+func (c DeparseContext) deparseCreatePolicyStmt(node nodes.CreatePolicyStmt) (string, error) {
+	table, err := c.deparseItem(node.Table)
 	if err != nil {
 		return "", err
 	}
-	output = append(output, arg)
-	switch node.Nulltesttype {
-	case nodes.IS_NULL:
-		output = append(output, "IS NULL")
-	case nodes.IS_NOT_NULL:
-		output = append(output, "IS NOT NULL")
-	}
-	return strings.Join(output, " "), nil
-}
+	output := []string{"CREATE POLICY", quoteIdentifier(*node.PolicyName), "ON", table}
 
-func (c DeparseContext) deparseParamRef(node nodes.ParamRef) (string, error) {
-	// count starts at 1 so this should be fine
-	if node.Number == 0 {
-		return "?", nil
+	if !node.Permissive {
+		output = append(output, "AS RESTRICTIVE")
+	}
+	if node.CmdName != nil && *node.CmdName != "all" {
+		output = append(output, "FOR", strings.ToUpper(*node.CmdName))
 	}
-	return fmt.Sprintf("%d", node.Number), nil
-}
 
-func (c DeparseContext) deparseRangeFunction(node nodes.RangeFunction) (string, error) {
-	output := []string{}
-	if node.Lateral {
-		output = append(output, "LATERAL")
+	// Policies without roles apply to PUBLIC, which is implied
+	if len(node.Roles.Items) != 1 || !isPublicRole(node.Roles.Items[0]) {
+		roleItems, err := c.deparseItemList(node.Roles)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "TO", strings.Join(roleItems, ", "))
 	}
-	function, err := c.deparseItem(node.Functions.Items[0].(nodes.List).Items[0]) // FIXME: Needs more test cases
+
+	policyExprs, err := c.deparsePolicyExprs(node.Qual, node.WithCheck)
 	if err != nil {
 		return "", err
 	}
-	output = append(output, function)
-	if node.Alias != nil {
-		alias, err := c.deparseItem(node.Alias)
-		if err != nil {
-			return "", err
-		}
-		output = append(output, alias)
+	output = append(output, policyExprs...)
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseCreateRoleStmt(node nodes.CreateRoleStmt) (string, error) {
+	output := []string{"CREATE"}
+	switch node.StmtType {
+	case nodes.ROLESTMT_USER:
+		output = append(output, "USER")
+	case nodes.ROLESTMT_GROUP:
+		output = append(output, "GROUP")
+	default:
+		output = append(output, "ROLE")
 	}
-	if node.Coldeflist.Items != nil {
-		coldeflistItems, err := c.deparseItemList(node.Coldeflist)
+	output = append(output, quoteIdentifier(*node.Role))
+
+	if node.Options.Items != nil {
+		options, err := c.deparseRoleOptions(node.Options)
 		if err != nil {
 			return "", err
 		}
-		var coldeflist string
-		if node.Alias != nil {
+		output = append(output, "WITH", options)
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseCreateTableAsStmt(node nodes.CreateTableAsStmt) (string, error) {
+	output := []string{"CREATE"}
+	if persistence := deparseRelPersistence(node.Into.Rel); persistence != "" {
+		output = append(output, persistence)
+	}
+	if node.Relkind == nodes.OBJECT_MATVIEW {
+		output = append(output, "MATERIALIZED VIEW")
+	} else {
+		output = append(output, "TABLE")
+	}
+	if node.IfNotExists {
+		output = append(output, "IF NOT EXISTS")
+	}
+
+	into, err := c.deparseItem(node.Into)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, into, "AS")
+
+	query, err := c.deparseItem(node.Query)
+	if err != nil {
+		return "", err
+	}
+	result := strings.Join(output, " ") + c.clauseSeparator() + query
+
+	if node.Into.SkipData {
+		result += c.clauseSeparator() + "WITH NO DATA"
+	}
+	return result, nil
+}
+
+func (c DeparseContext) deparseCreateTrigStmt(node nodes.CreateTrigStmt) (string, error) {
+	output := []string{"CREATE"}
+	if node.Isconstraint {
+		output = append(output, "CONSTRAINT")
+	}
+	output = append(output, "TRIGGER", quoteIdentifier(*node.Trigname))
+
+	switch {
+	case node.Timing&triggerTypeBefore != 0:
+		output = append(output, "BEFORE")
+	case node.Timing&triggerTypeInstead != 0:
+		output = append(output, "INSTEAD OF")
+	default:
+		output = append(output, "AFTER")
+	}
+
+	events := []string{}
+	if node.Events&triggerTypeInsert != 0 {
+		events = append(events, "INSERT")
+	}
+	if node.Events&triggerTypeDelete != 0 {
+		events = append(events, "DELETE")
+	}
+	if node.Events&triggerTypeUpdate != 0 {
+		update := "UPDATE"
+		if node.Columns.Items != nil {
+			columnItems, err := c.deparseItemList(node.Columns)
+			if err != nil {
+				return "", err
+			}
+			update = fmt.Sprintf("UPDATE OF %s", strings.Join(columnItems, ", "))
+		}
+		events = append(events, update)
+	}
+	if node.Events&triggerTypeTruncate != 0 {
+		events = append(events, "TRUNCATE")
+	}
+	output = append(output, strings.Join(events, " OR "))
+
+	relation, err := c.deparseItem(node.Relation)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, "ON", relation)
+
+	if node.Constrrel != nil {
+		constrrel, err := c.deparseItem(node.Constrrel)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "FROM", constrrel)
+	}
+	if node.Deferrable {
+		output = append(output, "DEFERRABLE")
+	}
+	if node.Initdeferred {
+		output = append(output, "INITIALLY DEFERRED")
+	}
+
+	if node.TransitionRels.Items != nil {
+		transitionRelItems, err := c.deparseItemList(node.TransitionRels)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "REFERENCING", strings.Join(transitionRelItems, " "))
+	}
+
+	if node.Row {
+		output = append(output, "FOR EACH ROW")
+	} else {
+		output = append(output, "FOR EACH STATEMENT")
+	}
+
+	if node.WhenClause != nil {
+		whenClause, err := c.withContext("select").deparseItem(node.WhenClause)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, fmt.Sprintf("WHEN (%s)", whenClause))
+	}
+
+	args := make([]string, len(node.Args.Items))
+	for i, item := range node.Args.Items {
+		arg, ok := item.(nodes.String)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse trigger argument: %# v", pretty.Formatter(item))
+		}
+		args[i] = quoteString(arg.Str)
+	}
+	output = append(output, "EXECUTE PROCEDURE", fmt.Sprintf("%s(%s)", deparseAnyName(node.Funcname), strings.Join(args, ", ")))
+
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseCurrentOfExpr(node nodes.CurrentOfExpr) (string, error) {
+	if node.CursorName == nil {
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+	return fmt.Sprintf("CURRENT OF %s", quoteIdentifier(*node.CursorName)), nil
+}
+
+func (c DeparseContext) deparseDeallocateStmt(node nodes.DeallocateStmt) (string, error) {
+	if node.Name == nil {
+		return "DEALLOCATE ALL", nil
+	}
+	return fmt.Sprintf("DEALLOCATE %s", quoteIdentifier(*node.Name)), nil
+}
+
+// deparseDefElem deparses a generic option, e.g. of EXPLAIN or COPY
+func (c DeparseContext) deparseDefElem(node nodes.DefElem) (string, error) {
+	name := strings.ToUpper(*node.Defname)
+	if node.Arg == nil {
+		return name, nil
+	}
+
+	var arg string
+	switch value := node.Arg.(type) {
+	case nodes.String:
+		arg = quoteOptionValue(value.Str)
+	case nodes.List:
+		ctx := c.withContext("column")
+		items, err := ctx.deparseItemList(value)
+		if err != nil {
+			return "", err
+		}
+		arg = fmt.Sprintf("(%s)", strings.Join(items, ", "))
+	default:
+		var err error
+		arg, err = c.deparseItem(value)
+		if err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%s %s", name, arg), nil
+}
+
+func (c DeparseContext) deparseDeleteStmt(node nodes.DeleteStmt) (string, error) {
+	ctx := c.withContext("select")
+	clauses := []deparseClause{}
+
+	if node.WithClause != nil {
+		withClause, err := ctx.deparseItem(node.WithClause)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, deparseClause{items: []string{withClause}})
+	}
+
+	relation, err := ctx.deparseItem(node.Relation)
+	if err != nil {
+		return "", err
+	}
+	clauses = append(clauses, deparseClause{items: []string{"DELETE FROM " + relation}})
+
+	itemCtx := ctx.indented()
+	if node.UsingClause.Items != nil {
+		usingItems, err := itemCtx.deparseItemList(node.UsingClause)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, deparseClause{"USING", usingItems, ","})
+	}
+
+	if node.WhereClause != nil {
+		whereClause, err := itemCtx.deparseCondition(node.WhereClause)
+		if err != nil {
+			return "", err
+		}
+		whereClause.keyword = "WHERE"
+		clauses = append(clauses, whereClause)
+	}
+
+	if node.ReturningList.Items != nil {
+		returningItems, err := itemCtx.deparseItemList(node.ReturningList)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, deparseClause{"RETURNING", returningItems, ","})
+	}
+
+	return c.joinClauses(clauses), nil
+}
+
+func (c DeparseContext) deparseDiscardStmt(node nodes.DiscardStmt) (string, error) {
+	switch node.Target {
+	case nodes.DISCARD_ALL:
+		return "DISCARD ALL", nil
+	case nodes.DISCARD_PLANS:
+		return "DISCARD PLANS", nil
+	case nodes.DISCARD_SEQUENCES:
+		return "DISCARD SEQUENCES", nil
+	case nodes.DISCARD_TEMP:
+		return "DISCARD TEMP", nil
+	}
+	return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+}
+
+func (c DeparseContext) deparseDoStmt(node nodes.DoStmt) (string, error) {
+	output := []string{"DO"}
+	for _, item := range node.Args.Items {
+		arg, ok := item.(nodes.DefElem)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse DO argument: %# v", pretty.Formatter(item))
+		}
+		value, ok := arg.Arg.(nodes.String)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse DO argument: %# v", pretty.Formatter(item))
+		}
+		switch *arg.Defname {
+		case "language":
+			output = append(output, "LANGUAGE", quoteIdentifier(value.Str))
+		case "as":
+			output = append(output, dollarQuote(value.Str))
+		default:
+			return "", fmt.Errorf("Can't deparse DO argument %s", *arg.Defname)
+		}
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseDropOwnedStmt(node nodes.DropOwnedStmt) (string, error) {
+	roleItems, err := c.deparseItemList(node.Roles)
+	if err != nil {
+		return "", err
+	}
+	output := fmt.Sprintf("DROP OWNED BY %s", strings.Join(roleItems, ", "))
+	if node.Behavior == nodes.DROP_CASCADE {
+		output += " CASCADE"
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparseDropRoleStmt(node nodes.DropRoleStmt) (string, error) {
+	output := []string{"DROP ROLE"}
+	if node.MissingOk {
+		output = append(output, "IF EXISTS")
+	}
+	roleItems, err := c.deparseItemList(node.Roles)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, strings.Join(roleItems, ", "))
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseExecuteStmt(node nodes.ExecuteStmt) (string, error) {
+	output := fmt.Sprintf("EXECUTE %s", quoteIdentifier(*node.Name))
+	if node.Params.Items != nil {
+		paramItems, err := c.deparseItemList(node.Params)
+		if err != nil {
+			return "", err
+		}
+		output = fmt.Sprintf("%s (%s)", output, strings.Join(paramItems, ", "))
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparseExplainStmt(node nodes.ExplainStmt) (string, error) {
+	output := "EXPLAIN"
+	if node.Options.Items != nil {
+		options, err := c.deparseOptions(node.Options)
+		if err != nil {
+			return "", err
+		}
+		output = fmt.Sprintf("%s %s", output, options)
+	}
+	query, err := c.deparseItem(node.Query)
+	if err != nil {
+		return "", err
+	}
+	if c.format != nil {
+		return output + "\n" + query, nil
+	}
+	return fmt.Sprintf("%s %s", output, query), nil
+}
+
+// deparseFrameBound deparses the start or end of a window frame
+func (c DeparseContext) deparseFrameBound(frameOptions int, unboundedPreceding int, unboundedFollowing int, currentRow int, valuePreceding int, valueFollowing int, offset nodes.Node) (string, error) {
+	switch {
+	case frameOptions&unboundedPreceding != 0:
+		return "UNBOUNDED PRECEDING", nil
+	case frameOptions&unboundedFollowing != 0:
+		return "UNBOUNDED FOLLOWING", nil
+	case frameOptions&currentRow != 0:
+		return "CURRENT ROW", nil
+	case frameOptions&(valuePreceding|valueFollowing) != 0:
+		value, err := c.withContext("select").deparseItem(offset)
+		if err != nil {
+			return "", err
+		}
+		if frameOptions&valuePreceding != 0 {
+			return value + " PRECEDING", nil
+		}
+		return value + " FOLLOWING", nil
+	}
+	return "", fmt.Errorf("Can't deparse window frame options %d", frameOptions)
+}
+
+// Functions the grammar calls for SQL-standard syntax, which can't be
+// written as plain calls, e.g. POSITION(b IN a), mapped to the minimum number
+// of arguments their syntax takes
+var sqlSyntaxFunctions = map[string]int{
+	"btrim":            1,
+	"ltrim":            1,
+	"rtrim":            1,
+	"date_part":        2,
+	"overlaps":         4,
+	"overlay":          3,
+	"pg_collation_for": 1,
+	"position":         2,
+	"timezone":         2,
+	"xmlexists":        2,
+}
+
+// Keywords EXTRACT() accepts as fields
+var intervalFields = map[string]bool{
+	"year":   true,
+	"month":  true,
+	"day":    true,
+	"hour":   true,
+	"minute": true,
+	"second": true,
+}
+
+// Trim functions by the TRIM() direction they implement
+var trimDirections = map[string]string{
+	"btrim": "BOTH",
+	"ltrim": "LEADING",
+	"rtrim": "TRAILING",
+}
+
+// deparseSQLSyntaxFuncCall deparses a call the grammar creates for
+// SQL-standard syntax, e.g. pg_catalog.position(a, b) for POSITION(b IN a),
+// back to that syntax. It returns false for any other call.
+func (c DeparseContext) deparseSQLSyntaxFuncCall(node nodes.FuncCall) (string, bool, error) {
+	if len(node.Funcname.Items) != 2 || node.AggOrder.Items != nil || node.AggFilter != nil || node.Over != nil ||
+		node.AggStar || node.AggDistinct || node.FuncVariadic {
+		return "", false, nil
+	}
+	schema, _ := node.Funcname.Items[0].(nodes.String)
+	name, _ := node.Funcname.Items[1].(nodes.String)
+	args := node.Args.Items
+	if minArgs, ok := sqlSyntaxFunctions[name.Str]; schema.Str != "pg_catalog" || !ok || len(args) < minArgs {
+		return "", false, nil
+	}
+
+	switch name.Str {
+	case "btrim", "ltrim", "rtrim":
+		// TRIM(BOTH b FROM a) passes the characters to trim last
+		argItems, err := c.deparseItemList(node.Args)
+		if err != nil {
+			return "", false, err
+		}
+		direction := trimDirections[name.Str]
+		if len(argItems) == 1 {
+			return fmt.Sprintf("TRIM(%s %s)", direction, argItems[0]), true, nil
+		}
+		last := len(argItems) - 1
+		return fmt.Sprintf("TRIM(%s %s FROM %s)", direction, argItems[last], strings.Join(argItems[:last], ", ")), true, nil
+	case "date_part":
+		field, ok := args[0].(nodes.A_Const)
+		if !ok || len(args) != 2 {
+			return "", false, nil
+		}
+		fieldName, ok := field.Val.(nodes.String)
+		if !ok {
+			return "", false, nil
+		}
+		source, err := c.deparseItem(args[1])
+		if err != nil {
+			return "", false, err
+		}
+		// The field is written as a word if the grammar accepts it as one, i.e.
+		// it isn't a keyword other than those of the interval fields
+		extractField := quoteString(fieldName.Str)
+		if _, keyword := keywords[fieldName.Str]; intervalFields[fieldName.Str] || (!keyword && quoteIdentifier(fieldName.Str) == fieldName.Str) {
+			extractField = fieldName.Str
+		}
+		return fmt.Sprintf("EXTRACT(%s FROM %s)", extractField, source), true, nil
+	case "overlaps":
+		if len(args) != 4 {
+			return "", false, nil
+		}
+		argItems, err := c.deparseItemList(node.Args)
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("((%s, %s) OVERLAPS (%s, %s))", argItems[0], argItems[1], argItems[2], argItems[3]), true, nil
+	case "overlay":
+		if len(args) > 4 {
+			return "", false, nil
+		}
+		argItems, err := c.deparseItemList(node.Args)
+		if err != nil {
+			return "", false, err
+		}
+		output := fmt.Sprintf("OVERLAY(%s PLACING %s FROM %s", argItems[0], argItems[1], argItems[2])
+		if len(argItems) == 4 {
+			output += " FOR " + argItems[3]
+		}
+		return output + ")", true, nil
+	case "pg_collation_for":
+		if len(args) != 1 {
+			return "", false, nil
+		}
+		arg, err := c.deparseItem(args[0])
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("COLLATION FOR (%s)", arg), true, nil
+	case "position":
+		// The operands of IN are restricted expressions
+		if len(args) != 2 {
+			return "", false, nil
+		}
+		str, err := c.deparseOperand(args[0], precedenceOp, false)
+		if err != nil {
+			return "", false, err
+		}
+		substr, err := c.deparseOperand(args[1], precedenceOp, false)
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("POSITION(%s IN %s)", substr, str), true, nil
+	case "timezone":
+		if len(args) != 2 {
+			return "", false, nil
+		}
+		zone, err := c.deparseOperand(args[0], precedenceCollate, false)
+		if err != nil {
+			return "", false, err
+		}
+		value, err := c.deparseOperand(args[1], precedenceCollate, false)
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("(%s AT TIME ZONE %s)", value, zone), true, nil
+	case "xmlexists":
+		// Both arguments are restricted to atoms
+		if len(args) != 2 {
+			return "", false, nil
+		}
+		query, err := c.deparseOperand(args[0], precedenceAtom, false)
+		if err != nil {
+			return "", false, err
+		}
+		document, err := c.deparseOperand(args[1], precedenceAtom, false)
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("XMLEXISTS(%s PASSING %s)", query, document), true, nil
+	}
+	return "", false, nil
+}
+
+func (c DeparseContext) deparseFuncCall(node nodes.FuncCall) (string, error) {
+	if output, ok, err := c.deparseSQLSyntaxFuncCall(node); ok || err != nil {
+		return output, err
+	}
+
+	output := []string{}
+
+	argItems, err := c.deparseItemList(node.Args)
+	if err != nil {
+		return "", err
+	}
+	if node.FuncVariadic && len(argItems) > 0 {
+		argItems[len(argItems)-1] = "VARIADIC " + argItems[len(argItems)-1]
+	}
+	if node.AggStar {
+		argItems = append(argItems, "*")
+	}
+	args := strings.Join(argItems, ", ")
+
+	ctx := c.withContext("func_call")
+	funcnameItemsPre, err := ctx.deparseItemList(node.Funcname)
+	if err != nil {
+		return "", err
+	}
+	funcnameItems := funcnameItemsPre[:0]
+	for _, fn := range funcnameItemsPre {
+		if fn != "pg_catalog" {
+			funcnameItems = append(funcnameItems, fn)
+		}
+	}
+	funcname := strings.Join(funcnameItems, ".")
+
+	var distinct string
+	if node.AggDistinct {
+		distinct = "DISTINCT "
+	}
+
+	var aggOrder string
+	if node.AggOrder.Items != nil {
+		aggOrderItems, err := c.deparseItemList(node.AggOrder)
+		if err != nil {
+			return "", err
+		}
+		aggOrder = "ORDER BY " + strings.Join(aggOrderItems, ", ")
+	}
+
+	if node.AggOrder.Items != nil && !node.AggWithinGroup {
+		output = append(output, fmt.Sprintf("%s(%s%s %s)", funcname, distinct, args, aggOrder))
+	} else {
+		output = append(output, fmt.Sprintf("%s(%s%s)", funcname, distinct, args))
+	}
+	if node.AggWithinGroup {
+		output = append(output, fmt.Sprintf("WITHIN GROUP (%s)", aggOrder))
+	}
+	if node.AggFilter != nil {
+		aggFilter, err := c.deparseItem(node.AggFilter)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, fmt.Sprintf("FILTER (WHERE %s)", aggFilter))
+	}
+	if node.Over != nil && node.Over.Name != nil {
+		output = append(output, fmt.Sprintf("OVER %s", quoteIdentifier(*node.Over.Name)))
+	} else if node.Over != nil {
+		over, err := c.deparseItem(node.Over)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, fmt.Sprintf("OVER (%s)", over))
+	}
+
+	return strings.Join(output, " "), nil
+}
+
+// deparseFunctionOption deparses an option of CREATE FUNCTION, e.g. LANGUAGE
+func (c DeparseContext) deparseFunctionOption(node nodes.DefElem) (string, error) {
+	switch *node.Defname {
+	case "as":
+		list, ok := node.Arg.(nodes.List)
+		if !ok {
+			break
+		}
+		definition := []string{}
+		for _, item := range list.Items {
+			str, ok := item.(nodes.String)
+			if !ok {
+				return "", fmt.Errorf("Can't deparse function definition: %# v", pretty.Formatter(item))
+			}
+			if len(list.Items) == 1 {
+				definition = append(definition, dollarQuote(str.Str))
+			} else {
+				// Object file and link symbol of a C function
+				definition = append(definition, quoteString(str.Str))
+			}
+		}
+		return fmt.Sprintf("AS %s", strings.Join(definition, ", ")), nil
+	case "language":
+		if str, ok := node.Arg.(nodes.String); ok {
+			return fmt.Sprintf("LANGUAGE %s", quoteIdentifier(str.Str)), nil
+		}
+	case "volatility", "parallel":
+		if str, ok := node.Arg.(nodes.String); ok {
+			if *node.Defname == "parallel" {
+				return fmt.Sprintf("PARALLEL %s", strings.ToUpper(str.Str)), nil
+			}
+			return strings.ToUpper(str.Str), nil
+		}
+	case "strict", "security", "leakproof", "window":
+		value, ok := node.Arg.(nodes.Integer)
+		if !ok {
+			break
+		}
+		switch {
+		case *node.Defname == "strict" && value.Ival != 0:
+			return "STRICT", nil
+		case *node.Defname == "strict":
+			return "CALLED ON NULL INPUT", nil
+		case *node.Defname == "security" && value.Ival != 0:
+			return "SECURITY DEFINER", nil
+		case *node.Defname == "security":
+			return "SECURITY INVOKER", nil
+		case *node.Defname == "leakproof" && value.Ival != 0:
+			return "LEAKPROOF", nil
+		case *node.Defname == "leakproof":
+			return "NOT LEAKPROOF", nil
+		case *node.Defname == "window":
+			return "WINDOW", nil
+		}
+	case "cost", "rows":
+		value, err := c.deparseItem(node.Arg)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s", strings.ToUpper(*node.Defname), value), nil
+	case "set":
+		return c.deparseItem(node.Arg)
+	}
+	return "", fmt.Errorf("Can't deparse function option: %# v", pretty.Formatter(node))
+}
+
+func (c DeparseContext) deparseFunctionParameter(node nodes.FunctionParameter) (string, error) {
+	output := []string{}
+	switch node.Mode {
+	case nodes.FUNC_PARAM_OUT:
+		output = append(output, "OUT")
+	case nodes.FUNC_PARAM_INOUT:
+		output = append(output, "INOUT")
+	case nodes.FUNC_PARAM_VARIADIC:
+		output = append(output, "VARIADIC")
+	}
+	if node.Name != nil {
+		output = append(output, quoteIdentifier(*node.Name))
+	}
+
+	argType, err := c.deparseItem(node.ArgType)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, argType)
+
+	if node.Defexpr != nil {
+		defexpr, err := c.withContext("select").deparseItem(node.Defexpr)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "DEFAULT", defexpr)
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseGrantRoleStmt(node nodes.GrantRoleStmt) (string, error) {
+	output := []string{}
+	if node.IsGrant {
+		output = append(output, "GRANT")
+	} else {
+		output = append(output, "REVOKE")
+		if node.AdminOpt {
+			output = append(output, "ADMIN OPTION FOR")
+		}
+	}
+
+	grantedRoles := []string{}
+	for _, item := range node.GrantedRoles.Items {
+		role, ok := item.(nodes.AccessPriv)
+		if !ok || role.PrivName == nil {
+			return "", fmt.Errorf("Can't deparse granted role: %# v", pretty.Formatter(item))
+		}
+		grantedRoles = append(grantedRoles, quoteIdentifier(*role.PrivName))
+	}
+	output = append(output, strings.Join(grantedRoles, ", "))
+
+	granteeRoleItems, err := c.deparseItemList(node.GranteeRoles)
+	if err != nil {
+		return "", err
+	}
+	if node.IsGrant {
+		output = append(output, "TO")
+	} else {
+		output = append(output, "FROM")
+	}
+	output = append(output, strings.Join(granteeRoleItems, ", "))
+
+	if node.IsGrant && node.AdminOpt {
+		output = append(output, "WITH ADMIN OPTION")
+	}
+	if node.Grantor != nil {
+		grantor, err := c.deparseItem(node.Grantor)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "GRANTED BY", grantor)
+	}
+	if node.Behavior == nodes.DROP_CASCADE {
+		output = append(output, "CASCADE")
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseGrantStmt(node nodes.GrantStmt) (string, error) {
+	output := []string{}
+	if node.IsGrant {
+		output = append(output, "GRANT")
+	} else {
+		output = append(output, "REVOKE")
+		if node.GrantOption {
+			output = append(output, "GRANT OPTION FOR")
+		}
+	}
+
+	if node.Privileges.Items == nil {
+		output = append(output, "ALL")
+	} else {
+		privilegeItems, err := c.deparseItemList(node.Privileges)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, strings.Join(privilegeItems, ", "))
+	}
+	output = append(output, "ON")
+
+	switch node.Targtype {
+	case nodes.ACL_TARGET_OBJECT:
+		objectType, ok := grantObjectTypeNames[node.Objtype]
+		if !ok {
+			return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+		}
+		objects := []string{}
+		for _, item := range node.Objects.Items {
+			object, err := c.deparseObjectName(nodes.OBJECT_TABLE, item)
+			if err != nil {
+				return "", err
+			}
+			objects = append(objects, object)
+		}
+		output = append(output, objectType, strings.Join(objects, ", "))
+	case nodes.ACL_TARGET_ALL_IN_SCHEMA, nodes.ACL_TARGET_DEFAULTS:
+		objectType, ok := grantObjectTypePluralNames[node.Objtype]
+		if !ok {
+			return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+		}
+		if node.Targtype == nodes.ACL_TARGET_DEFAULTS {
+			output = append(output, objectType)
+		} else {
+			output = append(output, "ALL", objectType, "IN SCHEMA", strings.Join(quoteNames(node.Objects), ", "))
+		}
+	}
+
+	granteeItems, err := c.deparseItemList(node.Grantees)
+	if err != nil {
+		return "", err
+	}
+	if node.IsGrant {
+		output = append(output, "TO")
+	} else {
+		output = append(output, "FROM")
+	}
+	output = append(output, strings.Join(granteeItems, ", "))
+
+	if node.IsGrant && node.GrantOption {
+		output = append(output, "WITH GRANT OPTION")
+	}
+	if node.Behavior == nodes.DROP_CASCADE {
+		output = append(output, "CASCADE")
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseGroupingFunc(node nodes.GroupingFunc) (string, error) {
+	argItems, err := c.deparseItemList(node.Args)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("GROUPING(%s)", strings.Join(argItems, ", ")), nil
+}
+
+func (c DeparseContext) deparseGroupingSet(node nodes.GroupingSet) (string, error) {
+	contentItems, err := c.deparseItemList(node.Content)
+	if err != nil {
+		return "", err
+	}
+	content := strings.Join(contentItems, ", ")
+	switch node.Kind {
+	case nodes.GROUPING_SET_EMPTY:
+		return "()", nil
+	case nodes.GROUPING_SET_ROLLUP:
+		return fmt.Sprintf("ROLLUP (%s)", content), nil
+	case nodes.GROUPING_SET_CUBE:
+		return fmt.Sprintf("CUBE (%s)", content), nil
+	case nodes.GROUPING_SET_SETS:
+		return fmt.Sprintf("GROUPING SETS (%s)", content), nil
+	}
+	return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+}
+
+func (c DeparseContext) deparseIndexElem(node nodes.IndexElem) (string, error) {
+	output := []string{}
+	if node.Name != nil {
+		output = append(output, quoteIdentifier(*node.Name))
+	} else {
+		expr, err := c.withContext("select").deparseItem(node.Expr)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := node.Expr.(nodes.FuncCall); ok {
+			output = append(output, expr)
+		} else {
+			output = append(output, fmt.Sprintf("(%s)", expr))
+		}
+	}
+	if node.Collation.Items != nil {
+		output = append(output, "COLLATE", deparseAnyName(node.Collation))
+	}
+	if node.Opclass.Items != nil {
+		output = append(output, deparseAnyName(node.Opclass))
+	}
+	switch node.Ordering {
+	case nodes.SORTBY_ASC:
+		output = append(output, "ASC")
+	case nodes.SORTBY_DESC:
+		output = append(output, "DESC")
+	}
+	switch node.NullsOrdering {
+	case nodes.SORTBY_NULLS_FIRST:
+		output = append(output, "NULLS FIRST")
+	case nodes.SORTBY_NULLS_LAST:
+		output = append(output, "NULLS LAST")
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseInferClause(node nodes.InferClause) (string, error) {
+	if node.Conname != nil {
+		return fmt.Sprintf("ON CONSTRAINT %s", quoteIdentifier(*node.Conname)), nil
+	}
+	indexElemItems, err := c.deparseItemList(node.IndexElems)
+	if err != nil {
+		return "", err
+	}
+	output := fmt.Sprintf("(%s)", strings.Join(indexElemItems, ", "))
+	if node.WhereClause != nil {
+		whereClause, err := c.withContext("select").deparseItem(node.WhereClause)
+		if err != nil {
+			return "", err
+		}
+		output = fmt.Sprintf("%s WHERE %s", output, whereClause)
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparseInsertStmt(node nodes.InsertStmt) (string, error) {
+	head, tail, err := c.deparseInsertClauses(node)
+	if err != nil {
+		return "", err
+	}
+
+	query := "DEFAULT VALUES"
+	if node.SelectStmt != nil {
+		ctx := c.withContext("select")
+		selectStmt, err := ctx.deparseItem(node.SelectStmt)
+		if err != nil {
+			return "", err
+		}
+		// The query is already indented, joinClauses indents it again
+		query = strings.TrimPrefix(selectStmt, ctx.indentation())
+	}
+
+	clauses := append(head, deparseClause{items: []string{query}})
+	return c.joinClauses(append(clauses, tail...)), nil
+}
+
+// deparseInsertClauses deparses the clauses of an INSERT statement before
+// and after its query
+func (c DeparseContext) deparseInsertClauses(node nodes.InsertStmt) ([]deparseClause, []deparseClause, error) {
+	ctx := c.withContext("select")
+	head := []deparseClause{}
+	tail := []deparseClause{}
+
+	if node.WithClause != nil {
+		withClause, err := ctx.deparseItem(node.WithClause)
+		if err != nil {
+			return nil, nil, err
+		}
+		head = append(head, deparseClause{items: []string{withClause}})
+	}
+
+	// The alias of the target table needs AS here
+	relation := *node.Relation
+	relation.Alias = nil
+	insert, err := ctx.deparseItem(relation)
+	if err != nil {
+		return nil, nil, err
+	}
+	insert = "INSERT INTO " + insert
+	if node.Relation.Alias != nil {
+		alias, err := ctx.deparseItem(node.Relation.Alias)
+		if err != nil {
+			return nil, nil, err
+		}
+		insert += " AS " + alias
+	}
+	if node.Cols.Items != nil {
+		colItems, err := c.withContext("insert").deparseItemList(node.Cols)
+		if err != nil {
+			return nil, nil, err
+		}
+		insert += fmt.Sprintf(" (%s)", strings.Join(colItems, ", "))
+	}
+	switch node.Override {
+	case nodes.OVERRIDING_USER_VALUE:
+		insert += " OVERRIDING USER VALUE"
+	case nodes.OVERRIDING_SYSTEM_VALUE:
+		insert += " OVERRIDING SYSTEM VALUE"
+	}
+	head = append(head, deparseClause{items: []string{insert}})
+
+	itemCtx := ctx.indented()
+	if node.OnConflictClause != nil {
+		onConflict, err := itemCtx.deparseOnConflictClause(*node.OnConflictClause)
+		if err != nil {
+			return nil, nil, err
+		}
+		tail = append(tail, onConflict...)
+	}
+
+	if node.ReturningList.Items != nil {
+		returningItems, err := itemCtx.deparseItemList(node.ReturningList)
+		if err != nil {
+			return nil, nil, err
+		}
+		tail = append(tail, deparseClause{"RETURNING", returningItems, ","})
+	}
+
+	return head, tail, nil
+}
+
+// deparseIntervalConst deparses an interval constant, e.g. INTERVAL '1' HOUR
+func (c DeparseContext) deparseIntervalConst(node nodes.TypeCast) (string, error) {
+	arg, err := c.deparseItem(node.Arg)
+	if err != nil {
+		return "", err
+	}
+	fields, precision, err := deparseIntervalTypmods(node.TypeName.Typmods)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case fields == "" && precision != "":
+		return fmt.Sprintf("INTERVAL(%s) %s", precision, arg), nil
+	case precision != "":
+		return fmt.Sprintf("INTERVAL %s %s(%s)", arg, fields, precision), nil
+	case fields != "":
+		return fmt.Sprintf("INTERVAL %s %s", arg, fields), nil
+	}
+	return fmt.Sprintf("INTERVAL %s", arg), nil
+}
+
+func (c DeparseContext) deparseIntoClause(node nodes.IntoClause) (string, error) {
+	relation, err := c.deparseItem(node.Rel)
+	if err != nil {
+		return "", err
+	}
+	output := []string{relation}
+
+	if node.ColNames.Items != nil {
+		colNameItems, err := c.deparseItemList(node.ColNames)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, fmt.Sprintf("(%s)", strings.Join(colNameItems, ", ")))
+	}
+	if node.Options.Items != nil {
+		options, err := c.deparseRelOptions(node.Options)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "WITH", options)
+	}
+	switch node.OnCommit {
+	case nodes.ONCOMMIT_PRESERVE_ROWS:
+		output = append(output, "ON COMMIT PRESERVE ROWS")
+	case nodes.ONCOMMIT_DELETE_ROWS:
+		output = append(output, "ON COMMIT DELETE ROWS")
+	case nodes.ONCOMMIT_DROP:
+		output = append(output, "ON COMMIT DROP")
+	}
+	if node.TableSpaceName != nil {
+		output = append(output, "TABLESPACE", quoteIdentifier(*node.TableSpaceName))
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseJoinExpr(node nodes.JoinExpr) (string, error) {
+	output := []string{}
+	larg, err := c.deparseItem(node.Larg)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, larg)
+	switch node.Jointype {
+	case nodes.JOIN_INNER:
+		if node.IsNatural {
+			output = append(output, "NATURAL")
+		} else if node.Quals == nil && node.UsingClause.Items == nil {
+			output = append(output, "CROSS")
+		}
+	case nodes.JOIN_LEFT:
+		output = append(output, "LEFT")
+	case nodes.JOIN_FULL:
+		output = append(output, "FULL")
+	case nodes.JOIN_RIGHT:
+		output = append(output, "RIGHT")
+	}
+	output = append(output, "JOIN")
+	rarg, err := c.deparseItem(node.Rarg)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, rarg)
+
+	if node.Quals != nil {
+		output = append(output, "ON")
+		quals, err := c.deparseItem(node.Quals)
+		if err != nil {
+			return "", nil
+		}
+		output = append(output, quals)
+	}
+
+	if node.UsingClause.Items != nil {
+		usingClauseItems, err := c.deparseItemList(node.UsingClause)
+		if err != nil {
+			return "", err
+		}
+		usingClause := strings.Join(usingClauseItems, ", ")
+		output = append(output, fmt.Sprintf("USING (%s)", usingClause))
+	}
+
+	if c.format != nil {
+		// Every join starts on a new line
+		return output[0] + c.newline() + strings.Join(output[1:], " "), nil
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseListenStmt(node nodes.ListenStmt) (string, error) {
+	return fmt.Sprintf("LISTEN %s", quoteIdentifier(*node.Conditionname)), nil
+}
+
+func (c DeparseContext) deparseLockingClause(node nodes.LockingClause) (string, error) {
+	output := []string{}
+	switch node.Strength {
+	case nodes.LCS_FORKEYSHARE:
+		output = append(output, "FOR KEY SHARE")
+	case nodes.LCS_FORSHARE:
+		output = append(output, "FOR SHARE")
+	case nodes.LCS_FORNOKEYUPDATE:
+		output = append(output, "FOR NO KEY UPDATE")
+	case nodes.LCS_FORUPDATE:
+		output = append(output, "FOR UPDATE")
+	default:
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+
+	if node.LockedRels.Items != nil {
+		lockedRelItems, err := c.deparseItemList(node.LockedRels)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "OF", strings.Join(lockedRelItems, ", "))
+	}
+
+	switch node.WaitPolicy {
+	case nodes.LockWaitSkip:
+		output = append(output, "SKIP LOCKED")
+	case nodes.LockWaitError:
+		output = append(output, "NOWAIT")
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseLockStmt(node nodes.LockStmt) (string, error) {
+	relationItems, err := c.deparseItemList(node.Relations)
+	if err != nil {
+		return "", err
+	}
+	if node.Mode < 1 || node.Mode >= len(lockModeNames) {
+		return "", fmt.Errorf("Can't deparse lock mode %d", node.Mode)
+	}
+	output := fmt.Sprintf("LOCK TABLE %s IN %s MODE", strings.Join(relationItems, ", "), lockModeNames[node.Mode])
+	if node.Nowait {
+		output += " NOWAIT"
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparseMinMaxExpr(node nodes.MinMaxExpr) (string, error) {
+	argItems, err := c.deparseItemList(node.Args)
+	if err != nil {
+		return "", err
+	}
+	if node.Op == nodes.IS_LEAST {
+		return fmt.Sprintf("LEAST(%s)", strings.Join(argItems, ", ")), nil
+	}
+	return fmt.Sprintf("GREATEST(%s)", strings.Join(argItems, ", ")), nil
+}
+
+// deparseMultiAssignRef deparses the source of a multiple-column assignment,
+// the target columns are written by deparseSetClause
+func (c DeparseContext) deparseMultiAssignRef(node nodes.MultiAssignRef) (string, error) {
+	return c.deparseItem(node.Source)
+}
+
+func (c DeparseContext) deparseNamedArgExpr(node nodes.NamedArgExpr) (string, error) {
+	arg, err := c.deparseItem(node.Arg)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s => %s", quoteIdentifier(*node.Name), arg), nil
+}
+
+func (c DeparseContext) deparseNotifyStmt(node nodes.NotifyStmt) (string, error) {
+	output := fmt.Sprintf("NOTIFY %s", quoteIdentifier(*node.Conditionname))
+	if node.Payload != nil {
+		output = fmt.Sprintf("%s, %s", output, quoteString(*node.Payload))
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparseNullTest(node nodes.NullTest) (string, error) {
+	output := []string{}
+	arg, err := c.deparseOperand(node.Arg, precedenceIs, true)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, arg)
+	switch node.Nulltesttype {
+	case nodes.IS_NULL:
+		output = append(output, "IS NULL")
+	case nodes.IS_NOT_NULL:
+		output = append(output, "IS NOT NULL")
+	}
+	return strings.Join(output, " "), nil
+}
+
+// deparseObjectName writes the name of a database object, e.g. of a function
+// including its argument types
+func (c DeparseContext) deparseObjectName(objectType nodes.ObjectType, object nodes.Node) (string, error) {
+	switch object := object.(type) {
+	case nodes.String:
+		return quoteIdentifier(object.Str), nil
+	case nodes.Integer:
+		return fmt.Sprintf("%d", object.Ival), nil
+	case nodes.List:
+		// Operator classes and families are preceded by their access method
+		if objectType == nodes.OBJECT_OPCLASS || objectType == nodes.OBJECT_OPFAMILY {
+			names := quoteNames(object)
+			if len(names) < 2 {
+				return "", fmt.Errorf("Can't deparse object name: %# v", pretty.Formatter(object))
+			}
+			return fmt.Sprintf("%s USING %s", strings.Join(names[1:], "."), names[0]), nil
+		}
+		return deparseAnyName(object), nil
+	case nodes.ObjectWithArgs:
+		// Aggregates without arguments are written as name(*)
+		if objectType == nodes.OBJECT_AGGREGATE && !object.ArgsUnspecified && object.Objargs.Items == nil {
+			return deparseAnyName(object.Objname) + "(*)", nil
+		}
+	}
+	return c.deparseItem(object)
+}
+
+func (c DeparseContext) deparseObjectWithArgs(node nodes.ObjectWithArgs) (string, error) {
+	names := quoteNames(node.Objname)
+	last := ""
+	if len(node.Objname.Items) > 0 {
+		if str, ok := node.Objname.Items[len(node.Objname.Items)-1].(nodes.String); ok {
+			last = str.Str
+		}
+	}
+	isOperator := last != "" && !isIdentStart(last[0])
+	if isOperator {
+		names[len(names)-1] = last
+	}
+	output := strings.Join(names, ".")
+	if node.ArgsUnspecified {
+		return output, nil
+	}
+
+	args := []string{}
+	for _, item := range node.Objargs.Items {
+		if item == nil {
+			args = append(args, "NONE")
+			continue
+		}
+		arg, err := c.deparseItem(item)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, arg)
+	}
+	if isOperator {
+		return fmt.Sprintf("%s (%s)", output, strings.Join(args, ", ")), nil
+	}
+	return fmt.Sprintf("%s(%s)", output, strings.Join(args, ", ")), nil
+}
+
+// deparseOnConflictClause returns the clauses of ON CONFLICT, i.e. the
+// conflict target and action, the assignments and their condition
+func (c DeparseContext) deparseOnConflictClause(node nodes.OnConflictClause) ([]deparseClause, error) {
+	onConflict := "ON CONFLICT"
+	if node.Infer != nil {
+		infer, err := c.deparseItem(node.Infer)
+		if err != nil {
+			return nil, err
+		}
+		onConflict += " " + infer
+	}
+
+	if node.Action == nodes.ONCONFLICT_NOTHING {
+		return []deparseClause{{items: []string{onConflict + " DO NOTHING"}}}, nil
+	}
+
+	targetItems, err := c.deparseSetClause(node.TargetList)
+	if err != nil {
+		return nil, err
+	}
+	clauses := []deparseClause{{onConflict + " DO UPDATE SET", targetItems, ","}}
+	if node.WhereClause != nil {
+		whereClause, err := c.deparseCondition(node.WhereClause)
+		if err != nil {
+			return nil, err
+		}
+		whereClause.keyword = "WHERE"
+		clauses = append(clauses, whereClause)
+	}
+	return clauses, nil
+}
+
+// deparseOptions deparses a parenthesized list of generic options
+func (c DeparseContext) deparseOptions(options nodes.List) (string, error) {
+	optionItems, err := c.deparseItemList(options)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(%s)", strings.Join(optionItems, ", ")), nil
+}
+
+func (c DeparseContext) deparseParamRef(node nodes.ParamRef) (string, error) {
+	// count starts at 1 so this should be fine
+	if node.Number == 0 {
+		return "?", nil
+	}
+	return fmt.Sprintf("$%d", node.Number), nil
+}
+
+// deparsePolicyExprs returns the USING and WITH CHECK clauses of a policy
+func (c DeparseContext) deparsePolicyExprs(qual nodes.Node, withCheck nodes.Node) ([]string, error) {
+	output := []string{}
+	ctx := c.withContext("select")
+	if qual != nil {
+		result, err := ctx.deparseItem(qual)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, fmt.Sprintf("USING (%s)", result))
+	}
+	if withCheck != nil {
+		result, err := ctx.deparseItem(withCheck)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, fmt.Sprintf("WITH CHECK (%s)", result))
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparsePrepareStmt(node nodes.PrepareStmt) (string, error) {
+	output := fmt.Sprintf("PREPARE %s", quoteIdentifier(*node.Name))
+	if node.Argtypes.Items != nil {
+		ctx := c.withContext("type_name")
+		argtypeItems, err := ctx.deparseItemList(node.Argtypes)
+		if err != nil {
+			return "", err
+		}
+		output = fmt.Sprintf("%s (%s)", output, strings.Join(argtypeItems, ", "))
+	}
+	query, err := c.deparseItem(node.Query)
+	if err != nil {
+		return "", err
+	}
+	if c.format != nil {
+		return output + " AS\n" + query, nil
+	}
+	return fmt.Sprintf("%s AS %s", output, query), nil
+}
+
+func (c DeparseContext) deparseRangeFunction(node nodes.RangeFunction) (string, error) {
+	output := []string{}
+	if node.Lateral {
+		output = append(output, "LATERAL")
+	}
+
+	// Every function comes with its column definitions, e.g. ROWS FROM (f() AS (a int))
+	functions := []string{}
+	for _, item := range node.Functions.Items {
+		list, ok := item.(nodes.List)
+		if !ok || len(list.Items) == 0 {
+			return "", fmt.Errorf("Can't deparse function: %# v", pretty.Formatter(item))
+		}
+		function, err := c.deparseItem(list.Items[0])
+		if err != nil {
+			return "", err
+		}
+		if len(list.Items) > 1 && list.Items[1] != nil {
+			coldeflist, ok := list.Items[1].(nodes.List)
+			if !ok {
+				return "", fmt.Errorf("Can't deparse function: %# v", pretty.Formatter(item))
+			}
+			coldeflistItems, err := c.deparseItemList(coldeflist)
+			if err != nil {
+				return "", err
+			}
+			function = fmt.Sprintf("%s AS (%s)", function, strings.Join(coldeflistItems, ", "))
+		}
+		functions = append(functions, function)
+	}
+	if node.IsRowsfrom {
+		output = append(output, fmt.Sprintf("ROWS FROM (%s)", strings.Join(functions, ", ")))
+	} else {
+		output = append(output, functions...)
+	}
+
+	if node.Ordinality {
+		output = append(output, "WITH ORDINALITY")
+	}
+	if node.Alias != nil {
+		alias, err := c.deparseItem(node.Alias)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, alias)
+	}
+	if node.Coldeflist.Items != nil {
+		coldeflistItems, err := c.deparseItemList(node.Coldeflist)
+		if err != nil {
+			return "", err
+		}
+		var coldeflist string
+		if node.Alias != nil {
 			coldeflist = fmt.Sprintf("(%s)", strings.Join(coldeflistItems, ", "))
 		} else {
-			coldeflist = fmt.Sprintf("AS (%s)", strings.Join(coldeflistItems, ", "))
+			coldeflist = fmt.Sprintf("AS (%s)", strings.Join(coldeflistItems, ", "))
+		}
+		output = append(output, coldeflist)
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseRangeSubselect(node nodes.RangeSubselect) (string, error) {
+	output, err := c.deparseSubquery(node.Subquery)
+	if err != nil {
+		return "", err
+	}
+	if node.Lateral {
+		output = "LATERAL " + output
+	}
+	if node.Alias != nil {
+		alias, err := c.deparseItem(node.Alias)
+		if err != nil {
+			return "", err
+		}
+		output = fmt.Sprintf("%s %s", output, alias)
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparseRangeTableFunc(node nodes.RangeTableFunc) (string, error) {
+	output := []string{}
+	if node.Lateral {
+		output = append(output, "LATERAL")
+	}
+
+	args := []string{}
+	if node.Namespaces.Items != nil {
+		namespaces := []string{}
+		for _, item := range node.Namespaces.Items {
+			namespace, ok := item.(nodes.ResTarget)
+			if !ok {
+				return "", fmt.Errorf("Can't deparse XML namespace: %# v", pretty.Formatter(item))
+			}
+			uri, err := c.withContext("select").deparseItem(namespace.Val)
+			if err != nil {
+				return "", err
+			}
+			if namespace.Name == nil {
+				namespaces = append(namespaces, "DEFAULT "+uri)
+			} else {
+				namespaces = append(namespaces, fmt.Sprintf("%s AS %s", uri, quoteIdentifier(*namespace.Name)))
+			}
+		}
+		args = append(args, fmt.Sprintf("XMLNAMESPACES(%s)", strings.Join(namespaces, ", ")))
+	}
+
+	rowexpr, err := c.deparseCExpr(node.Rowexpr)
+	if err != nil {
+		return "", err
+	}
+	docexpr, err := c.deparseCExpr(node.Docexpr)
+	if err != nil {
+		return "", err
+	}
+	columnItems, err := c.deparseItemList(node.Columns)
+	if err != nil {
+		return "", err
+	}
+	args = append(args, fmt.Sprintf("%s PASSING %s COLUMNS %s", rowexpr, docexpr, strings.Join(columnItems, ", ")))
+	output = append(output, fmt.Sprintf("XMLTABLE(%s)", strings.Join(args, ", ")))
+
+	if node.Alias != nil {
+		alias, err := c.deparseItem(node.Alias)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, alias)
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseRangeTableFuncCol(node nodes.RangeTableFuncCol) (string, error) {
+	output := []string{quoteIdentifier(*node.Colname)}
+	if node.ForOrdinality {
+		output = append(output, "FOR ORDINALITY")
+		return strings.Join(output, " "), nil
+	}
+
+	typeName, err := c.deparseItem(node.TypeName)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, typeName)
+
+	ctx := c.withContext("select")
+	if node.Colexpr != nil {
+		colexpr, err := ctx.deparseItem(node.Colexpr)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "PATH", colexpr)
+	}
+	if node.Coldefexpr != nil {
+		coldefexpr, err := ctx.deparseItem(node.Coldefexpr)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "DEFAULT", coldefexpr)
+	}
+	if node.IsNotNull {
+		output = append(output, "NOT NULL")
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseRangeTableSample(node nodes.RangeTableSample) (string, error) {
+	relation, err := c.deparseItem(node.Relation)
+	if err != nil {
+		return "", err
+	}
+	argItems, err := c.withContext("select").deparseItemList(node.Args)
+	if err != nil {
+		return "", err
+	}
+	output := fmt.Sprintf("%s TABLESAMPLE %s (%s)", relation, deparseAnyName(node.Method), strings.Join(argItems, ", "))
+
+	if node.Repeatable != nil {
+		repeatable, err := c.withContext("select").deparseItem(node.Repeatable)
+		if err != nil {
+			return "", err
+		}
+		output = fmt.Sprintf("%s REPEATABLE (%s)", output, repeatable)
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparseRangeVar(node nodes.RangeVar) (string, error) {
+	output := []string{}
+
+	if !node.Inh {
+		output = append(output, "ONLY")
+	}
+	if node.Schemaname != nil {
+		output = append(output, fmt.Sprintf(`"%s"."%s"`, *node.Schemaname, *node.Relname))
+	} else {
+		output = append(output, fmt.Sprintf(`"%s"`, *node.Relname))
+	}
+	if node.Alias != nil {
+		alias, err := c.deparseItem(node.Alias)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, alias)
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseRawStmt(node nodes.RawStmt) (string, error) {
+	return c.deparseItem(node.Stmt)
+}
+
+func (c DeparseContext) deparseReassignOwnedStmt(node nodes.ReassignOwnedStmt) (string, error) {
+	roleItems, err := c.deparseItemList(node.Roles)
+	if err != nil {
+		return "", err
+	}
+	newrole, err := c.deparseItem(node.Newrole)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("REASSIGN OWNED BY %s TO %s", strings.Join(roleItems, ", "), newrole), nil
+}
+
+func (c DeparseContext) deparseRefreshMatViewStmt(node nodes.RefreshMatViewStmt) (string, error) {
+	output := []string{"REFRESH MATERIALIZED VIEW"}
+	if node.Concurrent {
+		output = append(output, "CONCURRENTLY")
+	}
+	relation, err := c.deparseItem(node.Relation)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, relation)
+	if node.SkipData {
+		output = append(output, "WITH NO DATA")
+	}
+	return strings.Join(output, " "), nil
+}
+
+// deparseRelOptions deparses storage parameters, e.g. WITH (fillfactor=70)
+func (c DeparseContext) deparseRelOptions(options nodes.List) (string, error) {
+	output := []string{}
+	for _, item := range options.Items {
+		option, ok := item.(nodes.DefElem)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse option: %# v", pretty.Formatter(item))
+		}
+		name := quoteIdentifier(*option.Defname)
+		if option.Defnamespace != nil {
+			name = quoteIdentifier(*option.Defnamespace) + "." + name
+		}
+		switch value := option.Arg.(type) {
+		case nil:
+			output = append(output, name)
+		case nodes.String:
+			output = append(output, fmt.Sprintf("%s=%s", name, quoteOptionValue(value.Str)))
+		default:
+			arg, err := c.deparseItem(value)
+			if err != nil {
+				return "", err
+			}
+			output = append(output, fmt.Sprintf("%s=%s", name, arg))
+		}
+	}
+	return fmt.Sprintf("(%s)", strings.Join(output, ", ")), nil
+}
+
+func (c DeparseContext) deparseResTarget(node nodes.ResTarget) (string, error) {
+	if c.Context == "select" {
+		val, err := c.deparseItem(node.Val)
+		if err != nil {
+			return "", err
+		}
+		if node.Name != nil {
+			return fmt.Sprintf("%s AS %s", val, *node.Name), nil
+		} else {
+			return val, nil
+		}
+	}
+	if c.Context == "insert" || c.Context == "update" {
+		// The target column, e.g. "col"[1] in INSERT INTO t ("col"[1])
+		name := fmt.Sprintf(`"%s"`, strings.Replace(*node.Name, `"`, `""`, -1))
+		indirectionItems, err := c.withContext("select").deparseItemList(node.Indirection)
+		if err != nil {
+			return "", err
+		}
+		for _, item := range indirectionItems {
+			if strings.HasPrefix(item, "[") {
+				name += item
+			} else {
+				name += "." + item
+			}
+		}
+		if c.Context == "insert" {
+			return name, nil
+		}
+
+		val, err := c.withContext("select").deparseItem(node.Val)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s = %s", name, val), nil
+	}
+	return "", fmt.Errorf("Can't deparse %# v in context %s", pretty.Formatter(node), c.Context)
+}
+
+// deparseRoleOptions deparses the options of CREATE ROLE and ALTER ROLE,
+// e.g. LOGIN CONNECTION LIMIT 5
+func (c DeparseContext) deparseRoleOptions(options nodes.List) (string, error) {
+	output := []string{}
+	for _, item := range options.Items {
+		option, ok := item.(nodes.DefElem)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse role option: %# v", pretty.Formatter(item))
+		}
+
+		if keyword, ok := roleOptionKeywords[*option.Defname]; ok {
+			value, ok := option.Arg.(nodes.Integer)
+			if !ok {
+				return "", fmt.Errorf("Can't deparse role option: %# v", pretty.Formatter(item))
+			}
+			if value.Ival == 0 {
+				keyword = "NO" + keyword
+			}
+			output = append(output, keyword)
+			continue
+		}
+
+		switch *option.Defname {
+		case "password":
+			if option.Arg == nil {
+				output = append(output, "PASSWORD NULL")
+				continue
+			}
+			password, err := c.deparseItem(option.Arg)
+			if err != nil {
+				return "", err
+			}
+			output = append(output, "PASSWORD", password)
+		case "validUntil":
+			value, ok := option.Arg.(nodes.String)
+			if !ok {
+				return "", fmt.Errorf("Can't deparse role option: %# v", pretty.Formatter(item))
+			}
+			output = append(output, "VALID UNTIL", quoteString(value.Str))
+		case "connectionlimit", "sysid":
+			value, err := c.deparseItem(option.Arg)
+			if err != nil {
+				return "", err
+			}
+			if *option.Defname == "sysid" {
+				output = append(output, "SYSID", value)
+			} else {
+				output = append(output, "CONNECTION LIMIT", value)
+			}
+		case "addroleto", "rolemembers", "adminmembers":
+			roles, ok := option.Arg.(nodes.List)
+			if !ok {
+				return "", fmt.Errorf("Can't deparse role option: %# v", pretty.Formatter(item))
+			}
+			roleItems, err := c.deparseItemList(roles)
+			if err != nil {
+				return "", err
+			}
+			keyword := map[string]string{"addroleto": "IN ROLE", "rolemembers": "ROLE", "adminmembers": "ADMIN"}[*option.Defname]
+			output = append(output, keyword, strings.Join(roleItems, ", "))
+		default:
+			return "", fmt.Errorf("Can't deparse role option %s", *option.Defname)
+		}
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseRoleSpec(node nodes.RoleSpec) (string, error) {
+	switch node.Roletype {
+	case nodes.ROLESPEC_CURRENT_USER:
+		return "CURRENT_USER", nil
+	case nodes.ROLESPEC_SESSION_USER:
+		return "SESSION_USER", nil
+	case nodes.ROLESPEC_PUBLIC:
+		return "PUBLIC", nil
+	}
+	return quoteIdentifier(*node.Rolename), nil
+}
+
+func (c DeparseContext) deparseRowExpr(node nodes.RowExpr) (string, error) {
+	argItems, err := c.deparseItemList(node.Args)
+	if err != nil {
+		return "", err
+	}
+	// Implicit rows are plain parenthesized lists, e.g. in GROUPING SETS
+	if node.RowFormat == nodes.COERCE_IMPLICIT_CAST {
+		return fmt.Sprintf("(%s)", strings.Join(argItems, ", ")), nil
+	}
+	return fmt.Sprintf("ROW(%s)", strings.Join(argItems, ", ")), nil
+}
+
+func (c DeparseContext) deparseRuleStmt(node nodes.RuleStmt) (string, error) {
+	output := []string{"CREATE"}
+	if node.Replace {
+		output = append(output, "OR REPLACE")
+	}
+	output = append(output, "RULE", quoteIdentifier(*node.Rulename), "AS ON")
+
+	switch node.Event {
+	case nodes.CMD_SELECT:
+		output = append(output, "SELECT")
+	case nodes.CMD_UPDATE:
+		output = append(output, "UPDATE")
+	case nodes.CMD_INSERT:
+		output = append(output, "INSERT")
+	case nodes.CMD_DELETE:
+		output = append(output, "DELETE")
+	default:
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+
+	relation, err := c.deparseItem(node.Relation)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, "TO", relation)
+
+	if node.WhereClause != nil {
+		whereClause, err := c.withContext("select").deparseItem(node.WhereClause)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "WHERE", whereClause)
+	}
+
+	output = append(output, "DO")
+	if node.Instead {
+		output = append(output, "INSTEAD")
+	}
+
+	// Actions are written on a single line, they're separated by semicolons
+	ctx := c
+	ctx.format = nil
+	actionItems, err := ctx.deparseItemList(node.Actions)
+	if err != nil {
+		return "", err
+	}
+	switch len(actionItems) {
+	case 0:
+		output = append(output, "NOTHING")
+	case 1:
+		output = append(output, actionItems[0])
+	default:
+		output = append(output, fmt.Sprintf("(%s)", strings.Join(actionItems, "; ")))
+	}
+
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseSelect(node nodes.SelectStmt) (string, error) {
+	ctx := c.withContext("select")
+
+	if node.Op != nodes.SETOP_NONE {
+		return ctx.deparseSetOperation(node)
+	}
+
+	clauses := []deparseClause{}
+
+	if node.WithClause != nil {
+		withClause, err := ctx.deparseItem(node.WithClause)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, deparseClause{items: []string{withClause}})
+	}
+
+	itemCtx := ctx.indented()
+
+	if node.TargetList.Items != nil {
+		keyword := "SELECT"
+		if node.DistinctClause.Items != nil {
+			keyword = "SELECT DISTINCT"
+		}
+		targetListItems, err := itemCtx.deparseItemList(node.TargetList)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, deparseClause{keyword, targetListItems, ","})
+	}
+
+	if node.IntoClause != nil {
+		into := []string{}
+		if persistence := deparseRelPersistence(node.IntoClause.Rel); persistence != "" {
+			into = append(into, persistence)
+		}
+		rel, err := itemCtx.deparseItem(node.IntoClause.Rel)
+		if err != nil {
+			return "", err
+		}
+		into = append(into, rel)
+		clauses = append(clauses, deparseClause{"INTO", []string{strings.Join(into, " ")}, ""})
+	}
+
+	if node.FromClause.Items != nil {
+		fromClauseItems, err := itemCtx.deparseItemList(node.FromClause)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, deparseClause{"FROM", fromClauseItems, ","})
+	}
+
+	if node.WhereClause != nil {
+		whereClause, err := itemCtx.deparseCondition(node.WhereClause)
+		if err != nil {
+			return "", err
+		}
+		whereClause.keyword = "WHERE"
+		clauses = append(clauses, whereClause)
+	}
+
+	if node.ValuesLists != nil {
+		valuesListsItems := make([]string, len(node.ValuesLists))
+		for i, valuesList := range node.ValuesLists {
+			result, err := itemCtx.deparseValuesList(valuesList)
+			if err != nil {
+				return "", err
+			}
+			valuesListsItems[i] = result
+		}
+		clauses = append(clauses, deparseClause{"VALUES", valuesListsItems, ","})
+	}
+
+	if node.GroupClause.Items != nil {
+		groupItems, err := itemCtx.deparseItemList(node.GroupClause)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, deparseClause{"GROUP BY", groupItems, ","})
+	}
+
+	if node.HavingClause != nil {
+		havingClause, err := itemCtx.deparseCondition(node.HavingClause)
+		if err != nil {
+			return "", err
+		}
+		havingClause.keyword = "HAVING"
+		clauses = append(clauses, havingClause)
+	}
+
+	if node.WindowClause.Items != nil {
+		windowItems := []string{}
+		for _, item := range node.WindowClause.Items {
+			window, ok := item.(nodes.WindowDef)
+			if !ok || window.Name == nil {
+				return "", fmt.Errorf("Can't deparse window: %# v", pretty.Formatter(item))
+			}
+			spec, err := itemCtx.deparseItem(window)
+			if err != nil {
+				return "", err
+			}
+			windowItems = append(windowItems, fmt.Sprintf("%s AS (%s)", quoteIdentifier(*window.Name), spec))
+		}
+		clauses = append(clauses, deparseClause{"WINDOW", windowItems, ","})
+	}
+
+	tailClauses, err := ctx.deparseSelectTail(node)
+	if err != nil {
+		return "", err
+	}
+	clauses = append(clauses, tailClauses...)
+
+	return c.joinClauses(clauses), nil
+}
+
+// deparseSetOperation deparses UNION, INTERSECT and EXCEPT, putting
+// operands in parentheses where they wouldn't otherwise parse back the same
+func (c DeparseContext) deparseSetOperation(node nodes.SelectStmt) (string, error) {
+	var keyword string
+	switch node.Op {
+	case nodes.SETOP_UNION:
+		keyword = "UNION"
+	case nodes.SETOP_INTERSECT:
+		keyword = "INTERSECT"
+	case nodes.SETOP_EXCEPT:
+		keyword = "EXCEPT"
+	default:
+		return "", fmt.Errorf("Can't deparse set operation: %# v", pretty.Formatter(node))
+	}
+	if node.All {
+		keyword += " ALL"
+	}
+	if node.Larg == nil || node.Rarg == nil {
+		return "", fmt.Errorf("Can't deparse set operation: %# v", pretty.Formatter(node))
+	}
+
+	output := []string{}
+	if node.WithClause != nil {
+		withClause, err := c.deparseItem(node.WithClause)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, c.indentation()+withClause)
+	}
+	// The operands are complete statements, which are already indented
+	larg, err := c.deparseSetOperand(*node.Larg, node.Op, false)
+	if err != nil {
+		return "", err
+	}
+	rarg, err := c.deparseSetOperand(*node.Rarg, node.Op, true)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, larg, c.indentation()+keyword, rarg)
+
+	tailClauses, err := c.deparseSelectTail(node)
+	if err != nil {
+		return "", err
+	}
+	if len(tailClauses) > 0 {
+		output = append(output, c.joinClauses(tailClauses))
+	}
+	if c.format != nil {
+		return strings.Join(output, "\n"), nil
+	}
+	return strings.Join(output, " "), nil
+}
+
+// deparseSetOperand deparses an operand of a set operation, in parentheses
+// if it has clauses of its own or is a set operation that binds less tightly
+// than its parent. INTERSECT binds more tightly than UNION and EXCEPT, and
+// all of them are left-associative.
+func (c DeparseContext) deparseSetOperand(node nodes.SelectStmt, parentOp nodes.SetOperation, right bool) (string, error) {
+	parenthesize := node.WithClause != nil || node.SortClause.Items != nil || node.LimitCount != nil ||
+		node.LimitOffset != nil || node.LockingClause.Items != nil
+	if node.Op != nodes.SETOP_NONE {
+		if right {
+			parenthesize = parenthesize || parentOp == nodes.SETOP_INTERSECT || node.Op != nodes.SETOP_INTERSECT
+		} else {
+			parenthesize = parenthesize || (parentOp == nodes.SETOP_INTERSECT && node.Op != nodes.SETOP_INTERSECT)
 		}
-		output = append(output, coldeflist)
 	}
+	if !parenthesize {
+		return c.deparseItem(node)
+	}
+	subquery, err := c.deparseSubquery(node)
+	if err != nil {
+		return "", err
+	}
+	return c.indentation() + subquery, nil
+}
+
+// deparseSelectTail deparses the clauses that follow the body of a SELECT or
+// set operation: ORDER BY, LIMIT, OFFSET and the locking clauses
+func (c DeparseContext) deparseSelectTail(node nodes.SelectStmt) ([]deparseClause, error) {
+	clauses := []deparseClause{}
+	itemCtx := c.indented()
+	if node.SortClause.Items != nil {
+		sortItems, err := itemCtx.deparseItemList(node.SortClause)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, deparseClause{"ORDER BY", sortItems, ","})
+	}
+
+	if node.LimitCount != nil {
+		limitCount, err := c.deparseItem(node.LimitCount)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, deparseClause{"LIMIT", []string{limitCount}, ""})
+	}
+
+	if node.LimitOffset != nil {
+		limitOffset, err := c.deparseItem(node.LimitOffset)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, deparseClause{"OFFSET", []string{limitOffset}, ""})
+	}
+
+	if node.LockingClause.Items != nil {
+		lockingClauseItems, err := c.deparseItemList(node.LockingClause)
+		if err != nil {
+			return nil, err
+		}
+		for _, lockingClause := range lockingClauseItems {
+			clauses = append(clauses, deparseClause{items: []string{lockingClause}})
+		}
+	}
+
+	return clauses, nil
+}
+
+// deparseCondition deparses a WHERE or HAVING condition, keeping the
+// arguments of a top-level AND/OR apart so they can be put on separate lines
+func (c DeparseContext) deparseCondition(node nodes.Node) (deparseClause, error) {
+	if c.format != nil {
+		var boolExpr nodes.BoolExpr
+		switch node := node.(type) {
+		case nodes.BoolExpr:
+			boolExpr = node
+		case *nodes.BoolExpr:
+			boolExpr = *node
+		}
+		switch boolExpr.Boolop {
+		case nodes.AND_EXPR, nodes.OR_EXPR:
+			if len(boolExpr.Args.Items) == 0 {
+				break
+			}
+			// The comments attached to the condition precede its first
+			// argument, as they do when the condition is deparsed as a whole
+			var comments []Comment
+			if c.comments != nil {
+				comments = c.comments.byLocation[boolExpr.Location]
+				delete(c.comments.byLocation, boolExpr.Location)
+			}
+			args, err := c.deparseBoolExprArgs(boolExpr)
+			if err != nil {
+				return deparseClause{}, err
+			}
+			args[0] = c.leadingComments(comments) + args[0]
+			if boolExpr.Boolop == nodes.AND_EXPR {
+				return deparseClause{items: args, separator: "AND"}, nil
+			}
+			return deparseClause{items: args, separator: "OR"}, nil
+		}
+	}
+
+	condition, err := c.deparseItem(node)
+	if err != nil {
+		return deparseClause{}, err
+	}
+	return deparseClause{items: []string{condition}, separator: "AND"}, nil
+}
+
+// deparseSetClause deparses the assignments of UPDATE and ON CONFLICT DO
+// UPDATE, combining the columns of multiple-column assignments, e.g.
+// (a, b) = (1, 2)
+func (c DeparseContext) deparseSetClause(targetList nodes.List) ([]string, error) {
+	ctx := c.withContext("update")
+	output := []string{}
+	for i := 0; i < len(targetList.Items); i++ {
+		target, ok := targetList.Items[i].(nodes.ResTarget)
+		if !ok {
+			return nil, fmt.Errorf("Can't deparse assignment: %# v", pretty.Formatter(targetList.Items[i]))
+		}
+		multiAssignRef, ok := target.Val.(nodes.MultiAssignRef)
+		if !ok {
+			result, err := ctx.deparseItem(target)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, result)
+			continue
+		}
+
+		if i+multiAssignRef.Ncolumns > len(targetList.Items) {
+			return nil, fmt.Errorf("Can't deparse assignment: %# v", pretty.Formatter(target))
+		}
+		columns := []string{}
+		for _, item := range targetList.Items[i : i+multiAssignRef.Ncolumns] {
+			column, ok := item.(nodes.ResTarget)
+			if !ok {
+				return nil, fmt.Errorf("Can't deparse assignment: %# v", pretty.Formatter(item))
+			}
+			column.Val = nil
+			result, err := c.withContext("insert").deparseItem(column)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, result)
+		}
+		source, err := c.withContext("select").deparseItem(multiAssignRef)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, fmt.Sprintf("(%s) = %s", strings.Join(columns, ", "), source))
+		i += multiAssignRef.Ncolumns - 1
+	}
+	return output, nil
+}
+
+func (c DeparseContext) deparseSetToDefault(node nodes.SetToDefault) (string, error) {
+	return "DEFAULT", nil
+}
+
+func (c DeparseContext) deparseSortBy(node nodes.SortBy) (string, error) {
+	output := []string{}
+	result, err := c.deparseItem(node.Node)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, result)
+	switch node.SortbyDir {
+	case nodes.SORTBY_ASC:
+		output = append(output, "ASC")
+	case nodes.SORTBY_DESC:
+		output = append(output, "DESC")
+	}
+
 	return strings.Join(output, " "), nil
 }
-func (c DeparseContext) deparseRangeSubselect(node nodes.RangeSubselect) (string, error) {
-	subquery, err := c.deparseItem(node.Subquery)
+
+func (c DeparseContext) deparseSQLValueFunction(node nodes.SQLValueFunction) (string, error) {
+	name, ok := sqlValueFunctionNames[node.Op]
+	if !ok {
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+	switch node.Op {
+	case nodes.SVFOP_CURRENT_TIME_N, nodes.SVFOP_CURRENT_TIMESTAMP_N, nodes.SVFOP_LOCALTIME_N, nodes.SVFOP_LOCALTIMESTAMP_N:
+		return fmt.Sprintf("%s(%d)", name, node.Typmod), nil
+	}
+	return name, nil
+}
+
+func (c DeparseContext) deparseSubLink(node nodes.SubLink) (string, error) {
+	subselect, err := c.deparseSubquery(node.Subselect)
 	if err != nil {
 		return "", err
 	}
-	output := fmt.Sprintf("(%s)", subquery)
-	if node.Alias != nil {
-		alias, err := c.deparseItem(node.Alias)
+
+	var testexpr string
+	if node.Testexpr != nil {
+		precedence := exprPrecedence(node)
+		testexpr, err = c.deparseOperand(node.Testexpr, precedence, precedence <= precedenceLike)
 		if err != nil {
 			return "", err
 		}
-		output = fmt.Sprintf("%s %s", output, alias)
 	}
-	return output, nil
+
+	switch node.SubLinkType {
+	case nodes.ANY_SUBLINK:
+		if node.OperName.Items == nil {
+			return fmt.Sprintf("%s IN %s", testexpr, subselect), nil
+		}
+		return fmt.Sprintf("%s %s ANY %s", testexpr, deparseOperatorName(node.OperName), subselect), nil
+	case nodes.ALL_SUBLINK:
+		return fmt.Sprintf("%s %s ALL %s", testexpr, deparseOperatorName(node.OperName), subselect), nil
+	case nodes.ROWCOMPARE_SUBLINK:
+		return fmt.Sprintf("%s %s %s", testexpr, deparseOperatorName(node.OperName), subselect), nil
+	case nodes.EXISTS_SUBLINK:
+		return fmt.Sprintf("EXISTS%s", subselect), nil
+	case nodes.ARRAY_SUBLINK:
+		return fmt.Sprintf("ARRAY%s", subselect), nil
+	default:
+		return subselect, nil
+	}
+}
+
+// deparseTransactionModes deparses the options of BEGIN and SET TRANSACTION
+func (c DeparseContext) deparseTransactionModes(options nodes.List) (string, error) {
+	output := []string{}
+	for _, item := range options.Items {
+		option, ok := item.(nodes.DefElem)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse transaction mode: %# v", pretty.Formatter(item))
+		}
+		arg, ok := option.Arg.(nodes.A_Const)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse transaction mode: %# v", pretty.Formatter(item))
+		}
+
+		switch *option.Defname {
+		case "transaction_isolation":
+			output = append(output, fmt.Sprintf("ISOLATION LEVEL %s", strings.ToUpper(arg.Val.(nodes.String).Str)))
+		case "transaction_read_only":
+			if arg.Val.(nodes.Integer).Ival == 0 {
+				output = append(output, "READ WRITE")
+			} else {
+				output = append(output, "READ ONLY")
+			}
+		case "transaction_deferrable":
+			if arg.Val.(nodes.Integer).Ival == 0 {
+				output = append(output, "NOT DEFERRABLE")
+			} else {
+				output = append(output, "DEFERRABLE")
+			}
+		default:
+			return "", fmt.Errorf("Can't deparse transaction mode %s", *option.Defname)
+		}
+	}
+	return strings.Join(output, ", "), nil
+}
+
+func (c DeparseContext) deparseTransactionStmt(node nodes.TransactionStmt) (string, error) {
+	var output string
+	switch node.Kind {
+	case nodes.TRANS_STMT_BEGIN:
+		output = "BEGIN"
+	case nodes.TRANS_STMT_START:
+		output = "START TRANSACTION"
+	case nodes.TRANS_STMT_COMMIT:
+		return "COMMIT", nil
+	case nodes.TRANS_STMT_ROLLBACK:
+		return "ROLLBACK", nil
+	case nodes.TRANS_STMT_SAVEPOINT:
+		output = "SAVEPOINT"
+	case nodes.TRANS_STMT_RELEASE:
+		output = "RELEASE SAVEPOINT"
+	case nodes.TRANS_STMT_ROLLBACK_TO:
+		output = "ROLLBACK TO SAVEPOINT"
+	case nodes.TRANS_STMT_PREPARE:
+		return fmt.Sprintf("PREPARE TRANSACTION %s", quoteString(*node.Gid)), nil
+	case nodes.TRANS_STMT_COMMIT_PREPARED:
+		return fmt.Sprintf("COMMIT PREPARED %s", quoteString(*node.Gid)), nil
+	case nodes.TRANS_STMT_ROLLBACK_PREPARED:
+		return fmt.Sprintf("ROLLBACK PREPARED %s", quoteString(*node.Gid)), nil
+	default:
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+
+	if node.Options.Items == nil {
+		return output, nil
+	}
+	switch node.Kind {
+	case nodes.TRANS_STMT_SAVEPOINT, nodes.TRANS_STMT_RELEASE, nodes.TRANS_STMT_ROLLBACK_TO:
+		for _, item := range node.Options.Items {
+			if option, ok := item.(nodes.DefElem); ok && *option.Defname == "savepoint_name" {
+				return fmt.Sprintf("%s %s", output, quoteIdentifier(option.Arg.(nodes.String).Str)), nil
+			}
+		}
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+	}
+
+	modes, err := c.deparseTransactionModes(node.Options)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s", output, modes), nil
+}
+
+func (c DeparseContext) deparseTriggerTransition(node nodes.TriggerTransition) (string, error) {
+	output := []string{}
+	if node.IsNew {
+		output = append(output, "NEW")
+	} else {
+		output = append(output, "OLD")
+	}
+	if node.IsTable {
+		output = append(output, "TABLE")
+	} else {
+		output = append(output, "ROW")
+	}
+	output = append(output, "AS", quoteIdentifier(*node.Name))
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseTruncateStmt(node nodes.TruncateStmt) (string, error) {
+	relationItems, err := c.deparseItemList(node.Relations)
+	if err != nil {
+		return "", err
+	}
+	output := []string{"TRUNCATE", strings.Join(relationItems, ", ")}
+	if node.RestartSeqs {
+		output = append(output, "RESTART IDENTITY")
+	}
+	if node.Behavior == nodes.DROP_CASCADE {
+		output = append(output, "CASCADE")
+	}
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseTypeCast(node nodes.TypeCast) (string, error) {
+	arg, err := c.deparseOperand(node.Arg, precedenceTypeCast, false)
+	if err != nil {
+		return "", err
+	}
+	ctx := c.withContext("type_name")
+	typeName, err := ctx.deparseItem(node.TypeName)
+	if err != nil {
+		return "", err
+	}
+	// true and false are parsed to 't'::bool and 'f'::bool
+	if typeName == "boolean" {
+		switch arg {
+		case "'t'":
+			return "true", nil
+		case "'f'":
+			return "false", nil
+		}
+	}
+	return fmt.Sprintf("%s::%s", arg, typeName), nil
 }
 
-func (c DeparseContext) deparseRangeVar(node nodes.RangeVar) (string, error) {
-	output := []string{}
-
-	if !node.Inh {
-		output = append(output, "ONLY")
+func (c DeparseContext) deparseTypeName(node nodes.TypeName) (string, error) {
+	names := []string{}
+	for _, item := range node.Names.Items {
+		if str, ok := item.(nodes.String); ok {
+			names = append(names, str.Str)
+		}
 	}
-	if node.Schemaname != nil {
-		output = append(output, fmt.Sprintf(`"%s"."%s"`, *node.Schemaname, *node.Relname))
-	} else {
-		output = append(output, fmt.Sprintf(`"%s"`, *node.Relname))
+
+	output := []string{}
+	if node.Setof {
+		output = append(output, "SETOF")
 	}
-	if node.Alias != nil {
-		alias, err := c.deparseItem(node.Alias)
+
+	var typeName string
+	switch {
+	case node.PctType:
+		// The type of a column, e.g. t.c%TYPE
+		typeName = deparseAnyName(node.Names) + "%TYPE"
+	case len(names) == 2 && names[0] == "pg_catalog" && names[1] == "interval":
+		fields, precision, err := deparseIntervalTypmods(node.Typmods)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, alias)
+		switch {
+		case fields == "" && precision != "":
+			typeName = fmt.Sprintf("interval(%s)", precision)
+		case precision != "":
+			typeName = fmt.Sprintf("interval %s(%s)", fields, precision)
+		case fields != "":
+			typeName = fmt.Sprintf("interval %s", fields)
+		default:
+			typeName = "interval"
+		}
+	default:
+		var typmods string
+		if node.Typmods.Items != nil {
+			typmodItems, err := c.deparseItemList(node.Typmods)
+			if err != nil {
+				return "", err
+			}
+			typmods = strings.Join(typmodItems, ", ")
+		}
+		typeName = deparseTypeNameCast(names, typmods)
+	}
+
+	for _, item := range node.ArrayBounds.Items {
+		if bound, ok := item.(nodes.Integer); ok && bound.Ival >= 0 {
+			typeName += fmt.Sprintf("[%d]", bound.Ival)
+		} else {
+			typeName += "[]"
+		}
 	}
+	output = append(output, typeName)
 	return strings.Join(output, " "), nil
 }
 
-func (c DeparseContext) deparseRawStmt(node nodes.RawStmt) (string, error) {
-	return c.deparseItem(node.Stmt)
+// deparseTypeNameCast writes a type name with its modifiers. The types the
+// grammar qualifies with pg_catalog are written in their SQL syntax, e.g. bit
+// varying(5) for pg_catalog.varbit, other types keep their schema since
+// pg_catalog was written explicitly
+func deparseTypeNameCast(names []string, typmods string) string {
+	withTypmods := func(name string) string {
+		if typmods == "" {
+			return name
+		}
+		return fmt.Sprintf("%s(%s)", name, typmods)
+	}
+
+	if len(names) == 2 && names[0] == "pg_catalog" {
+		switch names[1] {
+		case "bool", "int2", "int4", "int8", "float4", "float8":
+			if typmods == "" {
+				return sqlTypeNames[names[1]]
+			}
+		case "bpchar":
+			// Without modifiers char would be char(1)
+			if typmods != "" {
+				return withTypmods("char")
+			}
+		case "bit":
+			if typmods != "" {
+				return withTypmods("bit")
+			}
+		case "varbit":
+			return withTypmods("bit varying")
+		case "numeric", "varchar", "time", "timestamp":
+			return withTypmods(names[1])
+		case "timetz":
+			return withTypmods("time") + " with time zone"
+		case "timestamptz":
+			return withTypmods("timestamp") + " with time zone"
+		}
+	}
+
+	quoted := []string{}
+	for _, name := range names {
+		quoted = append(quoted, quoteIdentifier(name))
+	}
+	return withTypmods(strings.Join(quoted, "."))
 }
 
-func (c DeparseContext) deparseResTarget(node nodes.ResTarget) (string, error) {
-	if c.Context == "select" {
-		val, err := c.deparseItem(node.Val)
+func (c DeparseContext) deparseUnlistenStmt(node nodes.UnlistenStmt) (string, error) {
+	if node.Conditionname == nil {
+		return "UNLISTEN *", nil
+	}
+	return fmt.Sprintf("UNLISTEN %s", quoteIdentifier(*node.Conditionname)), nil
+}
+
+func (c DeparseContext) deparseUpdateStmt(node nodes.UpdateStmt) (string, error) {
+	ctx := c.withContext("select")
+	clauses := []deparseClause{}
+
+	if node.WithClause != nil {
+		withClause, err := ctx.deparseItem(node.WithClause)
 		if err != nil {
 			return "", err
 		}
-		if node.Name != nil {
-			return fmt.Sprintf("%s AS %s", val, *node.Name), nil
-		} else {
-			return val, nil
-		}
+		clauses = append(clauses, deparseClause{items: []string{withClause}})
 	}
-	// TODO context == update
-	// TODO node.Val == nil
-	return "", fmt.Errorf("Can't deparse %# v in context %s", pretty.Formatter(node), c.Context)
-}
 
-func (c DeparseContext) deparseRowExpr(node nodes.RowExpr) (string, error) {
-	argItems, err := c.deparseItemList(node.Args)
+	relation, err := ctx.deparseItem(node.Relation)
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("ROW(%s)", strings.Join(argItems, ", ")), nil
-}
+	clauses = append(clauses, deparseClause{items: []string{"UPDATE " + relation}})
 
-func (c DeparseContext) deparseSelect(node nodes.SelectStmt) (string, error) {
-	output := []string{}
-	ctx := DeparseContext{Context: "select"}
+	itemCtx := ctx.indented()
+	targetItems, err := itemCtx.deparseSetClause(node.TargetList)
+	if err != nil {
+		return "", err
+	}
+	clauses = append(clauses, deparseClause{"SET", targetItems, ","})
 
-	if node.Op == 1 {
-		larg, err := ctx.deparseItem(node.Larg)
+	if node.FromClause.Items != nil {
+		fromItems, err := itemCtx.deparseItemList(node.FromClause)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, larg)
-		output = append(output, "UNION")
-		if node.All {
-			output = append(output, "ALL")
-		}
-		rarg, err := ctx.deparseItem(node.Rarg)
+		clauses = append(clauses, deparseClause{"FROM", fromItems, ","})
+	}
+
+	if node.WhereClause != nil {
+		whereClause, err := itemCtx.deparseCondition(node.WhereClause)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, rarg)
-		return strings.Join(output, " "), nil
+		whereClause.keyword = "WHERE"
+		clauses = append(clauses, whereClause)
 	}
 
-	if node.WithClause != nil {
-		withClause, err := ctx.deparseItem(node.WithClause)
+	if node.ReturningList.Items != nil {
+		returningItems, err := itemCtx.deparseItemList(node.ReturningList)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, withClause)
+		clauses = append(clauses, deparseClause{"RETURNING", returningItems, ","})
 	}
 
-	if node.TargetList.Items != nil {
-		output = append(output, "SELECT")
-		if node.DistinctClause.Items != nil {
-			output = append(output, "DISTINCT")
+	return c.joinClauses(clauses), nil
+}
+
+func (c DeparseContext) deparseVacuumStmt(node nodes.VacuumStmt) (string, error) {
+	output := []string{}
+	options := []string{}
+	if node.Options&int(nodes.VACOPT_VACUUM) != 0 {
+		output = append(output, "VACUUM")
+		if node.Options&int(nodes.VACOPT_FULL) != 0 {
+			options = append(options, "FULL")
+		}
+		if node.Options&int(nodes.VACOPT_FREEZE) != 0 {
+			options = append(options, "FREEZE")
+		}
+		if node.Options&int(nodes.VACOPT_VERBOSE) != 0 {
+			options = append(options, "VERBOSE")
+		}
+		if node.Options&int(nodes.VACOPT_ANALYZE) != 0 {
+			options = append(options, "ANALYZE")
+		}
+		if node.Options&int(nodes.VACOPT_DISABLE_PAGE_SKIPPING) != 0 {
+			options = append(options, "DISABLE_PAGE_SKIPPING")
+		}
+		if len(options) > 0 {
+			output = append(output, fmt.Sprintf("(%s)", strings.Join(options, ", ")))
+		}
+	} else {
+		output = append(output, "ANALYZE")
+		if node.Options&int(nodes.VACOPT_VERBOSE) != 0 {
+			output = append(output, "VERBOSE")
 		}
-		targetListItems, err := ctx.deparseItemList(node.TargetList)
+	}
+
+	if node.Relation != nil {
+		relation, err := c.deparseItem(node.Relation)
 		if err != nil {
 			return "", err
 		}
-		targetList := strings.Join(targetListItems, ", ")
-		output = append(output, targetList)
+		output = append(output, relation)
 	}
-
-	if node.FromClause.Items != nil {
-		output = append(output, "FROM")
-		fromClauseItems, err := ctx.deparseItemList(node.FromClause)
+	if node.VaCols.Items != nil {
+		colItems, err := c.deparseItemList(node.VaCols)
 		if err != nil {
 			return "", err
 		}
-		fromClause := strings.Join(fromClauseItems, ", ")
-		output = append(output, fromClause)
+		output = append(output, fmt.Sprintf("(%s)", strings.Join(colItems, ", ")))
 	}
 
-	if node.WhereClause != nil {
-		output = append(output, "WHERE")
-		whereClause, err := ctx.deparseItem(node.WhereClause)
+	return strings.Join(output, " "), nil
+}
+
+// deparseValuesList deparses a row of a VALUES list, e.g. (1, 'a')
+func (c DeparseContext) deparseValuesList(valuesList []nodes.Node) (string, error) {
+	valuesItems := make([]string, len(valuesList))
+	for i, valuesItem := range valuesList {
+		result, err := c.deparseItem(valuesItem)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, whereClause)
+		valuesItems[i] = result
 	}
+	return "(" + strings.Join(valuesItems, ", ") + ")", nil
+}
 
-	if node.ValuesLists != nil {
-		output = append(output, "VALUES")
-		valuesListsItems := make([]string, len(node.ValuesLists))
-		for i, valuesList := range node.ValuesLists {
-			valuesItems := make([]string, len(valuesList))
-			for j, valuesItem := range valuesList {
-				result, err := ctx.deparseItem(valuesItem)
+func (c DeparseContext) deparseVariableSetStmt(node nodes.VariableSetStmt) (string, error) {
+	output := []string{}
+	switch node.Kind {
+	case nodes.VAR_RESET:
+		return fmt.Sprintf("RESET %s", quoteQualifiedName(*node.Name)), nil
+	case nodes.VAR_RESET_ALL:
+		return "RESET ALL", nil
+	}
+
+	output = append(output, "SET")
+	if node.IsLocal {
+		output = append(output, "LOCAL")
+	}
+
+	switch node.Kind {
+	case nodes.VAR_SET_VALUE:
+		// Intervals are only accepted by the special TIME ZONE syntax
+		if *node.Name == "timezone" && len(node.Args.Items) == 1 {
+			if typeCast, ok := node.Args.Items[0].(nodes.TypeCast); ok {
+				interval, err := c.deparseIntervalConst(typeCast)
 				if err != nil {
 					return "", err
 				}
-				valuesItems[j] = result
+				output = append(output, "TIME ZONE", interval)
+				break
 			}
-			valuesListsItems[i] = fmt.Sprintf("(%s)", strings.Join(valuesItems, ", "))
 		}
-		output = append(output, strings.Join(valuesListsItems, ", "))
-	}
-
-	if node.GroupClause.Items != nil {
-		output = append(output, "GROUP BY")
-		groupItems, err := ctx.deparseItemList(node.GroupClause)
+		argItems, err := c.deparseItemList(node.Args)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, strings.Join(groupItems, ", "))
-	}
-
-	//TODO HavingClause
-
-	if node.SortClause.Items != nil {
-		output = append(output, "ORDER BY")
-		sortItems, err := ctx.deparseItemList(node.SortClause)
+		output = append(output, quoteQualifiedName(*node.Name), "TO", strings.Join(argItems, ", "))
+	case nodes.VAR_SET_DEFAULT:
+		output = append(output, quoteQualifiedName(*node.Name), "TO DEFAULT")
+	case nodes.VAR_SET_CURRENT:
+		output = append(output, quoteQualifiedName(*node.Name), "FROM CURRENT")
+	case nodes.VAR_SET_MULTI:
+		if *node.Name == "TRANSACTION SNAPSHOT" {
+			argItems, err := c.deparseItemList(node.Args)
+			if err != nil {
+				return "", err
+			}
+			output = append(output, "TRANSACTION SNAPSHOT", strings.Join(argItems, ", "))
+			break
+		}
+		modes, err := c.deparseTransactionModes(node.Args)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, strings.Join(sortItems, ", "))
+		switch *node.Name {
+		case "TRANSACTION":
+			output = append(output, "TRANSACTION", modes)
+		case "SESSION CHARACTERISTICS":
+			output = append(output, "SESSION CHARACTERISTICS AS TRANSACTION", modes)
+		default:
+			return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
+		}
+	default:
+		return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
 	}
 
-	if node.LimitCount != nil {
-		output = append(output, "LIMIT")
-		limitCount, err := ctx.deparseItem(node.LimitCount)
-		if err != nil {
-			return "", err
-		}
-		output = append(output, limitCount)
+	return strings.Join(output, " "), nil
+}
+
+func (c DeparseContext) deparseVariableShowStmt(node nodes.VariableShowStmt) (string, error) {
+	if *node.Name == "all" {
+		return "SHOW ALL", nil
 	}
+	return fmt.Sprintf("SHOW %s", quoteQualifiedName(*node.Name)), nil
+}
 
-	if node.LimitOffset != nil {
-		output = append(output, "OFFSET")
-		limitOffset, err := ctx.deparseItem(node.LimitOffset)
+func (c DeparseContext) deparseViewStmt(node nodes.ViewStmt) (string, error) {
+	output := []string{"CREATE"}
+	if node.Replace {
+		output = append(output, "OR REPLACE")
+	}
+	if persistence := deparseRelPersistence(node.View); persistence != "" {
+		output = append(output, persistence)
+	}
+	output = append(output, "VIEW")
+
+	viewName, err := c.deparseItem(node.View)
+	if err != nil {
+		return "", err
+	}
+	output = append(output, viewName)
+
+	if node.Aliases.Items != nil {
+		aliasItems, err := c.deparseItemList(node.Aliases)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, limitOffset)
+		output = append(output, fmt.Sprintf("(%s)", strings.Join(aliasItems, ", ")))
 	}
-
-	if node.LockingClause.Items != nil {
-		lockingClauseItems, err := ctx.deparseItemList(node.LockingClause)
+	if node.Options.Items != nil {
+		options, err := c.deparseRelOptions(node.Options)
 		if err != nil {
 			return "", err
 		}
-		output = append(output, lockingClauseItems...)
+		output = append(output, "WITH", options)
 	}
+	output = append(output, "AS")
 
-	return strings.Join(output, " "), nil
-}
-
-func (c DeparseContext) deparseSortBy(node nodes.SortBy) (string, error) {
-	output := []string{}
-	result, err := c.deparseItem(node.Node)
+	query, err := c.deparseItem(node.Query)
 	if err != nil {
 		return "", err
 	}
-	output = append(output, result)
-	switch node.SortbyDir {
-	case nodes.SORTBY_ASC:
-		output = append(output, "ASC")
-	case nodes.SORTBY_DESC:
-		output = append(output, "DESC")
-	}
+	result := strings.Join(output, " ") + c.clauseSeparator() + query
 
-	return strings.Join(output, " "), nil
+	switch node.WithCheckOption {
+	case nodes.LOCAL_CHECK_OPTION:
+		result += c.clauseSeparator() + "WITH LOCAL CHECK OPTION"
+	case nodes.CASCADED_CHECK_OPTION:
+		result += c.clauseSeparator() + "WITH CASCADED CHECK OPTION"
+	}
+	return result, nil
 }
 
-func (c DeparseContext) deparseSubLink(node nodes.SubLink) (string, error) {
-	subselect, err := c.deparseItem(node.Subselect)
-	if err != nil {
-		return "", err
+// deparseWindowDef deparses the specification of a window, without its name
+func (c DeparseContext) deparseWindowDef(node nodes.WindowDef) (string, error) {
+	output := []string{}
+
+	if node.Refname != nil {
+		output = append(output, quoteIdentifier(*node.Refname))
 	}
-	switch node.SubLinkType {
-	case nodes.ANY_SUBLINK:
-		testexpr, err := c.deparseItem(node.Testexpr)
+
+	if node.PartitionClause.Items != nil {
+		partitionItems, err := c.deparseItemList(node.PartitionClause)
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf("%s IN (%s)", testexpr, subselect), nil
-	case nodes.EXISTS_SUBLINK:
-		return fmt.Sprintf("EXISTS(%s)", subselect), nil
-	default:
-		return fmt.Sprintf("(%s)", subselect), nil
+		output = append(output, "PARTITION BY", strings.Join(partitionItems, ", "))
+	}
+
+	if node.OrderClause.Items != nil {
+		orderItems, err := c.deparseItemList(node.OrderClause)
+		if err != nil {
+			return "", err
+		}
+		output = append(output, "ORDER BY", strings.Join(orderItems, ", "))
+	}
+
+	if node.FrameOptions&frameOptionNonDefault != 0 {
+		if node.FrameOptions&frameOptionRows != 0 {
+			output = append(output, "ROWS")
+		} else {
+			output = append(output, "RANGE")
+		}
+
+		start, err := c.deparseFrameBound(node.FrameOptions, frameOptionStartUnboundedPreceding, frameOptionStartUnboundedFollowing, frameOptionStartCurrentRow, frameOptionStartValuePreceding, frameOptionStartValueFollowing, node.StartOffset)
+		if err != nil {
+			return "", err
+		}
+		if node.FrameOptions&frameOptionBetween != 0 {
+			end, err := c.deparseFrameBound(node.FrameOptions, frameOptionEndUnboundedPreceding, frameOptionEndUnboundedFollowing, frameOptionEndCurrentRow, frameOptionEndValuePreceding, frameOptionEndValueFollowing, node.EndOffset)
+			if err != nil {
+				return "", err
+			}
+			output = append(output, "BETWEEN", start, "AND", end)
+		} else {
+			output = append(output, start)
+		}
 	}
+
+	return strings.Join(output, " "), nil
 }
 
-func (c DeparseContext) deparseTypeCast(node nodes.TypeCast) (string, error) {
-	arg, err := c.deparseItem(node.Arg)
-	if err != nil {
-		return "", err
+func (c DeparseContext) deparseWithClause(node nodes.WithClause) (string, error) {
+	output := []string{}
+	output = append(output, "WITH")
+	if node.Recursive {
+		output = append(output, "RECURSIVE")
 	}
-	ctx := DeparseContext{Context: "type_name"}
-	typeName, err := ctx.deparseItem(node.TypeName)
+	cteItems, err := c.deparseItemList(node.Ctes)
 	if err != nil {
 		return "", err
 	}
-	if typeName == "boolean" {
-		if arg == "'t'" {
-			return "true", nil
-		}
-		return "false", nil
+	if c.format != nil {
+		output = append(output, strings.Join(cteItems, ","+c.newline()))
+	} else {
+		output = append(output, strings.Join(cteItems, ", "))
 	}
-	return fmt.Sprintf("%s::%s", arg, typeName), nil
+	return strings.Join(output, " "), nil
 }
 
-func (c DeparseContext) deparseTypeName(node nodes.TypeName) (string, error) {
-	ctx := DeparseContext{Context: "type_name"}
-	nameItems, err := ctx.deparseItemList(node.Names)
+func (c DeparseContext) deparseXmlExpr(node nodes.XmlExpr) (string, error) {
+	ctx := c.withContext("select")
+	argItems, err := ctx.deparseItemList(node.Args)
 	if err != nil {
 		return "", err
 	}
-	// TODO interval
 
-	output := []string{}
-	if node.Setof {
-		output = append(output, "SETOF")
-	}
-	var typmods string
-	if node.Typmods.Items != nil {
-		typmodItems, err := c.deparseItemList(node.Typmods)
+	// Attributes of XMLELEMENT and elements of XMLFOREST, e.g. a AS x
+	namedArgs := []string{}
+	for _, item := range node.NamedArgs.Items {
+		target, ok := item.(nodes.ResTarget)
+		if !ok {
+			return "", fmt.Errorf("Can't deparse XML argument: %# v", pretty.Formatter(item))
+		}
+		val, err := ctx.deparseItem(target.Val)
 		if err != nil {
 			return "", err
 		}
-		typmods = strings.Join(typmodItems, ", ")
-	}
-	typeNameCast, err := c.deparseTypeNameCast(nameItems, typmods)
-	if err != nil {
-		return "", err
-	}
-	output = append(output, typeNameCast)
-	if node.ArrayBounds.Items != nil {
-		output[len(output)-1] = fmt.Sprintf("%s[]", output[len(output)-1])
+		if target.Name != nil {
+			val = fmt.Sprintf("%s AS %s", val, quoteIdentifier(*target.Name))
+		}
+		namedArgs = append(namedArgs, val)
 	}
-	return strings.Join(output, " "), nil
-}
 
-func (c DeparseContext) deparseTypeNameCast(names []string, arguments string) (string, error) {
-	if names[0] != "pg_catalog" {
-		return strings.Join(names, "."), nil
-	}
-	switch names[1] {
-	case "bpchar":
-		return fmt.Sprintf("char(%s)", arguments), nil
-	case "varchar":
-		if arguments != "" {
-			return fmt.Sprintf("varchar(%s)", arguments), nil
-		}
-		return "varchar", nil
-	case "numeric":
-		if arguments != "" {
-			return fmt.Sprintf("numeric(%s)", arguments), nil
-		}
-		return "numeric", nil
-	case "bool":
-		return "boolean", nil
-	case "int2":
-		return "smallint", nil
-	case "int4":
-		return "int", nil
-	case "int8":
-		return "bigint", nil
-	case "real", "float4":
-		return "real", nil
-	case "float8":
-		return "double", nil
-	case "time":
-		return "time", nil
-	case "timetz":
-		return "time with time zone", nil
-	case "timestamp":
-		return "timestamp", nil
-	case "timestamptz":
-		return "timestamp with time zone", nil
-	default:
-		return "", fmt.Errorf("Can't deparse type %s", names[1])
+	switch node.Op {
+	case nodes.IS_XMLCONCAT:
+		return fmt.Sprintf("XMLCONCAT(%s)", strings.Join(argItems, ", ")), nil
+	case nodes.IS_XMLELEMENT:
+		args := []string{"NAME " + quoteIdentifier(*node.Name)}
+		if len(namedArgs) > 0 {
+			args = append(args, fmt.Sprintf("XMLATTRIBUTES(%s)", strings.Join(namedArgs, ", ")))
+		}
+		args = append(args, argItems...)
+		return fmt.Sprintf("XMLELEMENT(%s)", strings.Join(args, ", ")), nil
+	case nodes.IS_XMLFOREST:
+		return fmt.Sprintf("XMLFOREST(%s)", strings.Join(namedArgs, ", ")), nil
+	case nodes.IS_XMLPARSE:
+		if len(argItems) != 2 {
+			break
+		}
+		output := fmt.Sprintf("%s %s", deparseXmlOption(node.Xmloption), argItems[0])
+		// The second argument is 't'::bool when whitespace is preserved
+		if typeCast, ok := node.Args.Items[1].(nodes.TypeCast); ok {
+			if aConst, ok := typeCast.Arg.(nodes.A_Const); ok {
+				if str, ok := aConst.Val.(nodes.String); ok && str.Str == "t" {
+					output += " PRESERVE WHITESPACE"
+				}
+			}
+		}
+		return fmt.Sprintf("XMLPARSE(%s)", output), nil
+	case nodes.IS_XMLPI:
+		args := []string{"NAME " + quoteIdentifier(*node.Name)}
+		args = append(args, argItems...)
+		return fmt.Sprintf("XMLPI(%s)", strings.Join(args, ", ")), nil
+	case nodes.IS_XMLROOT:
+		if len(argItems) != 3 {
+			break
+		}
+		args := []string{argItems[0]}
+		if version, ok := node.Args.Items[1].(nodes.A_Const); ok {
+			if _, ok := version.Val.(nodes.Null); ok {
+				args = append(args, "VERSION NO VALUE")
+			} else {
+				args = append(args, "VERSION "+argItems[1])
+			}
+		}
+		// See XmlStandaloneType in utils/xml.h
+		if standalone, ok := node.Args.Items[2].(nodes.A_Const); ok {
+			if value, ok := standalone.Val.(nodes.Integer); ok {
+				switch value.Ival {
+				case 0:
+					args = append(args, "STANDALONE YES")
+				case 1:
+					args = append(args, "STANDALONE NO")
+				case 2:
+					args = append(args, "STANDALONE NO VALUE")
+				}
+			}
+		}
+		return fmt.Sprintf("XMLROOT(%s)", strings.Join(args, ", ")), nil
+	case nodes.IS_DOCUMENT:
+		if len(argItems) != 1 {
+			break
+		}
+		if exprPrecedence(node.Args.Items[0]) <= precedenceIs {
+			return fmt.Sprintf("(%s) IS DOCUMENT", argItems[0]), nil
+		}
+		return fmt.Sprintf("%s IS DOCUMENT", argItems[0]), nil
 	}
+	return "", fmt.Errorf("Can't deparse: %# v", pretty.Formatter(node))
 }
 
-func (c DeparseContext) deparseWithClause(node nodes.WithClause) (string, error) {
-	output := []string{}
-	output = append(output, "WITH")
-	if node.Recursive {
-		output = append(output, "RECURSIVE")
+func (c DeparseContext) deparseXmlSerialize(node nodes.XmlSerialize) (string, error) {
+	expr, err := c.withContext("select").deparseItem(node.Expr)
+	if err != nil {
+		return "", err
 	}
-	cteItems, err := c.deparseItemList(node.Ctes)
+	typeName, err := c.deparseItem(node.TypeName)
 	if err != nil {
 		return "", err
 	}
-	output = append(output, strings.Join(cteItems, ", "))
-	return strings.Join(output, " "), nil
+	return fmt.Sprintf("XMLSERIALIZE(%s %s AS %s)", deparseXmlOption(node.Xmloption), expr, typeName), nil
 }
 
 /*
@@ -1172,33 +4695,3 @@ def deparse_windowdef(node)
 	format('(%s)', output.join(' '))
 end
 */
-
-func (c DeparseContext) deparseWindowDef(node nodes.WindowDef) (string, error) {
-	// if len(*node.Name) != 0 {
-	// 	return deparseI
-	// }
-
-	output := strings.Builder{}
-
-	if len(node.PartitionClause.Items) > 0 {
-		output.WriteString("PARTITION BY ")
-		cteItems, err := c.deparseItemList(node.PartitionClause)
-		if err != nil {
-			return "", err
-		}
-		output.WriteString(strings.Join(cteItems, ", "))
-		output.WriteByte(' ')
-	}
-
-	if len(node.OrderClause.Items) > 0 {
-		output.WriteString("ORDER BY ")
-		cteItems, err := c.deparseItemList(node.OrderClause)
-		if err != nil {
-			return "", err
-		}
-		output.WriteString(strings.Join(cteItems, ", "))
-		output.WriteByte(' ')
-	}
-
-	return output.String(), nil
-}
@@ -1,6 +1,9 @@
 package pg_query_test
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"regexp"
 	"strings"
 	"testing"
@@ -15,6 +18,272 @@ type Query struct {
 }
 
 var queries = map[string][]Query{
+	"DDL": {
+		{
+			"CREATE FUNCTION",
+			`CREATE FUNCTION add(a int, b int) RETURNS int AS $$ SELECT a + b $$ LANGUAGE sql IMMUTABLE STRICT`,
+		},
+		{
+			"CREATE FUNCTION with parameter modes",
+			`CREATE OR REPLACE FUNCTION f(a int, OUT b int, INOUT c text DEFAULT 'x', VARIADIC d int[]) AS $$select 1$$ LANGUAGE sql`,
+		},
+		{
+			"CREATE FUNCTION returning table",
+			`CREATE FUNCTION t() RETURNS TABLE (x int, y text) AS $function$ select $$a$$ $function$ LANGUAGE plpgsql STABLE PARALLEL SAFE COST 10 ROWS 5 SECURITY DEFINER LEAKPROOF`,
+		},
+		{
+			"CREATE FUNCTION in C",
+			`CREATE FUNCTION c_fn(int) RETURNS int AS 'obj', 'sym' LANGUAGE c CALLED ON NULL INPUT SECURITY INVOKER NOT LEAKPROOF`,
+		},
+		{
+			"CREATE FUNCTION with SET",
+			`CREATE FUNCTION s() RETURNS SETOF record AS $$ select 1 $$ LANGUAGE sql SET search_path TO 'public', 'pg_temp' VOLATILE`,
+		},
+		{
+			"CREATE FUNCTION with %TYPE",
+			`CREATE FUNCTION p(x t.c%TYPE, y s."T".c%TYPE) RETURNS SETOF t.c%TYPE AS $$ select 1 $$ LANGUAGE sql`,
+		},
+		{
+			"CREATE TRIGGER",
+			`CREATE TRIGGER tr BEFORE INSERT OR UPDATE OF "a", "b" ON "t" FOR EACH ROW WHEN ("new"."a" > 0) EXECUTE PROCEDURE trg('x', 'y')`,
+		},
+		{
+			"CREATE TRIGGER with transition table",
+			`CREATE TRIGGER tr AFTER DELETE OR TRUNCATE ON "s"."t" REFERENCING OLD TABLE AS o FOR EACH STATEMENT EXECUTE PROCEDURE trg()`,
+		},
+		{
+			"CREATE TRIGGER INSTEAD OF",
+			`CREATE TRIGGER tr INSTEAD OF INSERT ON "v" FOR EACH ROW EXECUTE PROCEDURE trg()`,
+		},
+		{
+			"CREATE CONSTRAINT TRIGGER",
+			`CREATE CONSTRAINT TRIGGER tr AFTER UPDATE ON "t" FROM "u" DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE PROCEDURE trg()`,
+		},
+		{
+			"CREATE VIEW",
+			`CREATE VIEW "v" AS SELECT * FROM "t"`,
+		},
+		{
+			"CREATE VIEW with options",
+			`CREATE OR REPLACE TEMPORARY VIEW "v" ("a", "b") WITH (security_barrier=true) AS SELECT 1, 2 WITH CASCADED CHECK OPTION`,
+		},
+		{
+			"CREATE VIEW with LOCAL CHECK OPTION",
+			`CREATE VIEW "v" AS SELECT "a" FROM "t" WITH LOCAL CHECK OPTION`,
+		},
+		{
+			"CREATE TABLE AS",
+			`CREATE TABLE "n" AS SELECT * FROM "t"`,
+		},
+		{
+			"CREATE TABLE AS with options",
+			`CREATE TEMPORARY TABLE IF NOT EXISTS "n" ("a", "b") WITH (fillfactor=70) ON COMMIT DROP TABLESPACE ts AS SELECT 1, 2 WITH NO DATA`,
+		},
+		{
+			"CREATE TABLE AS EXECUTE",
+			`CREATE UNLOGGED TABLE "n" AS EXECUTE p (1)`,
+		},
+		{
+			"CREATE MATERIALIZED VIEW",
+			`CREATE MATERIALIZED VIEW "mv" AS SELECT 1`,
+		},
+		{
+			"REFRESH MATERIALIZED VIEW",
+			`REFRESH MATERIALIZED VIEW CONCURRENTLY "mv"`,
+		},
+		{
+			"REFRESH MATERIALIZED VIEW WITH NO DATA",
+			`REFRESH MATERIALIZED VIEW "mv" WITH NO DATA`,
+		},
+		{
+			"CREATE RULE",
+			`CREATE RULE r AS ON INSERT TO "t" DO INSTEAD NOTHING`,
+		},
+		{
+			"CREATE RULE with several actions",
+			`CREATE OR REPLACE RULE r AS ON UPDATE TO "t" WHERE "old"."a" <> "new"."a" DO (INSERT INTO "log" VALUES ("old"."a"); DELETE FROM "x" WHERE "a" = 1)`,
+		},
+		{
+			"DO",
+			`DO $$ BEGIN PERFORM 1; END $$`,
+		},
+		{
+			"DO with LANGUAGE",
+			`DO LANGUAGE plpgsql $$ BEGIN END $$`,
+		},
+	},
+	"DML": {
+		{
+			"INSERT",
+			`INSERT INTO "t" VALUES (1, 2)`,
+		},
+		{
+			"INSERT with alias and RETURNING",
+			`INSERT INTO "t" AS x ("a", "b") VALUES (1, 2), (3, 4) RETURNING *`,
+		},
+		{
+			"INSERT with indirection",
+			`INSERT INTO "t" ("a"[1], "b"."c") SELECT 1, 2`,
+		},
+		{
+			"INSERT DEFAULT VALUES",
+			`INSERT INTO "t" DEFAULT VALUES`,
+		},
+		{
+			"INSERT with WITH clause",
+			`WITH x AS (SELECT 1) INSERT INTO "t" ("a") VALUES (1), (2) RETURNING "a"`,
+		},
+		{
+			"INSERT with VALUES and LIMIT",
+			`INSERT INTO "t" VALUES (1), (2) LIMIT 1`,
+		},
+		{
+			"VALUES",
+			`VALUES (1, 'a'), (2, 'b')`,
+		},
+		{
+			"INSERT OVERRIDING",
+			`INSERT INTO "t" ("a") OVERRIDING SYSTEM VALUE VALUES (1)`,
+		},
+		{
+			"INSERT ON CONFLICT DO NOTHING",
+			`INSERT INTO "t" VALUES (1) ON CONFLICT DO NOTHING`,
+		},
+		{
+			"INSERT ON CONFLICT DO UPDATE",
+			`INSERT INTO "t" VALUES (1) ON CONFLICT (a, lower("b"), ("c" + 1) DESC) WHERE "a" > 0 DO UPDATE SET "a" = "excluded"."a", "b" = 2 WHERE "t"."a" < 5 RETURNING "a", "b" AS c`,
+		},
+		{
+			"INSERT ON CONFLICT ON CONSTRAINT",
+			`INSERT INTO "t" VALUES (1) ON CONFLICT ON CONSTRAINT t_pkey DO NOTHING`,
+		},
+		{
+			"INSERT with WITH",
+			`WITH x AS (SELECT 1) INSERT INTO "t" SELECT * FROM "x"`,
+		},
+		{
+			"UPDATE",
+			`UPDATE "t" SET "a" = 1, "b" = "b" + 1 WHERE "c" = 2`,
+		},
+		{
+			"UPDATE with FROM",
+			`UPDATE ONLY "t" x SET "a"[1] = 2 FROM "u" WHERE "x"."id" = "u"."id" RETURNING "x".*`,
+		},
+		{
+			"DELETE",
+			`DELETE FROM "t"`,
+		},
+		{
+			"DELETE with USING",
+			`DELETE FROM "t" USING "u" WHERE "t"."a" = "u"."a" AND "t"."b" = 1 RETURNING *`,
+		},
+		{
+			"DELETE in WITH",
+			`WITH d AS (DELETE FROM "t" RETURNING *) SELECT * FROM "d"`,
+		},
+	},
+	"EXPRESSION": {
+		{
+			"XMLELEMENT and XMLFOREST",
+			`SELECT XMLELEMENT(NAME foo, XMLATTRIBUTES("a" AS x, "b"), 'c', "d"), XMLFOREST("a" AS x, "b")`,
+		},
+		{
+			"XMLPARSE, XMLPI and XMLROOT",
+			`SELECT XMLPARSE(DOCUMENT '<a/>' PRESERVE WHITESPACE), XMLPARSE(CONTENT 'x'), XMLPI(NAME php, 'x'), XMLROOT("x", VERSION '1.0', STANDALONE YES), XMLROOT("x", VERSION NO VALUE)`,
+		},
+		{
+			"XMLCONCAT, XMLSERIALIZE and IS DOCUMENT",
+			`SELECT XMLCONCAT("a", "b"), XMLSERIALIZE(CONTENT "x" AS text), "x" IS DOCUMENT`,
+		},
+		{
+			"sublinks",
+			`SELECT "a" = ANY (SELECT 1), "a" < ALL (SELECT 1), ("a", "b") = (SELECT 1, 2), ARRAY(SELECT 1)`,
+		},
+		{
+			"pattern matching",
+			`SELECT "a" LIKE "b" ESCAPE 'x', "a" SIMILAR TO "b", "a" NOT SIMILAR TO "b" ESCAPE 'y', "a" ILIKE "b", "a" NOT ILIKE 'y'`,
+		},
+		{
+			"unary and qualified operators",
+			`SELECT -"a", "a" !, "a" OPERATOR(pg_catalog.+) "b", OPERATOR(pg_catalog.+) (1, 2)`,
+		},
+		{
+			"IS DISTINCT FROM",
+			`SELECT "a" IS DISTINCT FROM "b", "a" IS NOT DISTINCT FROM "b"`,
+		},
+		{
+			"IS OF",
+			`SELECT "a" IS OF (int, text), "a" IS NOT OF (int)`,
+		},
+		{
+			"ANY and ALL",
+			`SELECT "a" = ALL(ARRAY[1]), "a" = ANY("b")`,
+		},
+		{
+			"SQL value functions",
+			`SELECT CURRENT_DATE, CURRENT_TIME, CURRENT_TIME(2), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP(3), LOCALTIME, LOCALTIME(1), LOCALTIMESTAMP, LOCALTIMESTAMP(4), CURRENT_ROLE, CURRENT_USER, USER, SESSION_USER, CURRENT_CATALOG, CURRENT_SCHEMA`,
+		},
+		{
+			"GREATEST and LEAST",
+			`SELECT GREATEST("a", "b", 1), LEAST("a", 2)`,
+		},
+		{
+			"COLLATE",
+			`SELECT "a" COLLATE "C", "b" COLLATE pg_catalog."default" FROM "t" ORDER BY "c" COLLATE "de_DE"`,
+		},
+		{
+			"named and variadic arguments",
+			`SELECT f(a => 1, b => 'x'), g(1, VARIADIC ARRAY[1, 2])`,
+		},
+		{
+			"aggregate modifiers",
+			`SELECT count(*) FILTER (WHERE "a" > 1), array_agg("a" ORDER BY "b" DESC), percentile_cont(0.5) WITHIN GROUP (ORDER BY "a"), string_agg(DISTINCT "a", ',' ORDER BY "a") FROM "t"`,
+		},
+		{
+			"DEFAULT and CURRENT OF",
+			`UPDATE "t" SET "a" = DEFAULT WHERE CURRENT OF c`,
+		},
+		{
+			"multiple-column assignment",
+			`UPDATE "t" SET ("a", "b") = (1, 2), ("c", "d") = (SELECT 1, 2)`,
+		},
+		{
+			"subscripts and field selection",
+			`SELECT (f("x"))."a", (f("x")).*, "a"[1], "a"[1:2], "a"[:2], "a"[1:], ("a")."b"[1], $1[2], $1."f", (ARRAY[1, 2])[1]`,
+		},
+		{
+			"type modifiers and arrays",
+			`SELECT "a"::varchar(255), "a"::numeric(10, 2)::int[][3], "a"::char(2), "a"::bit(3), "a"::bit varying(5), "a"::mytype('x', 3), "a"::bool[]`,
+		},
+		{
+			"date and time types",
+			`SELECT "a"::timestamp(2) with time zone, "a"::time with time zone, "a"::timestamptz(3), "a"::interval HOUR TO MINUTE, "a"::interval(3), "a"::interval DAY TO SECOND(2), '1'::interval YEAR`,
+		},
+		{
+			"qualified and quoted types",
+			`SELECT "a"::"MyType", "a"::s.t, "a"::"S".t[], "a"::pg_catalog.text, "a"::pg_catalog.bpchar, "a"::"varchar", "a"::double precision, 'yes'::boolean, true, false`,
+		},
+		{
+			"arithmetic precedence",
+			`SELECT -("a" + "b"), ("a" + "b") * "c", "a" - ("b" - "c"), "a" - "b" - "c", "a" ^ ("b" ^ "c"), ("a" !) * 2, ("a" !) !, @("a" ## "b"), (-1)::int, ("a" || "b") COLLATE "C"`,
+		},
+		{
+			"comparison precedence",
+			`SELECT ("a" = "b") = "c", ("a" IS NULL) IS NULL, ("a" = "b") IN (true), "a" LIKE "b" || "c", "a" BETWEEN ("b" = "c") AND "d", ("a" OR "b") IS TRUE, ("a" IS DISTINCT FROM "b") IS DOCUMENT`,
+		},
+		{
+			"boolean precedence",
+			`SELECT 1 WHERE ("a" OR "b") AND NOT ("c" AND "d") AND ("e" AND ("f" OR "g")) IS NOT NULL AND ("h" OR ("i" OR "j"))`,
+		},
+		{
+			"SQL-standard function syntax",
+			`SELECT POSITION('a' IN "b"), OVERLAY("a" PLACING 'x' FROM 2 FOR 3), OVERLAY("a" PLACING 'x' FROM 2), TRIM(BOTH 'x' FROM "a"), TRIM(LEADING "a"), TRIM(TRAILING 'x' FROM "a", "b"), COLLATION FOR ("a")`,
+		},
+		{
+			"SQL-standard operator syntax",
+			`SELECT EXTRACT(epoch FROM "a"), EXTRACT(year FROM "a"), EXTRACT('select' FROM "a"), (("a" + 1) AT TIME ZONE 'UTC'), (("a", "b") OVERLAPS ("c", "d")), XMLEXISTS('//x' PASSING "d")`,
+		},
+	},
 	"SELECT": {
 		{
 			"basic statement",
@@ -303,9 +572,369 @@ var queries = map[string][]Query{
 		    AS (row_cols varchar[], admin int, ordinary int)
 		    `,
 		},
+		{
+			"with HAVING",
+			`SELECT "a", count(*) FROM "x" GROUP BY "a" HAVING count(*) > 1`,
+		},
+		{
+			"with UNION in WITH",
+			`WITH a AS (SELECT 1) SELECT * FROM "a" UNION ALL SELECT 2`,
+		},
 		{
 			"with window function",
-			`WITH cte_raw_data AS (SELECT i_start_time, i_device_id, input_port, row_number() OVER (PARTITION BY i_device_id, input_port ORDER BY i_start_time ASC) FROM foo WHERE i_start_time >= '2020-09-28 10:19:38' AND i_start_time < '2020-09-29 10:19:38' GROUP BY i_start_time, i_device_id, input_port) SELECT 1`,
+			`WITH cte_raw_data AS (SELECT "i_start_time", "i_device_id", "input_port", row_number() OVER (PARTITION BY "i_device_id", "input_port" ORDER BY "i_start_time" ASC) FROM "foo" WHERE "i_start_time" >= '2020-09-28 10:19:38' AND "i_start_time" < '2020-09-29 10:19:38' GROUP BY "i_start_time", "i_device_id", "input_port") SELECT 1`,
+		},
+		{
+			"with window frame",
+			`SELECT sum("a") OVER (ROWS UNBOUNDED PRECEDING), sum("b") OVER (PARTITION BY "c" ORDER BY "d" DESC ROWS BETWEEN CURRENT ROW AND 3 FOLLOWING) FROM "t"`,
+		},
+		{
+			"with named window",
+			`SELECT sum("a") OVER w, rank() OVER (w ORDER BY "b" ROWS BETWEEN 2 PRECEDING AND UNBOUNDED FOLLOWING) FROM "t" WINDOW w AS (PARTITION BY "c")`,
+		},
+		{
+			"with range frame",
+			`SELECT sum("c") OVER (RANGE BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) FROM "t"`,
+		},
+		{
+			"with grouping sets",
+			`SELECT "a", GROUPING("a", "b") FROM "t" GROUP BY GROUPING SETS (("a", "b"), CUBE ("c", ("d", "e")), ROLLUP ("a"), ())`,
+		},
+		{
+			"with TABLESAMPLE",
+			`SELECT * FROM "t" x TABLESAMPLE system (10) REPEATABLE (1)`,
+		},
+		{
+			"with XMLTABLE",
+			`SELECT * FROM XMLTABLE(XMLNAMESPACES('http://x' AS x, DEFAULT 'y'), '/rows/row' PASSING "data" COLUMNS id int PATH '@id' DEFAULT 0 NOT NULL, n FOR ORDINALITY) xt`,
+		},
+		{
+			"with LATERAL XMLTABLE",
+			`SELECT * FROM LATERAL XMLTABLE('/a' PASSING ("x" || "y") COLUMNS a text) x`,
+		},
+		{
+			"with INTO",
+			`SELECT * INTO TEMPORARY "n" FROM "t"`,
+		},
+		{
+			"with LATERAL and WITH ORDINALITY",
+			`SELECT * FROM "t", LATERAL generate_series(1, "t"."a") WITH ORDINALITY g("x", "n")`,
+		},
+		{
+			"with ROWS FROM",
+			`SELECT * FROM ROWS FROM (f(1), g(2) AS (a int)) WITH ORDINALITY`,
+		},
+		{
+			"with column definition list",
+			`SELECT * FROM f() AS (a int, b text)`,
+		},
+		{
+			"with locking clauses",
+			`SELECT * FROM "t" FOR UPDATE OF "t" NOWAIT FOR SHARE SKIP LOCKED`,
+		},
+		{
+			"with key locking clauses",
+			`SELECT * FROM "t" FOR KEY SHARE FOR NO KEY UPDATE`,
+		},
+		{
+			"with LATERAL subquery",
+			`SELECT * FROM "t", LATERAL (SELECT "t"."a") s`,
+		},
+		{
+			"with INTERSECT and EXCEPT",
+			`SELECT 1 EXCEPT ALL SELECT 2 INTERSECT SELECT 3`,
+		},
+		{
+			"with nested set operations",
+			`(SELECT 1 UNION SELECT 2) INTERSECT (SELECT 3 LIMIT 1)`,
+		},
+		{
+			"with ORDER BY and LIMIT on UNION",
+			`SELECT "a" FROM "t" UNION SELECT "b" FROM "u" ORDER BY 1 LIMIT 1 OFFSET 2`,
+		},
+	},
+	"SECURITY": {
+		{
+			"CREATE ROLE",
+			`CREATE ROLE r WITH LOGIN SUPERUSER CREATEDB CREATEROLE INHERIT REPLICATION BYPASSRLS CONNECTION LIMIT 5 PASSWORD 'x' VALID UNTIL '2020-01-01' IN ROLE a ROLE b ADMIN c SYSID 5`,
+		},
+		{
+			"CREATE USER",
+			`CREATE USER u WITH NOLOGIN NOSUPERUSER PASSWORD NULL`,
+		},
+		{
+			"ALTER ROLE",
+			`ALTER ROLE CURRENT_USER WITH NOINHERIT`,
+		},
+		{
+			"ALTER GROUP ADD USER",
+			`ALTER GROUP g ADD USER a, b`,
+		},
+		{
+			"ALTER GROUP DROP USER",
+			`ALTER GROUP g DROP USER a`,
+		},
+		{
+			"DROP ROLE",
+			`DROP ROLE IF EXISTS a, b`,
+		},
+		{
+			"ALTER DEFAULT PRIVILEGES",
+			`ALTER DEFAULT PRIVILEGES IN SCHEMA s FOR ROLE r GRANT SELECT ON TABLES TO PUBLIC`,
+		},
+		{
+			"ALTER DEFAULT PRIVILEGES REVOKE",
+			`ALTER DEFAULT PRIVILEGES REVOKE ALL ON SCHEMAS FROM r`,
+		},
+		{
+			"GRANT",
+			`GRANT SELECT ("a", "b"), UPDATE ON TABLE "t", "u" TO r WITH GRANT OPTION`,
+		},
+		{
+			"GRANT ALL",
+			`GRANT ALL ("a") ON TABLE "t" TO r`,
+		},
+		{
+			"GRANT on function",
+			`GRANT EXECUTE ON FUNCTION f(int, text) TO r`,
+		},
+		{
+			"GRANT on types",
+			`GRANT USAGE ON TYPE s.t, u TO r`,
+		},
+		{
+			"GRANT on large object",
+			`GRANT SELECT ON LARGE OBJECT 5 TO r`,
+		},
+		{
+			"REVOKE",
+			`REVOKE GRANT OPTION FOR ALL ON ALL TABLES IN SCHEMA s FROM r CASCADE`,
+		},
+		{
+			"GRANT role",
+			`GRANT a, b TO c WITH ADMIN OPTION GRANTED BY d`,
+		},
+		{
+			"REVOKE role",
+			`REVOKE ADMIN OPTION FOR a FROM c CASCADE`,
+		},
+		{
+			"CREATE POLICY",
+			`CREATE POLICY p ON "t"`,
+		},
+		{
+			"CREATE POLICY with roles and conditions",
+			`CREATE POLICY p ON "t" AS RESTRICTIVE FOR SELECT TO r, PUBLIC USING ("a" = 1) WITH CHECK ("b" = 2)`,
+		},
+		{
+			"ALTER POLICY",
+			`ALTER POLICY p ON "t" TO CURRENT_USER USING (true)`,
+		},
+		{
+			"REASSIGN OWNED",
+			`REASSIGN OWNED BY a, b TO c`,
+		},
+		{
+			"DROP OWNED",
+			`DROP OWNED BY a CASCADE`,
+		},
+		{
+			"ALTER FUNCTION OWNER",
+			`ALTER FUNCTION f(int) OWNER TO SESSION_USER`,
+		},
+		{
+			"ALTER FUNCTION OWNER without arguments",
+			`ALTER FUNCTION f OWNER TO r`,
+		},
+		{
+			"ALTER AGGREGATE OWNER",
+			`ALTER AGGREGATE a(*) OWNER TO r`,
+		},
+		{
+			"ALTER OPERATOR OWNER",
+			`ALTER OPERATOR + (int, NONE) OWNER TO r`,
+		},
+		{
+			"ALTER OPERATOR CLASS OWNER",
+			`ALTER OPERATOR CLASS c USING btree OWNER TO r`,
+		},
+		{
+			"ALTER SCHEMA OWNER",
+			`ALTER SCHEMA s OWNER TO r`,
+		},
+		{
+			"ALTER TABLE OWNER",
+			`ALTER TABLE "s"."t" OWNER TO r`,
+		},
+		{
+			"ALTER relation OWNER",
+			`ALTER VIEW IF EXISTS "v" OWNER TO CURRENT_USER; ALTER MATERIALIZED VIEW "m" OWNER TO r; ALTER SEQUENCE "q" OWNER TO r`,
+		},
+	},
+	"UTILITY": {
+		{
+			"SET",
+			`SET search_path TO 'a', 'B', 'public'`,
+		},
+		{
+			"SET LOCAL",
+			`SET LOCAL statement_timeout TO 5`,
+		},
+		{
+			"SET TIME ZONE interval",
+			`SET TIME ZONE INTERVAL '+00:00' HOUR TO MINUTE`,
+		},
+		{
+			"SET qualified name",
+			`SET myapp.user_id TO 'x'`,
+		},
+		{
+			"SET TO DEFAULT",
+			`SET x TO DEFAULT`,
+		},
+		{
+			"SET FROM CURRENT",
+			`SET x FROM CURRENT`,
+		},
+		{
+			"SET TRANSACTION",
+			`SET TRANSACTION ISOLATION LEVEL SERIALIZABLE, READ ONLY`,
+		},
+		{
+			"SET TRANSACTION SNAPSHOT",
+			`SET TRANSACTION SNAPSHOT '00000003-0000001B-1'`,
+		},
+		{
+			"SET SESSION CHARACTERISTICS",
+			`SET SESSION CHARACTERISTICS AS TRANSACTION ISOLATION LEVEL READ COMMITTED`,
+		},
+		{
+			"RESET",
+			`RESET x`,
+		},
+		{
+			"RESET ALL",
+			`RESET ALL`,
+		},
+		{
+			"SHOW",
+			`SHOW search_path`,
+		},
+		{
+			"SHOW ALL",
+			`SHOW ALL`,
+		},
+		{
+			"BEGIN",
+			`BEGIN ISOLATION LEVEL REPEATABLE READ, READ WRITE, NOT DEFERRABLE`,
+		},
+		{
+			"START TRANSACTION",
+			`START TRANSACTION`,
+		},
+		{
+			"COMMIT",
+			`COMMIT`,
+		},
+		{
+			"ROLLBACK",
+			`ROLLBACK`,
+		},
+		{
+			"SAVEPOINT",
+			`SAVEPOINT s1`,
+		},
+		{
+			"ROLLBACK TO SAVEPOINT",
+			`ROLLBACK TO SAVEPOINT s1`,
+		},
+		{
+			"RELEASE SAVEPOINT with quoted name",
+			`RELEASE SAVEPOINT "Select"`,
+		},
+		{
+			"PREPARE TRANSACTION",
+			`PREPARE TRANSACTION 'foo'`,
+		},
+		{
+			"COMMIT PREPARED",
+			`COMMIT PREPARED 'foo'`,
+		},
+		{
+			"ROLLBACK PREPARED",
+			`ROLLBACK PREPARED 'foo'`,
+		},
+		{
+			"EXPLAIN",
+			`EXPLAIN SELECT 1`,
+		},
+		{
+			"EXPLAIN with options",
+			`EXPLAIN (ANALYZE, BUFFERS false, FORMAT json) SELECT 1`,
+		},
+		{
+			"COPY FROM STDIN",
+			`COPY "foo" ("a", "b") FROM STDIN WITH (FORMAT csv, HEADER)`,
+		},
+		{
+			"COPY query",
+			`COPY (SELECT 1) TO '/tmp/x' WITH (FORMAT csv, HEADER 1)`,
+		},
+		{
+			"COPY TO PROGRAM",
+			`COPY "foo" TO PROGRAM 'gzip > x' WITH (FORCE_QUOTE ("a", "b"), DELIMITER ',', NULL '')`,
+		},
+		{
+			"COPY TO STDOUT",
+			`COPY "foo" TO STDOUT WITH (FORCE_QUOTE *)`,
+		},
+		{
+			"VACUUM",
+			`VACUUM`,
+		},
+		{
+			"VACUUM with options",
+			`VACUUM (FULL, FREEZE, VERBOSE, ANALYZE) "foo" ("a", "b")`,
+		},
+		{
+			"ANALYZE",
+			`ANALYZE VERBOSE "foo" ("a")`,
+		},
+		{
+			"LOCK",
+			`LOCK TABLE "foo", ONLY "bar" IN SHARE ROW EXCLUSIVE MODE NOWAIT`,
+		},
+		{
+			"TRUNCATE",
+			`TRUNCATE "foo", "bar" RESTART IDENTITY CASCADE`,
+		},
+		{
+			"DISCARD",
+			`DISCARD TEMP`,
+		},
+		{
+			"LISTEN",
+			`LISTEN foo`,
+		},
+		{
+			"UNLISTEN",
+			`UNLISTEN *`,
+		},
+		{
+			"NOTIFY",
+			`NOTIFY foo, 'it''s'`,
+		},
+		{
+			"PREPARE",
+			`PREPARE p (int, text) AS SELECT $1`,
+		},
+		{
+			"EXECUTE",
+			`EXECUTE p (1, 'a')`,
+		},
+		{
+			"DEALLOCATE",
+			`DEALLOCATE ALL`,
 		},
 	},
 }
@@ -338,6 +967,108 @@ func TestDeparse(t *testing.T) {
 	}
 }
 
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestDeparseTo(t *testing.T) {
+	rows := strings.TrimSuffix(strings.Repeat("(1, 'abc', now()), ", 5000), ", ")
+	inputs := []string{
+		`INSERT INTO "t" ("a", "b", "c") VALUES ` + rows + ` ON CONFLICT DO NOTHING`,
+		`VALUES ` + rows,
+		`SELECT 1; INSERT INTO "t" VALUES (1); SELECT 2`,
+	}
+	for _, input := range inputs {
+		tree, err := pg_query.Parse(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deparsed, err := pg_query.Deparse(tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deparsed != input {
+			t.Errorf("mismatch\n%.200s\n%.200s", input, deparsed)
+		}
+
+		// Writers other than strings.Builder and bytes.Buffer are buffered
+		var buf bytes.Buffer
+		if err := pg_query.DeparseTo(struct{ io.Writer }{&buf}, tree); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != input {
+			t.Errorf("mismatch\n%.200s\n%.200s", input, buf.String())
+		}
+
+		if err := pg_query.DeparseTo(failingWriter{}, tree); err == nil {
+			t.Errorf("expected write error for %.200s", input)
+		}
+	}
+}
+
+func TestDeparseEditedTree(t *testing.T) {
+	column := func(name string) nodes.Node {
+		return nodes.ColumnRef{Fields: nodes.List{Items: []nodes.Node{nodes.String{Str: name}}}}
+	}
+	operator := func(name string, lexpr nodes.Node, rexpr nodes.Node) nodes.Node {
+		return nodes.A_Expr{
+			Kind:  nodes.AEXPR_OP,
+			Name:  nodes.List{Items: []nodes.Node{nodes.String{Str: name}}},
+			Lexpr: lexpr,
+			Rexpr: rexpr,
+		}
+	}
+	boolExpr := func(boolop nodes.BoolExprType, args ...nodes.Node) nodes.Node {
+		return nodes.BoolExpr{Boolop: boolop, Args: nodes.List{Items: args}}
+	}
+
+	tests := []struct {
+		node     nodes.Node
+		expected string
+	}{
+		{
+			boolExpr(nodes.AND_EXPR, column("a"), boolExpr(nodes.OR_EXPR, column("b"), column("c"))),
+			`"a" AND ("b" OR "c")`,
+		},
+		{
+			boolExpr(nodes.NOT_EXPR, boolExpr(nodes.AND_EXPR, column("a"), column("b"))),
+			`NOT ("a" AND "b")`,
+		},
+		{
+			operator("-", nil, operator("+", column("a"), column("b"))),
+			`-("a" + "b")`,
+		},
+		{
+			operator("*", operator("+", column("a"), column("b")), column("c")),
+			`("a" + "b") * "c"`,
+		},
+		{
+			operator("-", column("a"), operator("-", column("b"), column("c"))),
+			`"a" - ("b" - "c")`,
+		},
+		{
+			operator("+", column("a"), operator("*", column("b"), column("c"))),
+			`"a" + "b" * "c"`,
+		},
+		{
+			&nodes.NullTest{Arg: boolExpr(nodes.OR_EXPR, column("a"), column("b"))},
+			`("a" OR "b") IS NULL`,
+		},
+	}
+
+	for _, test := range tests {
+		deparsed, err := pg_query.DeparseItem(test.node)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deparsed != test.expected {
+			t.Errorf("mismatch\n%s\n%s", test.expected, deparsed)
+		}
+	}
+}
+
 func TestFoo2(t *testing.T) {
 	var n nodes.Node = nodes.BoolExpr{
 		Boolop: nodes.AND_EXPR,
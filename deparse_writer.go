@@ -0,0 +1,155 @@
+package pg_query
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// deparseWriter is the buffer DeparseTo writes to
+type deparseWriter interface {
+	io.Writer
+	io.StringWriter
+}
+
+// DeparseTo writes the SQL of tree to w, with the same output as Deparse.
+// Everything goes through a single buffer, and the rows of INSERT ... VALUES
+// and VALUES statements are written one at a time, so that large batches
+// aren't built up in memory.
+func DeparseTo(w io.Writer, tree ParsetreeList) error {
+	var out deparseWriter
+	var buffered *bufio.Writer
+	switch w := w.(type) {
+	case *strings.Builder:
+		out = w
+	case *bytes.Buffer:
+		out = w
+	default:
+		buffered = bufio.NewWriter(w)
+		out = buffered
+	}
+
+	comments := attachComments(tree)
+	for i, item := range tree.Statements {
+		ctx := DeparseContext{comments: comments[i]}
+		trailing, err := ctx.writeStatement(out, item)
+		if err != nil {
+			return err
+		}
+
+		output := ""
+		last := i == len(tree.Statements)-1
+		if !last {
+			output += ";"
+		}
+		trailingOutput, lineBreak := trailingComments(trailing)
+		output += trailingOutput
+		if lineBreak {
+			output += "\n"
+		} else if !last {
+			output += " "
+		}
+		if _, err := out.WriteString(output); err != nil {
+			return err
+		}
+	}
+
+	if buffered != nil {
+		return buffered.Flush()
+	}
+	return nil
+}
+
+// writeStatement writes a top-level statement to out and returns the comments
+// following it, see deparseStatement. Statements with VALUES lists are written
+// row by row unless they have comments to place.
+func (c DeparseContext) writeStatement(out deparseWriter, node nodes.Node) ([]Comment, error) {
+	if c.comments == nil && c.format == nil {
+		stmt := node
+		if rawStmt, ok := node.(nodes.RawStmt); ok {
+			stmt = rawStmt.Stmt
+		}
+		switch stmt := stmt.(type) {
+		case nodes.InsertStmt:
+			if selectStmt, ok := stmt.SelectStmt.(nodes.SelectStmt); ok && isPlainValues(selectStmt) {
+				return nil, c.writeInsertValues(out, stmt, selectStmt.ValuesLists)
+			}
+		case nodes.SelectStmt:
+			if isPlainValues(stmt) {
+				return nil, c.withContext("select").writeValuesLists(out, stmt.ValuesLists)
+			}
+		}
+	}
+
+	result, trailing, err := c.deparseStatement(node)
+	if err != nil {
+		return nil, err
+	}
+	_, err = out.WriteString(result)
+	return trailing, err
+}
+
+// isPlainValues returns whether a SELECT statement is only a VALUES list,
+// without WITH, ORDER BY, LIMIT or locking clauses
+func isPlainValues(node nodes.SelectStmt) bool {
+	return node.ValuesLists != nil &&
+		node.WithClause == nil &&
+		node.SortClause.Items == nil &&
+		node.LimitOffset == nil &&
+		node.LimitCount == nil &&
+		node.LockingClause.Items == nil
+}
+
+// writeInsertValues writes an INSERT statement whose query is a VALUES list
+func (c DeparseContext) writeInsertValues(out deparseWriter, node nodes.InsertStmt, valuesLists [][]nodes.Node) error {
+	head, tail, err := c.deparseInsertClauses(node)
+	if err != nil {
+		return err
+	}
+	if _, err := out.WriteString(c.joinClauses(head) + " "); err != nil {
+		return err
+	}
+	if err := c.withContext("select").writeValuesLists(out, valuesLists); err != nil {
+		return err
+	}
+	if len(tail) > 0 {
+		if _, err := out.WriteString(" " + c.joinClauses(tail)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeValuesLists writes a VALUES list one value at a time, with the same
+// output as deparseValuesList
+func (c DeparseContext) writeValuesLists(out deparseWriter, valuesLists [][]nodes.Node) error {
+	if _, err := out.WriteString("VALUES "); err != nil {
+		return err
+	}
+	for i, valuesList := range valuesLists {
+		separator := "("
+		if i > 0 {
+			separator = ", ("
+		}
+		for _, valuesItem := range valuesList {
+			result, err := c.deparseItem(valuesItem)
+			if err != nil {
+				return err
+			}
+			if _, err := out.WriteString(separator); err != nil {
+				return err
+			}
+			if _, err := out.WriteString(result); err != nil {
+				return err
+			}
+			separator = ", "
+		}
+		if _, err := out.WriteString(")"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package pg_query
+
+import (
+	"fmt"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// FingerprintExplanation describes where the fingerprints of two queries diverge
+type FingerprintExplanation struct {
+	LeftFingerprint  string
+	RightFingerprint string
+
+	// Index of the first diverging token, or -1 if the fingerprints match
+	Index int
+
+	// The diverging tokens, nil if the respective token stream ended first
+	Left  *nodes.FingerprintToken
+	Right *nodes.FingerprintToken
+}
+
+// Equal returns whether both queries have the same fingerprint
+func (e FingerprintExplanation) Equal() bool {
+	return e.Index < 0
+}
+
+func (e FingerprintExplanation) String() string {
+	if e.Equal() {
+		return fmt.Sprintf("fingerprints match (%s)", e.LeftFingerprint)
+	}
+
+	describe := func(token *nodes.FingerprintToken) string {
+		if token == nil {
+			return "end of fingerprint"
+		}
+		return token.String()
+	}
+
+	return fmt.Sprintf("fingerprints differ (%s vs %s) at token %d: %s vs %s",
+		e.LeftFingerprint, e.RightFingerprint, e.Index, describe(e.Left), describe(e.Right))
+}
+
+// ExplainFingerprint - Compares the fingerprints of two SQL statements and
+// reports the first token (and the node that produced it) where they diverge
+func ExplainFingerprint(a, b string) (explanation FingerprintExplanation, err error) {
+	left, err := Parse(a)
+	if err != nil {
+		return
+	}
+	right, err := Parse(b)
+	if err != nil {
+		return
+	}
+
+	explanation.LeftFingerprint = left.Fingerprint()
+	explanation.RightFingerprint = right.Fingerprint()
+	explanation.Index = -1
+
+	leftTokens := left.FingerprintTokens()
+	rightTokens := right.FingerprintTokens()
+	for i := 0; i < len(leftTokens) || i < len(rightTokens); i++ {
+		if i < len(leftTokens) && i < len(rightTokens) && leftTokens[i].Value == rightTokens[i].Value {
+			continue
+		}
+
+		explanation.Index = i
+		if i < len(leftTokens) {
+			explanation.Left = &leftTokens[i]
+		}
+		if i < len(rightTokens) {
+			explanation.Right = &rightTokens[i]
+		}
+		break
+	}
+
+	return
+}
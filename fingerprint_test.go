@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/tomaszjonak/pg_query_go"
@@ -55,3 +56,335 @@ func TestFingerprint(t *testing.T) {
 
 	fmt.Printf("\n")
 }
+
+func TestFingerprintTokens(t *testing.T) {
+	var fingerprintTests []fingerprintTest
+
+	file, err := ioutil.ReadFile("./testdata/fingerprint.json")
+	if err != nil {
+		t.Errorf("Could not load test file: %v\n", err)
+	}
+
+	err = json.Unmarshal(file, &fingerprintTests)
+	if err != nil {
+		t.Errorf("Could not parse test file: %v\n", err)
+	}
+
+	for _, test := range fingerprintTests {
+		tokens, err := pg_query.FingerprintTokens(test.Input)
+		if err != nil {
+			t.Errorf("FingerprintTokens(%s)\nerror %s\n\n", test.Input, err)
+			continue
+		}
+
+		actualParts := make([]string, len(tokens))
+		for i, token := range tokens {
+			actualParts[i] = token.Value
+		}
+		if len(actualParts) != len(test.ExpectedParts) || len(actualParts) > 0 && !reflect.DeepEqual(actualParts, test.ExpectedParts) {
+			t.Errorf("FingerprintTokens(%s)\nexpected parts %#v\nactual parts %#v\n\n", test.Input, test.ExpectedParts, actualParts)
+		}
+	}
+}
+
+func TestFingerprintTokensDeeplyNested(t *testing.T) {
+	// Every call nests its argument two levels deeper (through the args
+	// List), so the innermost calls are cut off by the fingerprint depth limit
+	input := "SELECT " + strings.Repeat("f(", 60) + "1" + strings.Repeat(")", 60)
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := nodes.NewFingerprintSubContext()
+	for _, node := range tree.Statements {
+		node.Fingerprint(ctx, nil, "")
+	}
+	tokens := tree.FingerprintTokens()
+	actualParts := make([]string, len(tokens))
+	for i, token := range tokens {
+		actualParts[i] = token.Value
+	}
+	if !reflect.DeepEqual(actualParts, ctx.Sum()) {
+		t.Fatalf("FingerprintTokens(%.50s)\nexpected parts %#v\nactual parts %#v", input, ctx.Sum(), actualParts)
+	}
+
+	// The n-th call (counting from the outermost) is the argument of n calls
+	calls := 0
+	for _, token := range tokens {
+		if token.Value != "FuncCall" {
+			continue
+		}
+		if token.Node != "FuncCall" || strings.Count(token.Path, ".args[0]") != calls {
+			t.Errorf("FingerprintTokens(%.50s): call %d annotated with %s at %s", input, calls, token.Node, token.Path)
+		}
+		calls++
+	}
+	if calls == 0 || calls == 60 {
+		t.Errorf("FingerprintTokens(%.50s): expected the depth limit to cut off some calls, got %d", input, calls)
+	}
+}
+
+var explainFingerprintTests = []struct {
+	a             string
+	b             string
+	expectedIndex int
+	expectedLeft  *nodes.FingerprintToken
+	expectedRight *nodes.FingerprintToken
+}{
+	{
+		"SELECT a, b FROM x WHERE y = 1",
+		"SELECT b, a FROM x WHERE y = 2",
+		-1,
+		nil,
+		nil,
+	},
+	{
+		"SELECT a FROM x WHERE y = $1",
+		"SELECT a FROM x WHERE z = $1",
+		26,
+		&nodes.FingerprintToken{Value: "y", Node: "String", Path: "[0].stmt.whereClause.lexpr.fields[0]"},
+		&nodes.FingerprintToken{Value: "z", Node: "String", Path: "[0].stmt.whereClause.lexpr.fields[0]"},
+	},
+	{
+		"SELECT a FROM public.x",
+		"SELECT a FROM x",
+		11,
+		&nodes.FingerprintToken{Value: "schemaname", Node: "RangeVar", Path: "[0].stmt.fromClause[0]"},
+		&nodes.FingerprintToken{Value: "targetList", Node: "SelectStmt", Path: "[0].stmt"},
+	},
+	{
+		"SELECT 1",
+		"SELECT 1; SELECT 2",
+		5,
+		nil,
+		&nodes.FingerprintToken{Value: "RawStmt", Node: "RawStmt", Path: "[1]"},
+	},
+}
+
+func TestExplainFingerprint(t *testing.T) {
+	for _, test := range explainFingerprintTests {
+		explanation, err := pg_query.ExplainFingerprint(test.a, test.b)
+		if err != nil {
+			t.Errorf("ExplainFingerprint(%s, %s)\nerror %s\n\n", test.a, test.b, err)
+			continue
+		}
+
+		if explanation.Equal() != (explanation.LeftFingerprint == explanation.RightFingerprint) {
+			t.Errorf("ExplainFingerprint(%s, %s)\nexplanation does not match fingerprints: %s\n\n", test.a, test.b, explanation)
+		}
+		if explanation.Index != test.expectedIndex ||
+			!reflect.DeepEqual(explanation.Left, test.expectedLeft) ||
+			!reflect.DeepEqual(explanation.Right, test.expectedRight) {
+			t.Errorf("ExplainFingerprint(%s, %s)\nexpected %d %v %v\nactual %s\n\n", test.a, test.b, test.expectedIndex, test.expectedLeft, test.expectedRight, explanation)
+		}
+	}
+}
+
+// Statements that exercise node types and value edge cases (e.g. zero
+// integers, empty strings) beyond what the SELECT combinations below cover
+var fingerprintParityStatements = []string{
+	"CREATE SEQUENCE s START 0 INCREMENT 1",
+	"ALTER SEQUENCE s RESTART 0",
+	"ALTER TABLE t ALTER COLUMN a SET STATISTICS 0",
+	"CREATE TABLE t (a int) WITH (fillfactor = 0)",
+	"CREATE TABLE t (a varchar(0), b numeric(10, 0) DEFAULT 0, c interval hour to minute)",
+	"CREATE TEMP TABLE t (a int)",
+	"CREATE TABLE t AS SELECT 0",
+	"CREATE INDEX CONCURRENTLY i ON t (a) WHERE a > 0",
+	"ALTER TABLE t ADD COLUMN a int NOT NULL DEFAULT 0",
+	"ALTER TABLE t ADD CONSTRAINT c CHECK (a > 0) NOT VALID",
+	"CREATE VIEW v AS SELECT a, b FROM t WITH CHECK OPTION",
+	"CREATE FUNCTION f(a int) RETURNS int AS $$ SELECT 0 $$ LANGUAGE sql",
+	"CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW EXECUTE PROCEDURE f(0)",
+	"CREATE TYPE e AS ENUM ('', 'a')",
+	"CREATE POLICY p ON t USING (a = current_user)",
+	"GRANT SELECT (a, b) ON t TO r",
+	"COMMENT ON TABLE t IS ''",
+	"INSERT INTO t (a, b) VALUES (b, a), (a, b), (0, 0)",
+	"INSERT INTO t VALUES (a, a) ON CONFLICT (a) DO UPDATE SET b = 0",
+	"UPDATE t SET a = b, c = 0 FROM u WHERE t.a = u.a RETURNING *",
+	"DELETE FROM t USING u WHERE t.a = u.a",
+	"VALUES (a, b), (b, a), (a, b)",
+	"WITH x AS (INSERT INTO t VALUES (0) RETURNING *) SELECT * FROM x",
+	"SELECT * INTO TEMP t FROM x",
+	"SELECT a FROM t WINDOW w AS (ORDER BY a ROWS BETWEEN 0 PRECEDING AND CURRENT ROW)",
+	"SELECT a FROM t GROUP BY GROUPING SETS ((a), (), ROLLUP (a, b))",
+	"SELECT * FROM t TABLESAMPLE SYSTEM (0) REPEATABLE (0)",
+	"SELECT * FROM generate_series(0, 1) WITH ORDINALITY AS g (a, n)",
+	"SELECT x[0], x[0:1] FROM t",
+	"FETCH 0 FROM c",
+	"EXECUTE p(0, '')",
+	"PREPARE p (int) AS SELECT $1",
+	"SET x = 0",
+	"EXPLAIN (ANALYZE, COSTS 0) SELECT 0",
+	"VACUUM (FREEZE) t (a)",
+	"COPY t (a, b) FROM STDIN WITH (FORMAT csv)",
+	"LOCK TABLE t IN SHARE MODE NOWAIT",
+	"BEGIN ISOLATION LEVEL SERIALIZABLE",
+	"DO $$ BEGIN END $$",
+}
+
+// generatedFingerprintCorpus combines common clauses into a large number of
+// SELECT statements, and adds deeply nested and very long expressions that hit
+// the depth and comparison cut-offs of the C implementation
+func generatedFingerprintCorpus() []string {
+	targets := []string{"0", "1", "a", "a, b", "b, a", "a AS x, a AS y", "*", "t.*", "count(*)", "a + 0",
+		"''", "B'0'", "1.5", "$1", "ARRAY[a, 0]", "CASE WHEN a THEN 0 ELSE b END", "(SELECT 0)", "a::text"}
+	froms := []string{"", " FROM t", " FROM s.t", " FROM t, u", " FROM u, t", " FROM t JOIN u ON t.a = u.a",
+		" FROM (VALUES (a, b), (b, a)) v", " FROM generate_series(0, 1) g", " FROM t, LATERAL (SELECT t.a) s"}
+	wheres := []string{"", " WHERE a = 0", " WHERE a IN (0, 1, b, b)", " WHERE a NOT IN (SELECT b FROM u)",
+		" WHERE a BETWEEN 0 AND 1", " WHERE a IS NULL OR b LIKE ''", " WHERE NOT (a = $1 AND b = $2)"}
+	suffixes := []string{"", " ORDER BY a DESC", " LIMIT 0 OFFSET 0", " GROUP BY a HAVING count(*) > 0",
+		" FOR UPDATE SKIP LOCKED", " UNION ALL SELECT 0"}
+
+	corpus := []string{}
+	for _, target := range targets {
+		for _, from := range froms {
+			for _, where := range wheres {
+				for _, suffix := range suffixes {
+					corpus = append(corpus, "SELECT "+target+from+where+suffix)
+				}
+			}
+		}
+	}
+
+	for depth := 1; depth <= 130; depth++ {
+		corpus = append(corpus, "SELECT "+strings.Repeat("(a + ", depth)+"1"+strings.Repeat(")", depth))
+		corpus = append(corpus, "SELECT a FROM t WHERE "+strings.Repeat("a || ", depth)+"b = 0")
+	}
+
+	for _, length := range []int{100, 200, 400} {
+		long := strings.Repeat("b + ", length)
+		corpus = append(corpus, "SELECT a FROM t WHERE a IN ("+long+"c, "+long+"d)")
+		corpus = append(corpus, "SELECT "+long+"c, "+long+"d FROM t")
+	}
+
+	return corpus
+}
+
+func TestFingerprintParity(t *testing.T) {
+	var fingerprintTests []fingerprintTest
+
+	file, err := ioutil.ReadFile("./testdata/fingerprint.json")
+	if err != nil {
+		t.Errorf("Could not load test file: %v\n", err)
+	}
+
+	err = json.Unmarshal(file, &fingerprintTests)
+	if err != nil {
+		t.Errorf("Could not parse test file: %v\n", err)
+	}
+
+	corpus := []string{}
+	for _, test := range fingerprintTests {
+		corpus = append(corpus, test.Input)
+	}
+	corpus = append(corpus, fingerprintParityStatements...)
+	corpus = append(corpus, generatedFingerprintCorpus()...)
+
+	for _, input := range corpus {
+		expected, err := pg_query.FastFingerprint(input)
+		if err != nil {
+			t.Errorf("FastFingerprint(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+
+		tree, err := pg_query.Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", input, err)
+			continue
+		}
+
+		actual := tree.Fingerprint()
+		if actual != expected {
+			t.Errorf("Fingerprint(%s)\nexpected %s (FastFingerprint)\nactual %s\n\n", input, expected, actual)
+		}
+	}
+}
+
+func TestFingerprintNode(t *testing.T) {
+	whereClause := func(input string) nodes.Node {
+		tree, err := pg_query.Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%s)\nerror %s\n\n", input, err)
+		}
+		switch stmt := tree.Statements[0].(nodes.RawStmt).Stmt.(type) {
+		case nodes.SelectStmt:
+			return stmt.WhereClause
+		case nodes.DeleteStmt:
+			return stmt.WhereClause
+		}
+		t.Fatalf("Unexpected statement %s\n\n", input)
+		return nil
+	}
+
+	selectWhere := nodes.FingerprintNode(whereClause("SELECT * FROM a WHERE x = 1 AND y IN (1, 2)"))
+	deleteWhere := nodes.FingerprintNode(whereClause("DELETE FROM b WHERE x = $1 AND y IN ($2)"))
+	orWhere := nodes.FingerprintNode(whereClause("SELECT * FROM a WHERE x = 1 OR y IN (1, 2)"))
+
+	if selectWhere != deleteWhere {
+		t.Errorf("FingerprintNode\nexpected identical predicates to match\nactual %s and %s\n\n", selectWhere, deleteWhere)
+	}
+	if selectWhere == orWhere {
+		t.Errorf("FingerprintNode\nexpected different predicates to differ\nactual %s and %s\n\n", selectWhere, orWhere)
+	}
+	if !strings.HasPrefix(selectWhere, "02") || len(selectWhere) != 42 {
+		t.Errorf("FingerprintNode\nunexpected format %s\n\n", selectWhere)
+	}
+	if nodes.FingerprintNodeUint64(whereClause("SELECT 1 WHERE x = 1")) != nodes.FingerprintNodeUint64(whereClause("SELECT 1 WHERE x = 2")) {
+		t.Errorf("FingerprintNodeUint64\nexpected identical predicates to match\n\n")
+	}
+
+	tree, err := pg_query.Parse("SELECT a FROM b WHERE c = 1")
+	if err != nil {
+		t.Fatalf("Parse error %s\n\n", err)
+	}
+	if nodes.FingerprintNode(tree.Statements[0]) != tree.Fingerprint() {
+		t.Errorf("FingerprintNode\nexpected statement fingerprint %s\nactual %s\n\n", tree.Fingerprint(), nodes.FingerprintNode(tree.Statements[0]))
+	}
+}
+
+var fingerprintOptionsTests = []struct {
+	a       string
+	b       string
+	options pg_query.FingerprintOptions
+	equal   bool
+}{
+	{"SELECT a FROM public.x", "SELECT a FROM x", pg_query.FingerprintOptions{}, false},
+	{"SELECT a FROM public.x", "SELECT a FROM x", pg_query.FingerprintOptions{IgnoreSchema: true}, true},
+	{"SELECT a, b FROM x", "SELECT b, a FROM x", pg_query.FingerprintOptions{}, true},
+	{"SELECT a, b FROM x", "SELECT b, a FROM x", pg_query.FingerprintOptions{TargetListOrder: true}, false},
+	{"SELECT a, b FROM x", "SELECT a, b FROM x", pg_query.FingerprintOptions{TargetListOrder: true}, true},
+	{"SELECT a FROM x WHERE y IN (1, 2)", "SELECT a FROM x WHERE y IN (1, 2, 3)", pg_query.FingerprintOptions{}, true},
+	{"SELECT a FROM x WHERE y IN (1, 2)", "SELECT a FROM x WHERE y IN (1, 2, 3)", pg_query.FingerprintOptions{InListLength: true}, false},
+	{"SELECT a FROM x WHERE y IN (1, 2)", "SELECT a FROM x WHERE y IN (3, 4)", pg_query.FingerprintOptions{InListLength: true}, true},
+	{"SELECT a FROM x LIMIT 10", "SELECT a FROM x LIMIT 20", pg_query.FingerprintOptions{}, true},
+	{"SELECT a FROM x LIMIT 10", "SELECT a FROM x LIMIT 20", pg_query.FingerprintOptions{LimitConstants: true}, false},
+	{"SELECT a FROM x LIMIT 10 OFFSET 5", "SELECT a FROM x LIMIT 10 OFFSET 6", pg_query.FingerprintOptions{LimitConstants: true}, false},
+	{"SELECT a FROM x WHERE y = 1 LIMIT 10", "SELECT a FROM x WHERE y = 2 LIMIT 10", pg_query.FingerprintOptions{LimitConstants: true}, true},
+	{"SELECT a FROM x ORDER BY a ASC", "SELECT a FROM x ORDER BY a DESC", pg_query.FingerprintOptions{}, false},
+	{"SELECT a FROM x ORDER BY a ASC", "SELECT a FROM x ORDER BY a DESC", pg_query.FingerprintOptions{IgnoreSortDirection: true}, true},
+	{"SELECT a FROM x ORDER BY a", "SELECT a FROM x ORDER BY b", pg_query.FingerprintOptions{IgnoreSortDirection: true}, false},
+}
+
+func TestFingerprintWithOptions(t *testing.T) {
+	for _, test := range fingerprintOptionsTests {
+		treeA, err := pg_query.Parse(test.a)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.a, err)
+			continue
+		}
+		treeB, err := pg_query.Parse(test.b)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.b, err)
+			continue
+		}
+
+		a := treeA.FingerprintWithOptions(test.options)
+		b := treeB.FingerprintWithOptions(test.options)
+		if (a == b) != test.equal {
+			t.Errorf("FingerprintWithOptions(%s, %s, %+v)\nexpected equal %t\nactual %s and %s\n\n", test.a, test.b, test.options, test.equal, a, b)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package pg_query
+
+import (
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// KeywordCase determines how Format writes SQL keywords
+type KeywordCase int
+
+const (
+	KeywordUpper KeywordCase = iota // SELECT ... FROM ...
+	KeywordLower                    // select ... from ...
+)
+
+// FormatOptions controls the layout of SQL written by Format
+type FormatOptions struct {
+	// Number of spaces per indentation level, defaults to 2
+	IndentWidth int
+
+	KeywordCase KeywordCase
+
+	// Lines longer than this are wrapped at the last space that fits, 0 means
+	// lines are never wrapped
+	MaxLineWidth int
+
+	// Put the comma separating list items at the start of the next line
+	// instead of at the end of the line
+	CommaFirst bool
+}
+
+// Format turns the parse tree back into SQL, like Deparse, but pretty-prints
+// it with every clause and list item on its own line
+func Format(tree ParsetreeList, opts FormatOptions) (string, error) {
+	if opts.IndentWidth <= 0 {
+		opts.IndentWidth = 2
+	}
+
+	comments := attachComments(tree)
+	results := make([]string, len(tree.Statements))
+	for i, item := range tree.Statements {
+		ctx := DeparseContext{format: &opts, comments: comments[i]}
+		result, trailing, err := ctx.deparseStatement(item)
+		if err != nil {
+			return "", err
+		}
+		trailingOutput, _ := trailingComments(trailing)
+		result = formatKeywordCase(result+";"+trailingOutput, opts.KeywordCase)
+		if opts.MaxLineWidth > 0 {
+			result = formatLineWidth(result, opts.MaxLineWidth, opts.IndentWidth)
+		}
+		results[i] = result + "\n"
+	}
+	return strings.Join(results, "\n"), nil
+}
+
+// deparseClause is a part of a statement, e.g. the target list of a SELECT
+type deparseClause struct {
+	keyword   string   // e.g. "FROM", empty for clauses that are written as-is
+	items     []string // deparsed items, e.g. the tables of a FROM clause
+	separator string   // "," or a boolean operator, empty if there's only one item
+}
+
+func (c DeparseContext) indented() DeparseContext {
+	c.indent++
+	return c
+}
+
+// indentation returns the whitespace at the start of lines at the current
+// indentation level
+func (c DeparseContext) indentation() string {
+	if c.format == nil {
+		return ""
+	}
+	return strings.Repeat(" ", c.indent*c.format.IndentWidth)
+}
+
+// newline returns the separator between the lines of a formatted statement
+func (c DeparseContext) newline() string {
+	return "\n" + c.indentation()
+}
+
+// clauseSeparator returns the separator between the clauses of a statement,
+// which start on a new line when pretty-printing
+func (c DeparseContext) clauseSeparator() string {
+	if c.format == nil {
+		return " "
+	}
+	return c.newline()
+}
+
+// joinClauses writes the clauses of a statement, on a single line unless
+// pretty-printing
+func (c DeparseContext) joinClauses(clauses []deparseClause) string {
+	if c.format == nil {
+		output := []string{}
+		for _, clause := range clauses {
+			if clause.keyword != "" {
+				output = append(output, clause.keyword)
+			}
+			separator := " "
+			if clause.separator == "," {
+				separator = ", "
+			} else if clause.separator != "" {
+				separator = " " + clause.separator + " "
+			}
+			output = append(output, strings.Join(clause.items, separator))
+		}
+		return strings.Join(output, " ")
+	}
+
+	lines := []string{}
+	itemIndentation := c.indented().indentation()
+	for _, clause := range clauses {
+		switch {
+		case clause.keyword == "":
+			for _, item := range clause.items {
+				lines = append(lines, c.indentation()+item)
+			}
+		case clause.separator == "":
+			lines = append(lines, c.indentation()+clause.keyword+" "+strings.Join(clause.items, " "))
+		default:
+			lines = append(lines, c.indentation()+clause.keyword)
+			for i, item := range clause.items {
+				switch {
+				case i == 0:
+					lines = append(lines, itemIndentation+item)
+				case clause.separator != ",":
+					lines = append(lines, itemIndentation+clause.separator+" "+item)
+				case c.format.CommaFirst:
+					// The comma replaces the end of the indentation, so that the
+					// items stay aligned whatever the indentation width
+					comma := ", "
+					if len(itemIndentation) < len(comma) {
+						comma = ","
+					}
+					lines = append(lines, itemIndentation[:len(itemIndentation)-len(comma)]+comma+item)
+				default:
+					lines[len(lines)-1] += ","
+					lines = append(lines, itemIndentation+item)
+				}
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// deparseSubquery deparses a statement nested in another one, in parentheses.
+// When pretty-printing the statement starts on a new line and is indented.
+func (c DeparseContext) deparseSubquery(node nodes.Node) (string, error) {
+	if c.format == nil {
+		subquery, err := c.deparseItem(node)
+		if err != nil {
+			return "", err
+		}
+		return "(" + subquery + ")", nil
+	}
+
+	subquery, err := c.indented().deparseItem(node)
+	if err != nil {
+		return "", err
+	}
+	return "(\n" + subquery + c.newline() + ")", nil
+}
+
+// formatKeywordCase changes the case of all keywords in sql, leaving string
+// constants and quoted identifiers untouched
+func formatKeywordCase(sql string, keywordCase KeywordCase) string {
+	var output strings.Builder
+	output.Grow(len(sql))
+
+	for pos := 0; pos < len(sql); {
+		end := skipQuoted(sql, pos)
+		if end > pos {
+			output.WriteString(sql[pos:end])
+			pos = end
+			continue
+		}
+
+		if !isIdentStart(sql[pos]) {
+			output.WriteByte(sql[pos])
+			pos++
+			continue
+		}
+
+		end = pos
+		for end < len(sql) && isIdentChar(sql[end]) {
+			end++
+		}
+		word := sql[pos:end]
+		if _, ok := keywords[strings.ToLower(word)]; ok {
+			if keywordCase == KeywordLower {
+				word = strings.ToLower(word)
+			} else {
+				word = strings.ToUpper(word)
+			}
+		}
+		output.WriteString(word)
+		pos = end
+	}
+
+	return output.String()
+}
+
+// formatLineWidth wraps lines longer than width at spaces, indenting the
+// continuation lines one level deeper than the line they belong to
+func formatLineWidth(sql string, width int, indentWidth int) string {
+	output := []string{}
+	for _, line := range strings.Split(sql, "\n") {
+		indentation := line[:len(line)-len(strings.TrimLeft(line, " "))]
+		continuation := indentation + strings.Repeat(" ", indentWidth)
+
+		for len(line) > width {
+			lead := len(line) - len(strings.TrimLeft(line, " "))
+			breakAt := -1
+			for pos := lead; pos < len(line); {
+				end := skipQuoted(line, pos)
+				if end > pos {
+					pos = end
+					continue
+				}
+				if line[pos] == ' ' {
+					if pos > width && breakAt >= 0 {
+						break
+					}
+					breakAt = pos
+				}
+				pos++
+			}
+			if breakAt < 0 || len(strings.TrimSpace(line[breakAt:])) == 0 {
+				break
+			}
+			output = append(output, strings.TrimRight(line[:breakAt], " "))
+			line = continuation + strings.TrimLeft(line[breakAt:], " ")
+		}
+		output = append(output, line)
+	}
+	return strings.Join(output, "\n")
+}
+
+// skipQuoted returns the end of the string constant, quoted identifier,
+// dollar-quoted string or comment starting at pos, or pos if there is none
+func skipQuoted(sql string, pos int) int {
+	switch sql[pos] {
+	case '-', '/':
+		return skipComment(sql, pos)
+	case '\'', '"':
+		quote := sql[pos]
+		end := pos + 1
+		for end < len(sql) {
+			if sql[end] == quote {
+				if end+1 < len(sql) && sql[end+1] == quote {
+					end += 2
+					continue
+				}
+				return end + 1
+			}
+			end++
+		}
+		return len(sql)
+	case '$':
+		if pos > 0 && isIdentChar(sql[pos-1]) {
+			return pos
+		}
+		end := pos + 1
+		for end < len(sql) && isIdentChar(sql[end]) && sql[end] != '$' {
+			end++
+		}
+		if end >= len(sql) || sql[end] != '$' || (end > pos+1 && sql[pos+1] >= '0' && sql[pos+1] <= '9') {
+			return pos
+		}
+		tag := sql[pos : end+1]
+		close := strings.Index(sql[end+1:], tag)
+		if close < 0 {
+			return len(sql)
+		}
+		return end + 1 + close + len(tag)
+	}
+	return pos
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,170 @@
+package pg_query_test
+
+import (
+	"testing"
+
+	pg_query "github.com/tomaszjonak/pg_query_go"
+)
+
+var formatTests = []struct {
+	input    string
+	options  pg_query.FormatOptions
+	expected string
+}{
+	{
+		`SELECT a, b AS c FROM x WHERE y = 5 AND z = y ORDER BY a LIMIT 10`,
+		pg_query.FormatOptions{},
+		`SELECT
+  "a",
+  "b" AS c
+FROM
+  "x"
+WHERE
+  "y" = 5
+  AND "z" = "y"
+ORDER BY
+  "a"
+LIMIT 10;
+`,
+	},
+	{
+		`select a, b from x join y on x.id = y.id where a = 1 or b = 2`,
+		pg_query.FormatOptions{IndentWidth: 4, KeywordCase: pg_query.KeywordLower, CommaFirst: true},
+		`select
+    "a"
+  , "b"
+from
+    "x"
+    join "y" on "x"."id" = "y"."id"
+where
+    "a" = 1
+    or "b" = 2;
+`,
+	},
+	{
+		`SELECT a, b FROM (SELECT c, d FROM x) s`,
+		pg_query.FormatOptions{IndentWidth: 3, CommaFirst: true},
+		`SELECT
+   "a"
+ , "b"
+FROM
+   (
+      SELECT
+         "c"
+       , "d"
+      FROM
+         "x"
+   ) s;
+`,
+	},
+	{
+		`SELECT a, b FROM x`,
+		pg_query.FormatOptions{IndentWidth: 1, CommaFirst: true},
+		`SELECT
+ "a"
+,"b"
+FROM
+ "x";
+`,
+	},
+	{
+		`SELECT count(*) FROM (SELECT DISTINCT a FROM x) s WHERE EXISTS (SELECT 1 FROM y)`,
+		pg_query.FormatOptions{},
+		`SELECT
+  count(*)
+FROM
+  (
+    SELECT DISTINCT
+      "a"
+    FROM
+      "x"
+  ) s
+WHERE
+  EXISTS(
+    SELECT
+      1
+    FROM
+      "y"
+  );
+`,
+	},
+	{
+		`WITH a AS (SELECT 1) SELECT * FROM a UNION SELECT 2; SELECT 'select'`,
+		pg_query.FormatOptions{KeywordCase: pg_query.KeywordLower},
+		`with a as (
+  select
+    1
+)
+select
+  *
+from
+  "a"
+union
+select
+  2;
+
+select
+  'select';
+`,
+	},
+	{
+		`SELECT "x" WHERE "a_long_column_name" = 'a long string constant' AND "b" = 1`,
+		pg_query.FormatOptions{MaxLineWidth: 30},
+		`SELECT
+  "x"
+WHERE
+  "a_long_column_name" =
+    'a long string constant'
+  AND "b" = 1;
+`,
+	},
+}
+
+func TestFormat(t *testing.T) {
+	for _, test := range formatTests {
+		tree, err := pg_query.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+
+		actual, err := pg_query.Format(tree, test.options)
+		if err != nil {
+			t.Errorf("Format(%s)\nerror %s\n\n", test.input, err)
+		} else if actual != test.expected {
+			t.Errorf("Format(%s)\nexpected %s\nactual %s\n\n", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	options := []pg_query.FormatOptions{
+		{},
+		{KeywordCase: pg_query.KeywordLower, CommaFirst: true, MaxLineWidth: 20},
+	}
+
+	for _, queries := range queries {
+		for _, query := range queries {
+			tree, err := pg_query.Parse(query.Query)
+			if err != nil {
+				t.Errorf("Parse(%s)\nerror %s\n\n", query.Query, err)
+				continue
+			}
+
+			for _, opts := range options {
+				formatted, err := pg_query.Format(tree, opts)
+				if err != nil {
+					t.Errorf("Format(%s)\nerror %s\n\n", query.Query, err)
+					continue
+				}
+
+				reparsed, err := pg_query.Parse(formatted)
+				if err != nil {
+					t.Errorf("Format(%s)\nerror reparsing %s: %s\n\n", query.Query, formatted, err)
+				} else if reparsed.Fingerprint() != tree.Fingerprint() {
+					t.Errorf("Format(%s)\nfingerprint changed for %s\n\n", query.Query, formatted)
+				}
+			}
+		}
+	}
+}
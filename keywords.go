@@ -0,0 +1,448 @@
+// Auto-generated from parser/include/parser/kwlist.h - DO NOT EDIT
+
+package pg_query
+
+type keywordCategory int
+
+const (
+	unreservedKeyword keywordCategory = iota
+	colNameKeyword
+	typeFuncNameKeyword
+	reservedKeyword
+)
+
+// keywords maps every SQL keyword known to the parser to its category
+var keywords = map[string]keywordCategory{
+	"abort":             unreservedKeyword,
+	"absolute":          unreservedKeyword,
+	"access":            unreservedKeyword,
+	"action":            unreservedKeyword,
+	"add":               unreservedKeyword,
+	"admin":             unreservedKeyword,
+	"after":             unreservedKeyword,
+	"aggregate":         unreservedKeyword,
+	"all":               reservedKeyword,
+	"also":              unreservedKeyword,
+	"alter":             unreservedKeyword,
+	"always":            unreservedKeyword,
+	"analyse":           reservedKeyword,
+	"analyze":           reservedKeyword,
+	"and":               reservedKeyword,
+	"any":               reservedKeyword,
+	"array":             reservedKeyword,
+	"as":                reservedKeyword,
+	"asc":               reservedKeyword,
+	"assertion":         unreservedKeyword,
+	"assignment":        unreservedKeyword,
+	"asymmetric":        reservedKeyword,
+	"at":                unreservedKeyword,
+	"attach":            unreservedKeyword,
+	"attribute":         unreservedKeyword,
+	"authorization":     typeFuncNameKeyword,
+	"backward":          unreservedKeyword,
+	"before":            unreservedKeyword,
+	"begin":             unreservedKeyword,
+	"between":           colNameKeyword,
+	"bigint":            colNameKeyword,
+	"binary":            typeFuncNameKeyword,
+	"bit":               colNameKeyword,
+	"boolean":           colNameKeyword,
+	"both":              reservedKeyword,
+	"by":                unreservedKeyword,
+	"cache":             unreservedKeyword,
+	"called":            unreservedKeyword,
+	"cascade":           unreservedKeyword,
+	"cascaded":          unreservedKeyword,
+	"case":              reservedKeyword,
+	"cast":              reservedKeyword,
+	"catalog":           unreservedKeyword,
+	"chain":             unreservedKeyword,
+	"char":              colNameKeyword,
+	"character":         colNameKeyword,
+	"characteristics":   unreservedKeyword,
+	"check":             reservedKeyword,
+	"checkpoint":        unreservedKeyword,
+	"class":             unreservedKeyword,
+	"close":             unreservedKeyword,
+	"cluster":           unreservedKeyword,
+	"coalesce":          colNameKeyword,
+	"collate":           reservedKeyword,
+	"collation":         typeFuncNameKeyword,
+	"column":            reservedKeyword,
+	"columns":           unreservedKeyword,
+	"comment":           unreservedKeyword,
+	"comments":          unreservedKeyword,
+	"commit":            unreservedKeyword,
+	"committed":         unreservedKeyword,
+	"concurrently":      typeFuncNameKeyword,
+	"configuration":     unreservedKeyword,
+	"conflict":          unreservedKeyword,
+	"connection":        unreservedKeyword,
+	"constraint":        reservedKeyword,
+	"constraints":       unreservedKeyword,
+	"content":           unreservedKeyword,
+	"continue":          unreservedKeyword,
+	"conversion":        unreservedKeyword,
+	"copy":              unreservedKeyword,
+	"cost":              unreservedKeyword,
+	"create":            reservedKeyword,
+	"cross":             typeFuncNameKeyword,
+	"csv":               unreservedKeyword,
+	"cube":              unreservedKeyword,
+	"current":           unreservedKeyword,
+	"current_catalog":   reservedKeyword,
+	"current_date":      reservedKeyword,
+	"current_role":      reservedKeyword,
+	"current_schema":    typeFuncNameKeyword,
+	"current_time":      reservedKeyword,
+	"current_timestamp": reservedKeyword,
+	"current_user":      reservedKeyword,
+	"cursor":            unreservedKeyword,
+	"cycle":             unreservedKeyword,
+	"data":              unreservedKeyword,
+	"database":          unreservedKeyword,
+	"day":               unreservedKeyword,
+	"deallocate":        unreservedKeyword,
+	"dec":               colNameKeyword,
+	"decimal":           colNameKeyword,
+	"declare":           unreservedKeyword,
+	"default":           reservedKeyword,
+	"defaults":          unreservedKeyword,
+	"deferrable":        reservedKeyword,
+	"deferred":          unreservedKeyword,
+	"definer":           unreservedKeyword,
+	"delete":            unreservedKeyword,
+	"delimiter":         unreservedKeyword,
+	"delimiters":        unreservedKeyword,
+	"depends":           unreservedKeyword,
+	"desc":              reservedKeyword,
+	"detach":            unreservedKeyword,
+	"dictionary":        unreservedKeyword,
+	"disable":           unreservedKeyword,
+	"discard":           unreservedKeyword,
+	"distinct":          reservedKeyword,
+	"do":                reservedKeyword,
+	"document":          unreservedKeyword,
+	"domain":            unreservedKeyword,
+	"double":            unreservedKeyword,
+	"drop":              unreservedKeyword,
+	"each":              unreservedKeyword,
+	"else":              reservedKeyword,
+	"enable":            unreservedKeyword,
+	"encoding":          unreservedKeyword,
+	"encrypted":         unreservedKeyword,
+	"end":               reservedKeyword,
+	"enum":              unreservedKeyword,
+	"escape":            unreservedKeyword,
+	"event":             unreservedKeyword,
+	"except":            reservedKeyword,
+	"exclude":           unreservedKeyword,
+	"excluding":         unreservedKeyword,
+	"exclusive":         unreservedKeyword,
+	"execute":           unreservedKeyword,
+	"exists":            colNameKeyword,
+	"explain":           unreservedKeyword,
+	"extension":         unreservedKeyword,
+	"external":          unreservedKeyword,
+	"extract":           colNameKeyword,
+	"false":             reservedKeyword,
+	"family":            unreservedKeyword,
+	"fetch":             reservedKeyword,
+	"filter":            unreservedKeyword,
+	"first":             unreservedKeyword,
+	"float":             colNameKeyword,
+	"following":         unreservedKeyword,
+	"for":               reservedKeyword,
+	"force":             unreservedKeyword,
+	"foreign":           reservedKeyword,
+	"forward":           unreservedKeyword,
+	"freeze":            typeFuncNameKeyword,
+	"from":              reservedKeyword,
+	"full":              typeFuncNameKeyword,
+	"function":          unreservedKeyword,
+	"functions":         unreservedKeyword,
+	"generated":         unreservedKeyword,
+	"global":            unreservedKeyword,
+	"grant":             reservedKeyword,
+	"granted":           unreservedKeyword,
+	"greatest":          colNameKeyword,
+	"group":             reservedKeyword,
+	"grouping":          colNameKeyword,
+	"handler":           unreservedKeyword,
+	"having":            reservedKeyword,
+	"header":            unreservedKeyword,
+	"hold":              unreservedKeyword,
+	"hour":              unreservedKeyword,
+	"identity":          unreservedKeyword,
+	"if":                unreservedKeyword,
+	"ilike":             typeFuncNameKeyword,
+	"immediate":         unreservedKeyword,
+	"immutable":         unreservedKeyword,
+	"implicit":          unreservedKeyword,
+	"import":            unreservedKeyword,
+	"in":                reservedKeyword,
+	"including":         unreservedKeyword,
+	"increment":         unreservedKeyword,
+	"index":             unreservedKeyword,
+	"indexes":           unreservedKeyword,
+	"inherit":           unreservedKeyword,
+	"inherits":          unreservedKeyword,
+	"initially":         reservedKeyword,
+	"inline":            unreservedKeyword,
+	"inner":             typeFuncNameKeyword,
+	"inout":             colNameKeyword,
+	"input":             unreservedKeyword,
+	"insensitive":       unreservedKeyword,
+	"insert":            unreservedKeyword,
+	"instead":           unreservedKeyword,
+	"int":               colNameKeyword,
+	"integer":           colNameKeyword,
+	"intersect":         reservedKeyword,
+	"interval":          colNameKeyword,
+	"into":              reservedKeyword,
+	"invoker":           unreservedKeyword,
+	"is":                typeFuncNameKeyword,
+	"isnull":            typeFuncNameKeyword,
+	"isolation":         unreservedKeyword,
+	"join":              typeFuncNameKeyword,
+	"key":               unreservedKeyword,
+	"label":             unreservedKeyword,
+	"language":          unreservedKeyword,
+	"large":             unreservedKeyword,
+	"last":              unreservedKeyword,
+	"lateral":           reservedKeyword,
+	"leading":           reservedKeyword,
+	"leakproof":         unreservedKeyword,
+	"least":             colNameKeyword,
+	"left":              typeFuncNameKeyword,
+	"level":             unreservedKeyword,
+	"like":              typeFuncNameKeyword,
+	"limit":             reservedKeyword,
+	"listen":            unreservedKeyword,
+	"load":              unreservedKeyword,
+	"local":             unreservedKeyword,
+	"localtime":         reservedKeyword,
+	"localtimestamp":    reservedKeyword,
+	"location":          unreservedKeyword,
+	"lock":              unreservedKeyword,
+	"locked":            unreservedKeyword,
+	"logged":            unreservedKeyword,
+	"mapping":           unreservedKeyword,
+	"match":             unreservedKeyword,
+	"materialized":      unreservedKeyword,
+	"maxvalue":          unreservedKeyword,
+	"method":            unreservedKeyword,
+	"minute":            unreservedKeyword,
+	"minvalue":          unreservedKeyword,
+	"mode":              unreservedKeyword,
+	"month":             unreservedKeyword,
+	"move":              unreservedKeyword,
+	"name":              unreservedKeyword,
+	"names":             unreservedKeyword,
+	"national":          colNameKeyword,
+	"natural":           typeFuncNameKeyword,
+	"nchar":             colNameKeyword,
+	"new":               unreservedKeyword,
+	"next":              unreservedKeyword,
+	"no":                unreservedKeyword,
+	"none":              colNameKeyword,
+	"not":               reservedKeyword,
+	"nothing":           unreservedKeyword,
+	"notify":            unreservedKeyword,
+	"notnull":           typeFuncNameKeyword,
+	"nowait":            unreservedKeyword,
+	"null":              reservedKeyword,
+	"nullif":            colNameKeyword,
+	"nulls":             unreservedKeyword,
+	"numeric":           colNameKeyword,
+	"object":            unreservedKeyword,
+	"of":                unreservedKeyword,
+	"off":               unreservedKeyword,
+	"offset":            reservedKeyword,
+	"oids":              unreservedKeyword,
+	"old":               unreservedKeyword,
+	"on":                reservedKeyword,
+	"only":              reservedKeyword,
+	"operator":          unreservedKeyword,
+	"option":            unreservedKeyword,
+	"options":           unreservedKeyword,
+	"or":                reservedKeyword,
+	"order":             reservedKeyword,
+	"ordinality":        unreservedKeyword,
+	"out":               colNameKeyword,
+	"outer":             typeFuncNameKeyword,
+	"over":              unreservedKeyword,
+	"overlaps":          typeFuncNameKeyword,
+	"overlay":           colNameKeyword,
+	"overriding":        unreservedKeyword,
+	"owned":             unreservedKeyword,
+	"owner":             unreservedKeyword,
+	"parallel":          unreservedKeyword,
+	"parser":            unreservedKeyword,
+	"partial":           unreservedKeyword,
+	"partition":         unreservedKeyword,
+	"passing":           unreservedKeyword,
+	"password":          unreservedKeyword,
+	"placing":           reservedKeyword,
+	"plans":             unreservedKeyword,
+	"policy":            unreservedKeyword,
+	"position":          colNameKeyword,
+	"preceding":         unreservedKeyword,
+	"precision":         colNameKeyword,
+	"prepare":           unreservedKeyword,
+	"prepared":          unreservedKeyword,
+	"preserve":          unreservedKeyword,
+	"primary":           reservedKeyword,
+	"prior":             unreservedKeyword,
+	"privileges":        unreservedKeyword,
+	"procedural":        unreservedKeyword,
+	"procedure":         unreservedKeyword,
+	"program":           unreservedKeyword,
+	"publication":       unreservedKeyword,
+	"quote":             unreservedKeyword,
+	"range":             unreservedKeyword,
+	"read":              unreservedKeyword,
+	"real":              colNameKeyword,
+	"reassign":          unreservedKeyword,
+	"recheck":           unreservedKeyword,
+	"recursive":         unreservedKeyword,
+	"ref":               unreservedKeyword,
+	"references":        reservedKeyword,
+	"referencing":       unreservedKeyword,
+	"refresh":           unreservedKeyword,
+	"reindex":           unreservedKeyword,
+	"relative":          unreservedKeyword,
+	"release":           unreservedKeyword,
+	"rename":            unreservedKeyword,
+	"repeatable":        unreservedKeyword,
+	"replace":           unreservedKeyword,
+	"replica":           unreservedKeyword,
+	"reset":             unreservedKeyword,
+	"restart":           unreservedKeyword,
+	"restrict":          unreservedKeyword,
+	"returning":         reservedKeyword,
+	"returns":           unreservedKeyword,
+	"revoke":            unreservedKeyword,
+	"right":             typeFuncNameKeyword,
+	"role":              unreservedKeyword,
+	"rollback":          unreservedKeyword,
+	"rollup":            unreservedKeyword,
+	"row":               colNameKeyword,
+	"rows":              unreservedKeyword,
+	"rule":              unreservedKeyword,
+	"savepoint":         unreservedKeyword,
+	"schema":            unreservedKeyword,
+	"schemas":           unreservedKeyword,
+	"scroll":            unreservedKeyword,
+	"search":            unreservedKeyword,
+	"second":            unreservedKeyword,
+	"security":          unreservedKeyword,
+	"select":            reservedKeyword,
+	"sequence":          unreservedKeyword,
+	"sequences":         unreservedKeyword,
+	"serializable":      unreservedKeyword,
+	"server":            unreservedKeyword,
+	"session":           unreservedKeyword,
+	"session_user":      reservedKeyword,
+	"set":               unreservedKeyword,
+	"setof":             colNameKeyword,
+	"sets":              unreservedKeyword,
+	"share":             unreservedKeyword,
+	"show":              unreservedKeyword,
+	"similar":           typeFuncNameKeyword,
+	"simple":            unreservedKeyword,
+	"skip":              unreservedKeyword,
+	"smallint":          colNameKeyword,
+	"snapshot":          unreservedKeyword,
+	"some":              reservedKeyword,
+	"sql":               unreservedKeyword,
+	"stable":            unreservedKeyword,
+	"standalone":        unreservedKeyword,
+	"start":             unreservedKeyword,
+	"statement":         unreservedKeyword,
+	"statistics":        unreservedKeyword,
+	"stdin":             unreservedKeyword,
+	"stdout":            unreservedKeyword,
+	"storage":           unreservedKeyword,
+	"strict":            unreservedKeyword,
+	"strip":             unreservedKeyword,
+	"subscription":      unreservedKeyword,
+	"substring":         colNameKeyword,
+	"symmetric":         reservedKeyword,
+	"sysid":             unreservedKeyword,
+	"system":            unreservedKeyword,
+	"table":             reservedKeyword,
+	"tables":            unreservedKeyword,
+	"tablesample":       typeFuncNameKeyword,
+	"tablespace":        unreservedKeyword,
+	"temp":              unreservedKeyword,
+	"template":          unreservedKeyword,
+	"temporary":         unreservedKeyword,
+	"text":              unreservedKeyword,
+	"then":              reservedKeyword,
+	"time":              colNameKeyword,
+	"timestamp":         colNameKeyword,
+	"to":                reservedKeyword,
+	"trailing":          reservedKeyword,
+	"transaction":       unreservedKeyword,
+	"transform":         unreservedKeyword,
+	"treat":             colNameKeyword,
+	"trigger":           unreservedKeyword,
+	"trim":              colNameKeyword,
+	"true":              reservedKeyword,
+	"truncate":          unreservedKeyword,
+	"trusted":           unreservedKeyword,
+	"type":              unreservedKeyword,
+	"types":             unreservedKeyword,
+	"unbounded":         unreservedKeyword,
+	"uncommitted":       unreservedKeyword,
+	"unencrypted":       unreservedKeyword,
+	"union":             reservedKeyword,
+	"unique":            reservedKeyword,
+	"unknown":           unreservedKeyword,
+	"unlisten":          unreservedKeyword,
+	"unlogged":          unreservedKeyword,
+	"until":             unreservedKeyword,
+	"update":            unreservedKeyword,
+	"user":              reservedKeyword,
+	"using":             reservedKeyword,
+	"vacuum":            unreservedKeyword,
+	"valid":             unreservedKeyword,
+	"validate":          unreservedKeyword,
+	"validator":         unreservedKeyword,
+	"value":             unreservedKeyword,
+	"values":            colNameKeyword,
+	"varchar":           colNameKeyword,
+	"variadic":          reservedKeyword,
+	"varying":           unreservedKeyword,
+	"verbose":           typeFuncNameKeyword,
+	"version":           unreservedKeyword,
+	"view":              unreservedKeyword,
+	"views":             unreservedKeyword,
+	"volatile":          unreservedKeyword,
+	"when":              reservedKeyword,
+	"where":             reservedKeyword,
+	"whitespace":        unreservedKeyword,
+	"window":            reservedKeyword,
+	"with":              reservedKeyword,
+	"within":            unreservedKeyword,
+	"without":           unreservedKeyword,
+	"work":              unreservedKeyword,
+	"wrapper":           unreservedKeyword,
+	"write":             unreservedKeyword,
+	"xml":               unreservedKeyword,
+	"xmlattributes":     colNameKeyword,
+	"xmlconcat":         colNameKeyword,
+	"xmlelement":        colNameKeyword,
+	"xmlexists":         colNameKeyword,
+	"xmlforest":         colNameKeyword,
+	"xmlnamespaces":     colNameKeyword,
+	"xmlparse":          colNameKeyword,
+	"xmlpi":             colNameKeyword,
+	"xmlroot":           colNameKeyword,
+	"xmlserialize":      colNameKeyword,
+	"xmltable":          colNameKeyword,
+	"year":              unreservedKeyword,
+	"yes":               unreservedKeyword,
+	"zone":              unreservedKeyword,
+}
@@ -0,0 +1,120 @@
+// Package lineage traces which catalog columns the data a statement writes is
+// copied or computed from, for INSERT, UPDATE, CREATE TABLE AS and views.
+package lineage
+
+import (
+	pg_query "github.com/tomaszjonak/pg_query_go"
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+	"github.com/tomaszjonak/pg_query_go/resolve"
+)
+
+// Kind tells how the value of a target column is obtained from a source
+// column
+type Kind int
+
+const (
+	// The value is copied unchanged, e.g. by SELECT a or through a CTE,
+	// subquery or set operation
+	KindCopy Kind = iota
+	// The value is computed from the source column, possibly among others,
+	// e.g. by an operator, a function, an aggregate or a cast
+	KindTransformation
+)
+
+func (kind Kind) String() string {
+	switch kind {
+	case KindCopy:
+		return "copy"
+	default:
+		return "transformation"
+	}
+}
+
+// Flow is a flow of data from a source column into a target column
+type Flow struct {
+	Target resolve.Column
+	Source resolve.Column
+	Kind   Kind
+}
+
+func (flow Flow) String() string {
+	return flow.Source.String() + " -> " + flow.Target.String() + " (" + flow.Kind.String() + ")"
+}
+
+// Extract - Parses the given SQL and returns the data flows of each
+// statement
+func Extract(c *catalog.Catalog, input string) ([][]Flow, error) {
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return ExtractTree(c, tree), nil
+}
+
+// ExtractTree returns the data flows of each statement of a parse tree
+func ExtractTree(c *catalog.Catalog, tree pg_query.ParsetreeList) [][]Flow {
+	flows := [][]Flow{}
+	for _, item := range tree.Statements {
+		stmt, _ := nodes.UnwrapRawStmt(item)
+		flows = append(flows, ExtractStatement(c, stmt))
+	}
+	return flows
+}
+
+// ExtractStatement returns the data flows of a statement: from the source
+// query of INSERT, the SET clause of UPDATE and ON CONFLICT DO UPDATE,
+// including the ones of data-modifying CTEs, and from the query of CREATE
+// TABLE AS, CREATE MATERIALIZED VIEW and CREATE VIEW into the new relation.
+// The columns of the new relation belong to its schema, or to the first
+// schema of the search path that exists.
+//
+// References that can't be resolved against the catalog don't result in
+// flows.
+func ExtractStatement(c *catalog.Catalog, node nodes.Node) []Flow {
+	result := resolve.ResolveStatement(c, node)
+	flows := []Flow{}
+	for _, assignment := range result.Assignments {
+		flows = appendFlows(flows, assignment.Target, assignment.Origins)
+	}
+
+	var relation *nodes.RangeVar
+	switch node := node.(type) {
+	case nodes.CreateTableAsStmt:
+		if node.Into != nil {
+			relation = node.Into.Rel
+		}
+	case nodes.ViewStmt:
+		relation = node.View
+	}
+	if relation == nil || relation.Relname == nil {
+		return flows
+	}
+	schema := ""
+	if relation.Schemaname != nil {
+		schema = *relation.Schemaname
+	} else {
+		for _, name := range c.SearchPath {
+			if c.Schema(name) != nil {
+				schema = name
+				break
+			}
+		}
+	}
+	for _, column := range result.Columns {
+		target := resolve.Column{Schema: schema, Table: *relation.Relname, Column: column.Name}
+		flows = appendFlows(flows, target, column.Origins)
+	}
+	return flows
+}
+
+func appendFlows(flows []Flow, target resolve.Column, origins []resolve.Origin) []Flow {
+	for _, origin := range origins {
+		kind := KindTransformation
+		if origin.Direct {
+			kind = KindCopy
+		}
+		flows = append(flows, Flow{Target: target, Source: origin.Column, Kind: kind})
+	}
+	return flows
+}
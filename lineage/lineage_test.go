@@ -0,0 +1,170 @@
+package lineage_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	"github.com/tomaszjonak/pg_query_go/lineage"
+)
+
+const schema = `
+CREATE TABLE users (id serial PRIMARY KEY, name text NOT NULL, email text);
+CREATE TABLE orders (id serial PRIMARY KEY, user_id int REFERENCES users, total numeric);
+CREATE TABLE archive (id int PRIMARY KEY, label text, amount numeric);
+CREATE VIEW emails AS SELECT id, upper(email) AS e FROM users;
+`
+
+var extractTests = []struct {
+	input string
+	flows []string
+}{
+	{
+		"INSERT INTO archive (id, label) SELECT id, upper(name) FROM users",
+		[]string{
+			"public.users.id -> public.archive.id (copy)",
+			"public.users.name -> public.archive.label (transformation)",
+		},
+	},
+	{
+		"INSERT INTO archive SELECT o.id, u.name, sum(o.total) FROM orders o JOIN users u ON u.id = o.user_id GROUP BY 1, 2",
+		[]string{
+			"public.orders.id -> public.archive.id (copy)",
+			"public.users.name -> public.archive.label (copy)",
+			"public.orders.total -> public.archive.amount (transformation)",
+		},
+	},
+	{
+		"INSERT INTO archive (id, label) WITH x AS (SELECT id, email FROM users) SELECT * FROM x UNION SELECT id, 'none' FROM orders",
+		[]string{
+			"public.users.id -> public.archive.id (copy)",
+			"public.orders.id -> public.archive.id (copy)",
+			"public.users.email -> public.archive.label (copy)",
+		},
+	},
+	{
+		"INSERT INTO archive (id, label) SELECT id, e FROM emails",
+		[]string{
+			"public.users.id -> public.archive.id (copy)",
+			"public.users.email -> public.archive.label (transformation)",
+		},
+	},
+	{
+		"INSERT INTO archive (id, label) VALUES (1, (SELECT name FROM users LIMIT 1))",
+		[]string{"public.users.name -> public.archive.label (copy)"},
+	},
+	{
+		"INSERT INTO archive (id, amount) SELECT id, total FROM orders ON CONFLICT (id) DO UPDATE SET amount = excluded.amount + archive.amount",
+		[]string{
+			"public.orders.id -> public.archive.id (copy)",
+			"public.orders.total -> public.archive.amount (copy)",
+			"public.orders.total -> public.archive.amount (transformation)",
+			"public.archive.amount -> public.archive.amount (transformation)",
+		},
+	},
+	{
+		"UPDATE archive SET label = u.email, amount = amount * 2 FROM users u WHERE u.id = archive.id",
+		[]string{
+			"public.users.email -> public.archive.label (copy)",
+			"public.archive.amount -> public.archive.amount (transformation)",
+		},
+	},
+	{
+		"UPDATE archive SET (label, amount) = (SELECT name, id FROM users WHERE users.id = archive.id)",
+		[]string{
+			"public.users.name -> public.archive.label (copy)",
+			"public.users.id -> public.archive.amount (copy)",
+		},
+	},
+	{
+		"CREATE TABLE t AS SELECT id AS user_id, coalesce(email, name) AS contact FROM users",
+		[]string{
+			"public.users.id -> public.t.user_id (copy)",
+			"public.users.email -> public.t.contact (transformation)",
+			"public.users.name -> public.t.contact (transformation)",
+		},
+	},
+	{
+		"CREATE VIEW v (a) AS SELECT sub.n FROM (SELECT length(name) AS n FROM users) sub",
+		[]string{"public.users.name -> public.v.a (transformation)"},
+	},
+	{
+		"WITH moved AS (DELETE FROM orders RETURNING id, total) INSERT INTO archive (id, amount) SELECT id, total FROM moved",
+		[]string{
+			"public.orders.id -> public.archive.id (copy)",
+			"public.orders.total -> public.archive.amount (copy)",
+		},
+	},
+	{
+		"INSERT INTO archive (id, label) SELECT id, missing FROM users",
+		[]string{"public.users.id -> public.archive.id (copy)"},
+	},
+	{
+		"SELECT name FROM users",
+		[]string{},
+	},
+}
+
+func TestExtract(t *testing.T) {
+	c := catalog.New()
+	if err := c.Apply(schema); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range extractTests {
+		flows, err := lineage.Extract(c, test.input)
+		if err != nil {
+			t.Errorf("Extract(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		actual := []string{}
+		for _, flow := range flows[0] {
+			actual = append(actual, flow.String())
+		}
+		if !reflect.DeepEqual(actual, test.flows) {
+			t.Errorf("Extract(%s)\ngot %#v\nexpected %#v\n\n", test.input, actual, test.flows)
+		}
+	}
+}
+
+var extractViewTests = []struct {
+	schema string
+	input  string
+	flows  []string
+}{
+	{
+		"CREATE TABLE t (a int); CREATE TABLE d (x int); CREATE VIEW v AS SELECT a, a + 1 AS b FROM t; ALTER TABLE t RENAME a TO c",
+		"INSERT INTO d SELECT b FROM v",
+		[]string{"public.t.c -> public.d.x (transformation)"},
+	},
+	{
+		"CREATE TABLE t (a int); CREATE TABLE d (x int); CREATE VIEW v AS SELECT a FROM t; ALTER TABLE t RENAME TO u; CREATE TABLE t (a int)",
+		"INSERT INTO d SELECT a FROM v",
+		[]string{"public.u.a -> public.d.x (copy)"},
+	},
+	{
+		"CREATE SCHEMA s; CREATE TABLE s.t (a int); CREATE TABLE t (a int); CREATE TABLE d (x int); SET search_path = s, public; CREATE VIEW public.v AS SELECT a FROM t; RESET search_path",
+		"INSERT INTO d SELECT a FROM v",
+		[]string{"s.t.a -> public.d.x (copy)"},
+	},
+}
+
+func TestExtractView(t *testing.T) {
+	for _, test := range extractViewTests {
+		c := catalog.New()
+		if err := c.Apply(test.schema); err != nil {
+			t.Fatal(err)
+		}
+		flows, err := lineage.Extract(c, test.input)
+		if err != nil {
+			t.Errorf("Extract(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		actual := []string{}
+		for _, flow := range flows[0] {
+			actual = append(actual, flow.String())
+		}
+		if !reflect.DeepEqual(actual, test.flows) {
+			t.Errorf("Extract(%s) after %s\ngot %#v\nexpected %#v\n\n", test.input, test.schema, actual, test.flows)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package lint checks migrations for statements that are unsafe to run against
+// a database in use, e.g. because they hold strong locks for a long time or
+// break the application code that is still running.
+package lint
+
+import (
+	"sort"
+
+	pg_query "github.com/tomaszjonak/pg_query_go"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// Severity tells how likely a finding is to cause problems
+type Severity int
+
+const (
+	// Worth a look, but usually fine
+	SeverityInfo Severity = iota
+	// Can block or break the application depending on table size and usage
+	SeverityWarning
+	// Almost certainly a mistake on a database in use
+	SeverityError
+)
+
+func (severity Severity) String() string {
+	switch severity {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// Finding is a problem found by a rule
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	Message  string
+
+	// How to make the change safely
+	Fix string
+
+	// Index of the statement in the input
+	Statement int
+
+	// Byte offset of the offending node within the input, or of its statement
+	// if the node doesn't have a location
+	Location int
+}
+
+// Rule checks a statement for a dangerous pattern. Check returns the findings
+// with Message, Fix and Location set, using a Location of -1 for the location
+// of the statement; the linter fills in the rest.
+type Rule struct {
+	ID       string
+	Severity Severity
+	Check    func(stmt Statement) []Finding
+}
+
+// Statement is a statement being checked, together with what the migration
+// did before it
+type Statement struct {
+	// The statement, without its RawStmt wrapper
+	Node nodes.Node
+
+	// Byte offset of the statement within the input, right after the
+	// semicolon of the previous statement
+	Location int
+
+	newRelations map[string]bool
+}
+
+// IsNewRelation returns whether the relation was created earlier in the same
+// migration, in which case it is still empty and not in use, and locking or
+// rewriting it is harmless
+func (stmt Statement) IsNewRelation(rangeVar *nodes.RangeVar) bool {
+	return rangeVar != nil && rangeVar.Relname != nil && stmt.newRelations[relationName(*rangeVar)]
+}
+
+// Linter runs a set of rules over migrations
+type Linter struct {
+	Rules []Rule
+}
+
+// New returns a linter with the default rules and the given additional rules
+func New(rules ...Rule) Linter {
+	return Linter{Rules: append(append([]Rule{}, DefaultRules...), rules...)}
+}
+
+// Lint - Parses the given migration and checks it with the default rules
+func Lint(input string) ([]Finding, error) {
+	return New().Lint(input)
+}
+
+// Lint parses the given migration and checks each of its statements
+func (linter Linter) Lint(input string) ([]Finding, error) {
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return linter.LintTree(tree), nil
+}
+
+// LintTree checks each statement of a parsed migration. Findings are sorted
+// by location.
+func (linter Linter) LintTree(tree pg_query.ParsetreeList) []Finding {
+	findings := []Finding{}
+	newRelations := map[string]bool{}
+	for i, item := range tree.Statements {
+		stmt := Statement{newRelations: newRelations}
+		stmt.Node, stmt.Location = nodes.UnwrapRawStmt(item)
+
+		for _, rule := range linter.Rules {
+			for _, finding := range rule.Check(stmt) {
+				finding.RuleID = rule.ID
+				finding.Severity = rule.Severity
+				finding.Statement = i
+				if finding.Location < 0 {
+					finding.Location = stmt.Location
+				}
+				findings = append(findings, finding)
+			}
+		}
+
+		switch node := stmt.Node.(type) {
+		case nodes.CreateStmt:
+			if node.Relation != nil && node.Relation.Relname != nil {
+				newRelations[relationName(*node.Relation)] = true
+			}
+		case nodes.CreateTableAsStmt:
+			if node.Into != nil && node.Into.Rel != nil && node.Into.Rel.Relname != nil {
+				newRelations[relationName(*node.Into.Rel)] = true
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Location < findings[j].Location
+	})
+	return findings
+}
+
+func relationName(rangeVar nodes.RangeVar) string {
+	name := *rangeVar.Relname
+	if rangeVar.Schemaname != nil {
+		name = *rangeVar.Schemaname + "." + name
+	}
+	return name
+}
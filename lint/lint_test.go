@@ -0,0 +1,113 @@
+package lint_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go/lint"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+var lintTests = []struct {
+	input   string
+	ruleIDs []string
+}{
+	{"ALTER TABLE t ADD COLUMN a int", nil},
+	{"ALTER TABLE t ADD COLUMN a int DEFAULT 0", []string{"add-column-default"}},
+	{"ALTER TABLE t ADD COLUMN a int DEFAULT NULL", nil},
+	{"ALTER TABLE t ADD COLUMN a int NOT NULL DEFAULT 0", []string{"add-column-default"}},
+	{"ALTER TABLE t ADD COLUMN a int NOT NULL", []string{"add-column-not-null"}},
+	{"ALTER TABLE t ALTER COLUMN a TYPE bigint", []string{"alter-column-type"}},
+	{"ALTER TABLE t ADD CONSTRAINT c CHECK (a > 0)", []string{"constraint-not-valid"}},
+	{"ALTER TABLE t ADD CONSTRAINT c CHECK (a > 0) NOT VALID", nil},
+	{"ALTER TABLE t ADD CONSTRAINT f FOREIGN KEY (a) REFERENCES u (b)", []string{"constraint-not-valid"}},
+	{"ALTER TABLE t ADD CONSTRAINT f FOREIGN KEY (a) REFERENCES u (b) NOT VALID", nil},
+	{"ALTER TABLE t ADD PRIMARY KEY (a)", []string{"constraint-not-valid"}},
+	{"ALTER TABLE t ADD CONSTRAINT p PRIMARY KEY USING INDEX i", nil},
+	{"ALTER TABLE t VALIDATE CONSTRAINT c", nil},
+	{"CREATE INDEX i ON t (a)", []string{"create-index-concurrently"}},
+	{"CREATE INDEX CONCURRENTLY i ON t (a)", nil},
+	{"ALTER TABLE t DROP COLUMN a", []string{"drop-column"}},
+	{"ALTER TABLE t RENAME COLUMN a TO b", []string{"rename"}},
+	{"ALTER TABLE t RENAME TO u", []string{"rename"}},
+	{"ALTER INDEX i RENAME TO j", nil},
+	{"UPDATE t SET a = 1", []string{"unbounded-dml"}},
+	{"UPDATE t SET a = 1 WHERE b = 2", nil},
+	{"DELETE FROM t", []string{"unbounded-dml"}},
+	{"DELETE FROM t WHERE a = 1", nil},
+	{"ALTER TABLE t ADD COLUMN a int NOT NULL, DROP COLUMN b", []string{"drop-column", "add-column-not-null"}},
+	{"CREATE TABLE t (a int); CREATE INDEX i ON t (a); ALTER TABLE t ADD COLUMN b int NOT NULL DEFAULT 0", nil},
+	{"CREATE TABLE s.t (a int); CREATE INDEX i ON t (a)", []string{"create-index-concurrently"}},
+}
+
+func TestLint(t *testing.T) {
+	for _, test := range lintTests {
+		findings, err := lint.Lint(test.input)
+		if err != nil {
+			t.Errorf("Lint(%s): %s", test.input, err)
+			continue
+		}
+		var ruleIDs []string
+		for _, finding := range findings {
+			ruleIDs = append(ruleIDs, finding.RuleID)
+		}
+		if !reflect.DeepEqual(ruleIDs, test.ruleIDs) {
+			t.Errorf("Lint(%s): expected %v, got %v", test.input, test.ruleIDs, ruleIDs)
+		}
+	}
+}
+
+func TestLintFinding(t *testing.T) {
+	findings, err := lint.Lint("SELECT 1;\nALTER TABLE t ADD COLUMN a int NOT NULL DEFAULT 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []lint.Finding{{
+		RuleID:    "add-column-default",
+		Severity:  lint.SeverityError,
+		Message:   "Adding a column with a default rewrites the table while blocking reads and writes",
+		Fix:       "Add the column without a default, set the default with ALTER COLUMN ... SET DEFAULT, then backfill existing rows in batches",
+		Statement: 1,
+		Location:  50,
+	}}
+	if !reflect.DeepEqual(findings, expected) {
+		t.Errorf("expected %+v, got %+v", expected, findings)
+	}
+
+	findings, err = lint.Lint("SELECT 1; DELETE FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].Location != 9 || findings[0].Severity.String() != "error" {
+		t.Errorf("expected an error at the start of the statement, got %+v", findings)
+	}
+}
+
+func TestLintCustomRule(t *testing.T) {
+	noTruncate := lint.Rule{
+		ID:       "no-truncate",
+		Severity: lint.SeverityError,
+		Check: func(stmt lint.Statement) []lint.Finding {
+			if _, ok := stmt.Node.(nodes.TruncateStmt); ok {
+				return []lint.Finding{{Message: "TRUNCATE is not allowed", Fix: "Delete rows in batches", Location: -1}}
+			}
+			return nil
+		},
+	}
+
+	findings, err := lint.New(noTruncate).Lint("TRUNCATE t; DELETE FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 2 || findings[0].RuleID != "no-truncate" || findings[1].RuleID != "unbounded-dml" {
+		t.Errorf("unexpected findings %+v", findings)
+	}
+
+	findings, err = lint.Linter{Rules: []lint.Rule{noTruncate}}.Lint("DELETE FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected only the custom rule to run, got %+v", findings)
+	}
+}
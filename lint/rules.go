@@ -0,0 +1,260 @@
+package lint
+
+import (
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// DefaultRules are the rules used by Lint and New
+var DefaultRules = []Rule{
+	AddColumnDefault,
+	AddColumnNotNull,
+	AlterColumnType,
+	ConstraintNotValid,
+	CreateIndexConcurrently,
+	DropColumn,
+	Rename,
+	UnboundedDML,
+}
+
+// AddColumnDefault flags columns added with a default, which makes PostgreSQL
+// 10 rewrite the whole table while holding an ACCESS EXCLUSIVE lock
+var AddColumnDefault = Rule{
+	ID:       "add-column-default",
+	Severity: SeverityError,
+	Check: func(stmt Statement) (findings []Finding) {
+		forEachAlterTableCmd(stmt, func(cmd nodes.AlterTableCmd) {
+			if cmd.Subtype != nodes.AT_AddColumn {
+				return
+			}
+			columnDef, ok := cmd.Def.(nodes.ColumnDef)
+			if !ok {
+				return
+			}
+			for _, constraint := range columnConstraints(columnDef) {
+				if constraint.Contype == nodes.CONSTR_DEFAULT && !isNullConst(constraint.RawExpr) {
+					findings = append(findings, Finding{
+						Message:  "Adding a column with a default rewrites the table while blocking reads and writes",
+						Fix:      "Add the column without a default, set the default with ALTER COLUMN ... SET DEFAULT, then backfill existing rows in batches",
+						Location: constraint.Location,
+					})
+				}
+			}
+		})
+		return
+	},
+}
+
+// AddColumnNotNull flags NOT NULL columns added without a default, which fail
+// on tables that have rows
+var AddColumnNotNull = Rule{
+	ID:       "add-column-not-null",
+	Severity: SeverityError,
+	Check: func(stmt Statement) (findings []Finding) {
+		forEachAlterTableCmd(stmt, func(cmd nodes.AlterTableCmd) {
+			if cmd.Subtype != nodes.AT_AddColumn {
+				return
+			}
+			columnDef, ok := cmd.Def.(nodes.ColumnDef)
+			if !ok {
+				return
+			}
+			var notNull *nodes.Constraint
+			for _, constraint := range columnConstraints(columnDef) {
+				switch constraint.Contype {
+				case nodes.CONSTR_DEFAULT:
+					if !isNullConst(constraint.RawExpr) {
+						return
+					}
+				case nodes.CONSTR_NOTNULL, nodes.CONSTR_PRIMARY:
+					constraint := constraint
+					notNull = &constraint
+				}
+			}
+			if notNull != nil {
+				findings = append(findings, Finding{
+					Message:  "Adding a NOT NULL column without a default fails if the table has rows",
+					Fix:      "Add the column as nullable, backfill it in batches, then add a NOT NULL constraint",
+					Location: notNull.Location,
+				})
+			}
+		})
+		return
+	},
+}
+
+// AlterColumnType flags column type changes, which rewrite the table and its
+// indexes while holding an ACCESS EXCLUSIVE lock
+var AlterColumnType = Rule{
+	ID:       "alter-column-type",
+	Severity: SeverityWarning,
+	Check: func(stmt Statement) (findings []Finding) {
+		forEachAlterTableCmd(stmt, func(cmd nodes.AlterTableCmd) {
+			if cmd.Subtype == nodes.AT_AlterColumnType {
+				findings = append(findings, Finding{
+					Message:  "Changing the type of a column can rewrite the table while blocking reads and writes",
+					Fix:      "Add a column with the new type, backfill it in batches and switch the application over to it before dropping the old column",
+					Location: nodes.Location(cmd.Def),
+				})
+			}
+		})
+		return
+	},
+}
+
+// ConstraintNotValid flags constraints whose existing rows are checked while
+// the table is locked
+var ConstraintNotValid = Rule{
+	ID:       "constraint-not-valid",
+	Severity: SeverityWarning,
+	Check: func(stmt Statement) (findings []Finding) {
+		forEachAlterTableCmd(stmt, func(cmd nodes.AlterTableCmd) {
+			if cmd.Subtype != nodes.AT_AddConstraint {
+				return
+			}
+			constraint, ok := cmd.Def.(nodes.Constraint)
+			if !ok {
+				return
+			}
+			switch constraint.Contype {
+			case nodes.CONSTR_CHECK, nodes.CONSTR_FOREIGN:
+				if !constraint.SkipValidation {
+					findings = append(findings, Finding{
+						Message:  "Adding a constraint scans the whole table while blocking writes",
+						Fix:      "Add the constraint with NOT VALID, then run ALTER TABLE ... VALIDATE CONSTRAINT in a separate transaction",
+						Location: constraint.Location,
+					})
+				}
+			case nodes.CONSTR_PRIMARY, nodes.CONSTR_UNIQUE:
+				if constraint.Indexname == nil {
+					findings = append(findings, Finding{
+						Message:  "Adding a primary key or unique constraint builds an index while blocking reads and writes",
+						Fix:      "Create a unique index with CREATE UNIQUE INDEX CONCURRENTLY, then add the constraint with USING INDEX",
+						Location: constraint.Location,
+					})
+				}
+			}
+		})
+		return
+	},
+}
+
+// CreateIndexConcurrently flags indexes built without CONCURRENTLY, which
+// blocks writes to the table for the whole build
+var CreateIndexConcurrently = Rule{
+	ID:       "create-index-concurrently",
+	Severity: SeverityWarning,
+	Check: func(stmt Statement) []Finding {
+		indexStmt, ok := stmt.Node.(nodes.IndexStmt)
+		if !ok || indexStmt.Concurrent || stmt.IsNewRelation(indexStmt.Relation) {
+			return nil
+		}
+		return []Finding{{
+			Message:  "Creating an index blocks writes to the table until the index is built",
+			Fix:      "Use CREATE INDEX CONCURRENTLY, outside of a transaction block",
+			Location: -1,
+		}}
+	},
+}
+
+// DropColumn flags dropped columns, which break application code that still
+// uses them
+var DropColumn = Rule{
+	ID:       "drop-column",
+	Severity: SeverityWarning,
+	Check: func(stmt Statement) (findings []Finding) {
+		forEachAlterTableCmd(stmt, func(cmd nodes.AlterTableCmd) {
+			if cmd.Subtype == nodes.AT_DropColumn {
+				findings = append(findings, Finding{
+					Message:  "Dropping a column breaks application code that still reads or writes it",
+					Fix:      "Deploy application code that no longer uses the column before dropping it",
+					Location: -1,
+				})
+			}
+		})
+		return
+	},
+}
+
+// Rename flags renamed tables, views and columns, which break application code
+// that uses the old name
+var Rename = Rule{
+	ID:       "rename",
+	Severity: SeverityWarning,
+	Check: func(stmt Statement) []Finding {
+		renameStmt, ok := stmt.Node.(nodes.RenameStmt)
+		if !ok || stmt.IsNewRelation(renameStmt.Relation) {
+			return nil
+		}
+		switch renameStmt.RenameType {
+		case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW, nodes.OBJECT_FOREIGN_TABLE, nodes.OBJECT_COLUMN:
+		default:
+			return nil
+		}
+		return []Finding{{
+			Message:  "Renaming breaks application code that still uses the old name",
+			Fix:      "Add the new table or column alongside the old one and migrate the application to it, or keep a view with the old name",
+			Location: -1,
+		}}
+	},
+}
+
+// UnboundedDML flags UPDATE and DELETE statements without WHERE, which touch
+// every row of the table
+var UnboundedDML = Rule{
+	ID:       "unbounded-dml",
+	Severity: SeverityError,
+	Check: func(stmt Statement) []Finding {
+		switch node := stmt.Node.(type) {
+		case nodes.UpdateStmt:
+			if node.WhereClause == nil {
+				return []Finding{{
+					Message:  "UPDATE without WHERE changes every row of the table",
+					Fix:      "Add a WHERE clause, and update large tables in batches",
+					Location: -1,
+				}}
+			}
+		case nodes.DeleteStmt:
+			if node.WhereClause == nil {
+				return []Finding{{
+					Message:  "DELETE without WHERE removes every row of the table",
+					Fix:      "Add a WHERE clause, or use TRUNCATE if emptying the table is intended",
+					Location: -1,
+				}}
+			}
+		}
+		return nil
+	},
+}
+
+// forEachAlterTableCmd calls fn for every subcommand of an ALTER TABLE
+// statement on a relation that wasn't created earlier in the migration
+func forEachAlterTableCmd(stmt Statement, fn func(cmd nodes.AlterTableCmd)) {
+	alterTableStmt, ok := stmt.Node.(nodes.AlterTableStmt)
+	if !ok || stmt.IsNewRelation(alterTableStmt.Relation) {
+		return
+	}
+	for _, item := range alterTableStmt.Cmds.Items {
+		if cmd, ok := item.(nodes.AlterTableCmd); ok {
+			fn(cmd)
+		}
+	}
+}
+
+func columnConstraints(columnDef nodes.ColumnDef) []nodes.Constraint {
+	constraints := []nodes.Constraint{}
+	for _, item := range columnDef.Constraints.Items {
+		if constraint, ok := item.(nodes.Constraint); ok {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints
+}
+
+func isNullConst(node nodes.Node) bool {
+	aConst, ok := node.(nodes.A_Const)
+	if !ok {
+		return false
+	}
+	_, ok = aConst.Val.(nodes.Null)
+	return ok
+}
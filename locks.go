@@ -0,0 +1,436 @@
+package pg_query
+
+import (
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// LockMode is a PostgreSQL table-level lock mode, in order of strength. The
+// values match LockStmt.Mode.
+type LockMode int
+
+const (
+	NoLock LockMode = iota
+	AccessShareLock
+	RowShareLock
+	RowExclusiveLock
+	ShareUpdateExclusiveLock
+	ShareLock
+	ShareRowExclusiveLock
+	ExclusiveLock
+	AccessExclusiveLock
+)
+
+func (mode LockMode) String() string {
+	if mode < AccessShareLock || int(mode) >= len(lockModeNames) {
+		return "NO LOCK"
+	}
+	return lockModeNames[mode]
+}
+
+// RelationLock is a lock a statement takes on a relation
+type RelationLock struct {
+	// Name of the relation as written in the statement, e.g. "public.users"
+	Relation string
+	Mode     LockMode
+}
+
+// Locks - Parses the given SQL and returns the locks each of its statements
+// takes on relations
+func Locks(input string) (locks [][]RelationLock, err error) {
+	tree, err := Parse(input)
+	if err != nil {
+		return
+	}
+	return tree.Locks(), nil
+}
+
+// Locks returns the table-level locks each statement of the tree takes on the
+// relations it names, following the rules of PostgreSQL 10. Every relation is
+// listed once with the strongest mode taken on it, in order of appearance.
+// Locks taken on relations that aren't named in the statement, e.g. the
+// tables of a view or the indexes of a table, aren't included.
+func (input ParsetreeList) Locks() [][]RelationLock {
+	locks := make([][]RelationLock, len(input.Statements))
+	for i, stmt := range input.Statements {
+		stmt, _ = nodes.UnwrapRawStmt(stmt)
+		collector := lockCollector{}
+		collector.statement(stmt)
+		locks[i] = collector.locks
+	}
+	return locks
+}
+
+// Relation options that need an ACCESS EXCLUSIVE lock to be changed, all
+// others only need SHARE UPDATE EXCLUSIVE
+var accessExclusiveRelOptions = map[string]bool{
+	"autosummarize":          true,
+	"buffering":              true,
+	"check_option":           true,
+	"fastupdate":             true,
+	"gin_pending_list_limit": true,
+	"pages_per_range":        true,
+	"security_barrier":       true,
+	"user_catalog_table":     true,
+}
+
+type lockCollector struct {
+	locks []RelationLock
+}
+
+// add records a lock on the relation, keeping the strongest mode if the
+// relation is already locked
+func (c *lockCollector) add(relation string, mode LockMode) {
+	for i, lock := range c.locks {
+		if lock.Relation == relation {
+			if mode > lock.Mode {
+				c.locks[i].Mode = mode
+			}
+			return
+		}
+	}
+	c.locks = append(c.locks, RelationLock{Relation: relation, Mode: mode})
+}
+
+func (c *lockCollector) addRangeVar(rangeVar *nodes.RangeVar, mode LockMode) {
+	if rangeVar != nil && rangeVar.Relname != nil {
+		c.add(rangeVarName(*rangeVar), mode)
+	}
+}
+
+// addNames records a lock on a relation given as a list of String nodes, as
+// used by DROP
+func (c *lockCollector) addNames(names []nodes.Node, mode LockMode) {
+	parts := []string{}
+	for _, item := range names {
+		if str, ok := item.(nodes.String); ok {
+			parts = append(parts, str.Str)
+		}
+	}
+	if len(parts) > 0 {
+		c.add(strings.Join(parts, "."), mode)
+	}
+}
+
+func rangeVarName(rangeVar nodes.RangeVar) string {
+	name := *rangeVar.Relname
+	if rangeVar.Schemaname != nil {
+		name = *rangeVar.Schemaname + "." + name
+	}
+	if rangeVar.Catalogname != nil {
+		name = *rangeVar.Catalogname + "." + name
+	}
+	return name
+}
+
+func (c *lockCollector) statement(node nodes.Node) {
+	switch node := node.(type) {
+	case nodes.SelectStmt, nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt:
+		c.query(node)
+	case nodes.CopyStmt:
+		if node.IsFrom {
+			c.addRangeVar(node.Relation, RowExclusiveLock)
+		} else {
+			c.addRangeVar(node.Relation, AccessShareLock)
+		}
+		c.query(node.Query)
+	case nodes.ExplainStmt:
+		// Planning the statement takes the same locks as running it
+		c.statement(node.Query)
+	case nodes.DeclareCursorStmt:
+		c.query(node.Query)
+	case nodes.CreateTableAsStmt:
+		c.query(node.Query)
+	case nodes.LockStmt:
+		for _, item := range node.Relations.Items {
+			if rangeVar, ok := item.(nodes.RangeVar); ok {
+				c.addRangeVar(&rangeVar, LockMode(node.Mode))
+			}
+		}
+	case nodes.TruncateStmt:
+		for _, item := range node.Relations.Items {
+			if rangeVar, ok := item.(nodes.RangeVar); ok {
+				c.addRangeVar(&rangeVar, AccessExclusiveLock)
+			}
+		}
+	case nodes.VacuumStmt:
+		if node.Options&int(nodes.VACOPT_FULL) != 0 {
+			c.addRangeVar(node.Relation, AccessExclusiveLock)
+		} else {
+			c.addRangeVar(node.Relation, ShareUpdateExclusiveLock)
+		}
+	case nodes.IndexStmt:
+		if node.Concurrent {
+			c.addRangeVar(node.Relation, ShareUpdateExclusiveLock)
+		} else {
+			c.addRangeVar(node.Relation, ShareLock)
+		}
+	case nodes.ReindexStmt:
+		switch node.Kind {
+		case nodes.REINDEX_OBJECT_INDEX:
+			c.addRangeVar(node.Relation, AccessExclusiveLock)
+		case nodes.REINDEX_OBJECT_TABLE:
+			c.addRangeVar(node.Relation, ShareLock)
+		}
+	case nodes.ClusterStmt:
+		c.addRangeVar(node.Relation, AccessExclusiveLock)
+	case nodes.RefreshMatViewStmt:
+		if node.Concurrent {
+			c.addRangeVar(node.Relation, ExclusiveLock)
+		} else {
+			c.addRangeVar(node.Relation, AccessExclusiveLock)
+		}
+	case nodes.CreateTrigStmt:
+		c.addRangeVar(node.Relation, ShareRowExclusiveLock)
+	case nodes.RuleStmt:
+		c.addRangeVar(node.Relation, AccessExclusiveLock)
+	case nodes.CreatePolicyStmt:
+		c.addRangeVar(node.Table, AccessExclusiveLock)
+	case nodes.AlterPolicyStmt:
+		c.addRangeVar(node.Table, AccessExclusiveLock)
+	case nodes.RenameStmt:
+		c.addRangeVar(node.Relation, AccessExclusiveLock)
+	case nodes.DropStmt:
+		c.dropStmt(node)
+	case nodes.AlterTableStmt:
+		c.alterTableStmt(node)
+	case nodes.CreateStmt:
+		c.createStmt(node)
+	}
+}
+
+// createStmt records the locks CREATE TABLE takes on existing tables: the
+// tables its foreign keys reference and the tables it inherits from. The new
+// table itself isn't visible to other sessions yet.
+func (c *lockCollector) createStmt(node nodes.CreateStmt) {
+	for _, item := range node.InhRelations.Items {
+		if parent, ok := item.(nodes.RangeVar); ok {
+			if node.Partbound != nil {
+				c.addRangeVar(&parent, AccessExclusiveLock)
+			} else {
+				c.addRangeVar(&parent, ShareUpdateExclusiveLock)
+			}
+		}
+	}
+	for _, item := range node.TableElts.Items {
+		switch item := item.(type) {
+		case nodes.ColumnDef:
+			c.foreignKeys(item.Constraints.Items)
+		case nodes.Constraint:
+			c.foreignKeys([]nodes.Node{item})
+		}
+	}
+}
+
+// foreignKeys records the SHARE ROW EXCLUSIVE locks taken on the tables the
+// foreign keys among the constraints reference
+func (c *lockCollector) foreignKeys(constraints []nodes.Node) {
+	for _, item := range constraints {
+		if constraint, ok := item.(nodes.Constraint); ok && constraint.Contype == nodes.CONSTR_FOREIGN {
+			c.addRangeVar(constraint.Pktable, ShareRowExclusiveLock)
+		}
+	}
+}
+
+func (c *lockCollector) dropStmt(node nodes.DropStmt) {
+	for _, item := range node.Objects.Items {
+		names, ok := item.(nodes.List)
+		if !ok {
+			continue
+		}
+		switch node.RemoveType {
+		case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW, nodes.OBJECT_SEQUENCE, nodes.OBJECT_FOREIGN_TABLE:
+			c.addNames(names.Items, AccessExclusiveLock)
+		case nodes.OBJECT_INDEX:
+			if node.Concurrent {
+				c.addNames(names.Items, ShareUpdateExclusiveLock)
+			} else {
+				c.addNames(names.Items, AccessExclusiveLock)
+			}
+		case nodes.OBJECT_TRIGGER, nodes.OBJECT_RULE, nodes.OBJECT_POLICY:
+			// The last name is the object, the others name its table
+			if len(names.Items) > 1 {
+				c.addNames(names.Items[:len(names.Items)-1], AccessExclusiveLock)
+			}
+		}
+	}
+}
+
+// alterTableStmt locks the table with the strongest mode needed by any of
+// its subcommands, see AlterTableGetLockLevel in PostgreSQL's tablecmds.c
+func (c *lockCollector) alterTableStmt(node nodes.AlterTableStmt) {
+	if node.Relation == nil || node.Relation.Relname == nil {
+		return
+	}
+	relation := rangeVarName(*node.Relation)
+	mode := NoLock
+	for _, item := range node.Cmds.Items {
+		cmd, ok := item.(nodes.AlterTableCmd)
+		if !ok {
+			continue
+		}
+		if cmdMode := c.alterTableCmd(cmd); cmdMode > mode {
+			mode = cmdMode
+		}
+	}
+	if mode == NoLock {
+		mode = AccessExclusiveLock
+	}
+
+	// Keep the altered table first, ahead of the tables its subcommands refer to
+	locks := c.locks
+	c.locks = nil
+	c.add(relation, mode)
+	for _, lock := range locks {
+		c.add(lock.Relation, lock.Mode)
+	}
+}
+
+// alterTableCmd returns the lock an ALTER TABLE subcommand needs on the
+// altered table, and records the locks it takes on other tables
+func (c *lockCollector) alterTableCmd(cmd nodes.AlterTableCmd) LockMode {
+	switch cmd.Subtype {
+	case nodes.AT_SetStatistics,
+		nodes.AT_ClusterOn,
+		nodes.AT_DropCluster,
+		nodes.AT_SetOptions,
+		nodes.AT_ResetOptions,
+		nodes.AT_ValidateConstraint,
+		nodes.AT_ValidateConstraintRecurse:
+		return ShareUpdateExclusiveLock
+	case nodes.AT_EnableTrig,
+		nodes.AT_EnableAlwaysTrig,
+		nodes.AT_EnableReplicaTrig,
+		nodes.AT_EnableTrigAll,
+		nodes.AT_EnableTrigUser,
+		nodes.AT_DisableTrig,
+		nodes.AT_DisableTrigAll,
+		nodes.AT_DisableTrigUser:
+		return ShareRowExclusiveLock
+	case nodes.AT_SetRelOptions, nodes.AT_ResetRelOptions, nodes.AT_ReplaceRelOptions:
+		options, _ := cmd.Def.(nodes.List)
+		for _, item := range options.Items {
+			if defElem, ok := item.(nodes.DefElem); ok && defElem.Defname != nil && accessExclusiveRelOptions[*defElem.Defname] {
+				return AccessExclusiveLock
+			}
+		}
+		return ShareUpdateExclusiveLock
+	case nodes.AT_AddConstraint, nodes.AT_AddConstraintRecurse:
+		if constraint, ok := cmd.Def.(nodes.Constraint); ok && constraint.Contype == nodes.CONSTR_FOREIGN {
+			// Adding a foreign key only blocks writes, on both tables
+			c.addRangeVar(constraint.Pktable, ShareRowExclusiveLock)
+			return ShareRowExclusiveLock
+		}
+	case nodes.AT_AddColumn, nodes.AT_AddColumnRecurse:
+		if columnDef, ok := cmd.Def.(nodes.ColumnDef); ok {
+			c.foreignKeys(columnDef.Constraints.Items)
+		}
+	case nodes.AT_AddInherit:
+		if parent, ok := cmd.Def.(nodes.RangeVar); ok {
+			c.addRangeVar(&parent, ShareUpdateExclusiveLock)
+		}
+	case nodes.AT_AttachPartition, nodes.AT_DetachPartition:
+		if partitionCmd, ok := cmd.Def.(nodes.PartitionCmd); ok {
+			c.addRangeVar(partitionCmd.Name, AccessExclusiveLock)
+		}
+	}
+	return AccessExclusiveLock
+}
+
+// query records the locks taken by a SELECT or DML statement: ACCESS SHARE
+// on every relation it reads, ROW SHARE on relations locked by FOR UPDATE or
+// FOR SHARE and ROW EXCLUSIVE on the targets of INSERT, UPDATE and DELETE,
+// including those in WITH clauses
+func (c *lockCollector) query(node nodes.Node) {
+	cteNames := map[string]bool{}
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		if cte, ok := node.(nodes.CommonTableExpr); ok && cte.Ctename != nil {
+			cteNames[*cte.Ctename] = true
+		}
+		return true
+	})
+
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		switch node := node.(type) {
+		case nodes.InsertStmt:
+			c.addRangeVar(node.Relation, RowExclusiveLock)
+		case nodes.UpdateStmt:
+			c.addRangeVar(node.Relation, RowExclusiveLock)
+		case nodes.DeleteStmt:
+			c.addRangeVar(node.Relation, RowExclusiveLock)
+		case nodes.SelectStmt:
+			c.lockingClauses(node, cteNames)
+		case nodes.IntoClause, nodes.LockingClause:
+			// The target of SELECT INTO is created, and the relations of
+			// locking clauses are references to the FROM clause
+			return false
+		case nodes.RangeVar:
+			if node.Relname != nil && (node.Schemaname != nil || !cteNames[*node.Relname]) {
+				c.addRangeVar(&node, AccessShareLock)
+			}
+		}
+		return true
+	})
+}
+
+// lockingClauses records the ROW SHARE locks of the FOR UPDATE and FOR SHARE
+// clauses of a SELECT statement. A clause without a list of relations locks
+// every relation of the FROM clause, and locking a subquery locks every
+// relation it reads.
+func (c *lockCollector) lockingClauses(node nodes.SelectStmt, cteNames map[string]bool) {
+	for _, item := range node.LockingClause.Items {
+		lockingClause, ok := item.(nodes.LockingClause)
+		if !ok {
+			continue
+		}
+		var lockedNames map[string]bool
+		if len(lockingClause.LockedRels.Items) > 0 {
+			lockedNames = map[string]bool{}
+			for _, lockedRel := range lockingClause.LockedRels.Items {
+				if rangeVar, ok := lockedRel.(nodes.RangeVar); ok && rangeVar.Relname != nil {
+					lockedNames[*rangeVar.Relname] = true
+				}
+			}
+		}
+		for _, fromItem := range node.FromClause.Items {
+			c.lockFromItem(fromItem, lockedNames, cteNames)
+		}
+	}
+}
+
+// lockFromItem records ROW SHARE locks on the relations of a FROM clause item
+// that are named in lockedNames, or on all of them if lockedNames is nil
+func (c *lockCollector) lockFromItem(node nodes.Node, lockedNames map[string]bool, cteNames map[string]bool) {
+	switch node := node.(type) {
+	case nodes.RangeVar:
+		if node.Relname == nil || (node.Schemaname == nil && cteNames[*node.Relname]) {
+			return
+		}
+		name := *node.Relname
+		if node.Alias != nil && node.Alias.Aliasname != nil {
+			name = *node.Alias.Aliasname
+		}
+		if lockedNames == nil || lockedNames[name] {
+			c.addRangeVar(&node, RowShareLock)
+		}
+	case nodes.JoinExpr:
+		c.lockFromItem(node.Larg, lockedNames, cteNames)
+		c.lockFromItem(node.Rarg, lockedNames, cteNames)
+	case nodes.RangeSubselect:
+		if lockedNames != nil && (node.Alias == nil || node.Alias.Aliasname == nil || !lockedNames[*node.Alias.Aliasname]) {
+			return
+		}
+		nodes.Walk(node.Subquery, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+			switch node := node.(type) {
+			case nodes.IntoClause, nodes.LockingClause:
+				return false
+			case nodes.RangeVar:
+				if node.Relname != nil && (node.Schemaname != nil || !cteNames[*node.Relname]) {
+					c.addRangeVar(&node, RowShareLock)
+				}
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package pg_query_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go"
+)
+
+type lock = pg_query.RelationLock
+
+var locksTests = []struct {
+	input string
+	locks []pg_query.RelationLock
+}{
+	{"SELECT * FROM t JOIN s.u ON t.a = u.a", []lock{{"t", pg_query.AccessShareLock}, {"s.u", pg_query.AccessShareLock}}},
+	{"SELECT * FROM t WHERE a IN (SELECT a FROM u)", []lock{{"t", pg_query.AccessShareLock}, {"u", pg_query.AccessShareLock}}},
+	{"WITH c AS (SELECT * FROM t) SELECT * FROM c", []lock{{"t", pg_query.AccessShareLock}}},
+	{"SELECT * FROM t, u FOR UPDATE", []lock{{"t", pg_query.RowShareLock}, {"u", pg_query.RowShareLock}}},
+	{"SELECT * FROM t x JOIN u ON true FOR SHARE OF x", []lock{{"t", pg_query.RowShareLock}, {"u", pg_query.AccessShareLock}}},
+	{"SELECT * FROM (SELECT * FROM t) x, u FOR UPDATE OF x", []lock{{"t", pg_query.RowShareLock}, {"u", pg_query.AccessShareLock}}},
+	{"SELECT * INTO u FROM t", []lock{{"t", pg_query.AccessShareLock}}},
+	{"INSERT INTO t SELECT * FROM u", []lock{{"t", pg_query.RowExclusiveLock}, {"u", pg_query.AccessShareLock}}},
+	{"UPDATE t SET a = u.a FROM u WHERE t.b = u.b", []lock{{"t", pg_query.RowExclusiveLock}, {"u", pg_query.AccessShareLock}}},
+	{"DELETE FROM t USING u WHERE t.a = u.a", []lock{{"t", pg_query.RowExclusiveLock}, {"u", pg_query.AccessShareLock}}},
+	{"WITH d AS (DELETE FROM t RETURNING *) INSERT INTO u SELECT * FROM d", []lock{{"u", pg_query.RowExclusiveLock}, {"t", pg_query.RowExclusiveLock}}},
+	{"EXPLAIN UPDATE t SET a = 1", []lock{{"t", pg_query.RowExclusiveLock}}},
+	{"COPY t FROM STDIN", []lock{{"t", pg_query.RowExclusiveLock}}},
+	{"COPY t TO STDOUT", []lock{{"t", pg_query.AccessShareLock}}},
+	{"LOCK TABLE t, u IN SHARE ROW EXCLUSIVE MODE", []lock{{"t", pg_query.ShareRowExclusiveLock}, {"u", pg_query.ShareRowExclusiveLock}}},
+	{"LOCK TABLE t", []lock{{"t", pg_query.AccessExclusiveLock}}},
+	{"TRUNCATE t, s.u", []lock{{"t", pg_query.AccessExclusiveLock}, {"s.u", pg_query.AccessExclusiveLock}}},
+	{"VACUUM t", []lock{{"t", pg_query.ShareUpdateExclusiveLock}}},
+	{"VACUUM FULL t", []lock{{"t", pg_query.AccessExclusiveLock}}},
+	{"ANALYZE t", []lock{{"t", pg_query.ShareUpdateExclusiveLock}}},
+	{"VACUUM", nil},
+	{"CREATE INDEX i ON t (a)", []lock{{"t", pg_query.ShareLock}}},
+	{"CREATE INDEX CONCURRENTLY i ON t (a)", []lock{{"t", pg_query.ShareUpdateExclusiveLock}}},
+	{"DROP INDEX i", []lock{{"i", pg_query.AccessExclusiveLock}}},
+	{"DROP INDEX CONCURRENTLY i", []lock{{"i", pg_query.ShareUpdateExclusiveLock}}},
+	{"DROP TABLE t, s.u", []lock{{"t", pg_query.AccessExclusiveLock}, {"s.u", pg_query.AccessExclusiveLock}}},
+	{"DROP TRIGGER tr ON t", []lock{{"t", pg_query.AccessExclusiveLock}}},
+	{"REFRESH MATERIALIZED VIEW CONCURRENTLY m", []lock{{"m", pg_query.ExclusiveLock}}},
+	{"CREATE TRIGGER tr AFTER INSERT ON t FOR EACH ROW EXECUTE PROCEDURE f()", []lock{{"t", pg_query.ShareRowExclusiveLock}}},
+	{"ALTER TABLE t RENAME COLUMN a TO b", []lock{{"t", pg_query.AccessExclusiveLock}}},
+	{"ALTER TABLE t ADD COLUMN a int", []lock{{"t", pg_query.AccessExclusiveLock}}},
+	{"ALTER TABLE t ALTER COLUMN a SET STATISTICS 100", []lock{{"t", pg_query.ShareUpdateExclusiveLock}}},
+	{"ALTER TABLE t VALIDATE CONSTRAINT c", []lock{{"t", pg_query.ShareUpdateExclusiveLock}}},
+	{"ALTER TABLE t DISABLE TRIGGER ALL", []lock{{"t", pg_query.ShareRowExclusiveLock}}},
+	{"ALTER TABLE t SET (fillfactor = 70)", []lock{{"t", pg_query.ShareUpdateExclusiveLock}}},
+	{"ALTER VIEW v SET (security_barrier = true)", []lock{{"v", pg_query.AccessExclusiveLock}}},
+	{"ALTER TABLE t ADD CONSTRAINT f FOREIGN KEY (a) REFERENCES u (b) NOT VALID", []lock{{"t", pg_query.ShareRowExclusiveLock}, {"u", pg_query.ShareRowExclusiveLock}}},
+	{"ALTER TABLE t ADD CONSTRAINT c CHECK (a > 0)", []lock{{"t", pg_query.AccessExclusiveLock}}},
+	{"ALTER TABLE t ADD COLUMN a int REFERENCES u", []lock{{"t", pg_query.AccessExclusiveLock}, {"u", pg_query.ShareRowExclusiveLock}}},
+	{"ALTER TABLE t VALIDATE CONSTRAINT c, ALTER COLUMN a TYPE bigint", []lock{{"t", pg_query.AccessExclusiveLock}}},
+	{"CREATE TABLE t (a int REFERENCES u, b int, FOREIGN KEY (b) REFERENCES s.v (id))", []lock{{"u", pg_query.ShareRowExclusiveLock}, {"s.v", pg_query.ShareRowExclusiveLock}}},
+	{"CREATE TABLE c () INHERITS (p)", []lock{{"p", pg_query.ShareUpdateExclusiveLock}}},
+	{"CREATE TABLE c PARTITION OF p FOR VALUES IN (1)", []lock{{"p", pg_query.AccessExclusiveLock}}},
+	{"CREATE TABLE t (a int)", nil},
+	{"ALTER TABLE p ATTACH PARTITION c FOR VALUES IN (1)", []lock{{"p", pg_query.AccessExclusiveLock}, {"c", pg_query.AccessExclusiveLock}}},
+	{"BEGIN", nil},
+}
+
+func TestLocks(t *testing.T) {
+	for _, test := range locksTests {
+		locks, err := pg_query.Locks(test.input)
+		if err != nil {
+			t.Errorf("Locks(%s): %s", test.input, err)
+			continue
+		}
+		if len(locks) != 1 {
+			t.Errorf("Locks(%s): expected 1 statement, got %d", test.input, len(locks))
+			continue
+		}
+		if !reflect.DeepEqual(locks[0], test.locks) {
+			t.Errorf("Locks(%s):\nexpected %v\nactual   %v", test.input, test.locks, locks[0])
+		}
+	}
+}
+
+func TestLockModeString(t *testing.T) {
+	if pg_query.ShareUpdateExclusiveLock.String() != "SHARE UPDATE EXCLUSIVE" {
+		t.Errorf("unexpected name %q", pg_query.ShareUpdateExclusiveLock.String())
+	}
+}
@@ -0,0 +1,430 @@
+package pg_query
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// OFFSET constants from which a statement is reported as using a large
+// offset, as the skipped rows still have to be computed
+const largeOffset = 1000
+
+// QueryMetrics describes the complexity of a statement and the patterns in
+// it that tend to make queries slow or dangerous
+type QueryMetrics struct {
+	// Number of joins: JOIN clauses and the additional items of FROM,
+	// UPDATE ... FROM and DELETE ... USING lists
+	Joins int
+
+	// Deepest nesting of subqueries in expressions, FROM and WITH, 0 for a
+	// statement without any
+	SubqueryDepth int
+
+	// Number of common table expressions
+	CTEs int
+
+	// Number of function calls, including aggregates and window functions
+	FunctionCalls int
+
+	// Whether a query combines several FROM items, or the sides of a CROSS
+	// JOIN, without a condition that relates them. Conditions with unqualified
+	// column references are assumed to relate the items they're in.
+	CartesianProduct bool
+
+	// Whether the target list of a query has * or t.*, outside of EXISTS
+	SelectStar bool
+
+	// Whether an UPDATE or DELETE has no WHERE clause
+	UnboundedWrite bool
+
+	// The largest constant OFFSET, 0 if there's none, and whether it's large
+	MaxOffset   int64
+	LargeOffset bool
+
+	// Whether a LIKE or ILIKE pattern starts with a wildcard, which can't
+	// use a B-tree index
+	LeadingWildcardLike bool
+
+	// Whether NOT IN or <> ALL is used with a subquery, which can't be
+	// planned as an anti-join and returns no rows if the subquery returns
+	// NULL
+	NotInSubquery bool
+
+	// Whether OR combines conditions on different columns, which can't use a
+	// single index
+	OrAcrossColumns bool
+}
+
+// Metrics - Parses the given SQL and returns the metrics of each of its
+// statements
+func Metrics(input string) (metrics []QueryMetrics, err error) {
+	tree, err := Parse(input)
+	if err != nil {
+		return
+	}
+	return tree.Metrics(), nil
+}
+
+// Metrics returns the complexity metrics and risky patterns of each
+// statement of the tree
+func (input ParsetreeList) Metrics() []QueryMetrics {
+	metrics := make([]QueryMetrics, len(input.Statements))
+	for i, stmt := range input.Statements {
+		stmt, _ = nodes.UnwrapRawStmt(stmt)
+		collector := metricsCollector{metrics: &metrics[i]}
+		collector.query(stmt, 0, false)
+	}
+	return metrics
+}
+
+type metricsCollector struct {
+	metrics *QueryMetrics
+}
+
+// query collects the metrics of a statement or subquery at the given depth.
+// The target list of a subquery of EXISTS doesn't matter.
+func (c *metricsCollector) query(node nodes.Node, depth int, exists bool) {
+	if depth > c.metrics.SubqueryDepth {
+		c.metrics.SubqueryDepth = depth
+	}
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		switch node := node.(type) {
+		case nodes.SubLink:
+			c.query(node.Testexpr, depth, false)
+			if node.SubLinkType == nodes.ALL_SUBLINK && len(node.OperName.Items) == 1 {
+				if str, ok := node.OperName.Items[0].(nodes.String); ok && str.Str == "<>" {
+					c.metrics.NotInSubquery = true
+				}
+			}
+			c.query(node.Subselect, depth+1, node.SubLinkType == nodes.EXISTS_SUBLINK)
+			return false
+		case nodes.RangeSubselect:
+			c.query(node.Subquery, depth+1, false)
+			return false
+		case nodes.CommonTableExpr:
+			c.metrics.CTEs++
+			c.query(node.Ctequery, depth+1, false)
+			return false
+		case nodes.SelectStmt:
+			c.selectStmt(node, exists)
+		case nodes.UpdateStmt:
+			c.metrics.UnboundedWrite = c.metrics.UnboundedWrite || node.WhereClause == nil
+			c.metrics.Joins += len(node.FromClause.Items)
+			c.checkCartesianProduct(append(targetItems(node.Relation), node.FromClause.Items...), node.WhereClause)
+		case nodes.DeleteStmt:
+			c.metrics.UnboundedWrite = c.metrics.UnboundedWrite || node.WhereClause == nil
+			c.metrics.Joins += len(node.UsingClause.Items)
+			c.checkCartesianProduct(append(targetItems(node.Relation), node.UsingClause.Items...), node.WhereClause)
+		case nodes.JoinExpr:
+			c.metrics.Joins++
+		case nodes.FuncCall:
+			// The escape function of a pattern isn't called by the query itself
+			if _, ok := parentNode.(nodes.A_Expr); !ok || parentFieldName != "Rexpr" || !isLikeEscape(node) {
+				c.metrics.FunctionCalls++
+			}
+		case nodes.A_Expr:
+			if isLikeExpr(node) && startsWithWildcard(node.Rexpr) {
+				c.metrics.LeadingWildcardLike = true
+			}
+		case nodes.BoolExpr:
+			switch node.Boolop {
+			case nodes.NOT_EXPR:
+				if len(node.Args.Items) == 1 {
+					if subLink, ok := node.Args.Items[0].(nodes.SubLink); ok && subLink.SubLinkType == nodes.ANY_SUBLINK {
+						c.metrics.NotInSubquery = true
+					}
+				}
+			case nodes.OR_EXPR:
+				if orAcrossColumns(node) {
+					c.metrics.OrAcrossColumns = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+func (c *metricsCollector) selectStmt(stmt nodes.SelectStmt, exists bool) {
+	if len(stmt.FromClause.Items) > 1 {
+		c.metrics.Joins += len(stmt.FromClause.Items) - 1
+	}
+	c.checkCartesianProduct(stmt.FromClause.Items, stmt.WhereClause)
+
+	if !exists {
+		for _, item := range stmt.TargetList.Items {
+			if resTarget, ok := item.(nodes.ResTarget); ok {
+				if ref, ok := resTarget.Val.(nodes.ColumnRef); ok && len(ref.Fields.Items) > 0 {
+					if _, ok := ref.Fields.Items[len(ref.Fields.Items)-1].(nodes.A_Star); ok {
+						c.metrics.SelectStar = true
+					}
+				}
+			}
+		}
+	}
+
+	if constant, ok := stmt.LimitOffset.(nodes.A_Const); ok {
+		var offset int64
+		switch value := constant.Val.(type) {
+		case nodes.Integer:
+			offset = value.Ival
+		case nodes.Float:
+			// Integers that don't fit into 32 bits
+			if parsed, err := strconv.ParseFloat(value.Str, 64); err == nil {
+				offset = int64(parsed)
+			}
+		}
+		if offset > c.metrics.MaxOffset {
+			c.metrics.MaxOffset = offset
+		}
+		c.metrics.LargeOffset = c.metrics.MaxOffset >= largeOffset
+	}
+}
+
+// targetItems returns the target table of UPDATE or DELETE as a FROM item
+func targetItems(relation *nodes.RangeVar) []nodes.Node {
+	if relation == nil {
+		return nil
+	}
+	return []nodes.Node{*relation}
+}
+
+func isLikeExpr(expr nodes.A_Expr) bool {
+	if expr.Kind != nodes.AEXPR_LIKE && expr.Kind != nodes.AEXPR_ILIKE && expr.Kind != nodes.AEXPR_OP {
+		return false
+	}
+	if len(expr.Name.Items) == 0 {
+		return false
+	}
+	str, ok := expr.Name.Items[len(expr.Name.Items)-1].(nodes.String)
+	return ok && (str.Str == "~~" || str.Str == "~~*" || str.Str == "!~~" || str.Str == "!~~*")
+}
+
+// isLikeEscape returns whether a function call is the one the grammar adds
+// for the pattern of LIKE ... ESCAPE or SIMILAR TO, e.g.
+// pg_catalog.like_escape(b, '!') for a LIKE b ESCAPE '!'
+func isLikeEscape(funcCall nodes.FuncCall) bool {
+	if len(funcCall.Funcname.Items) != 2 || len(funcCall.Args.Items) != 2 {
+		return false
+	}
+	schema, _ := funcCall.Funcname.Items[0].(nodes.String)
+	name, _ := funcCall.Funcname.Items[1].(nodes.String)
+	return schema.Str == "pg_catalog" && (name.Str == "like_escape" || name.Str == "similar_escape")
+}
+
+func startsWithWildcard(node nodes.Node) bool {
+	if funcCall, ok := node.(nodes.FuncCall); ok && isLikeEscape(funcCall) {
+		node = funcCall.Args.Items[0]
+	}
+	if typeCast, ok := node.(nodes.TypeCast); ok {
+		node = typeCast.Arg
+	}
+	constant, ok := node.(nodes.A_Const)
+	if !ok {
+		return false
+	}
+	str, ok := constant.Val.(nodes.String)
+	return ok && (strings.HasPrefix(str.Str, "%") || strings.HasPrefix(str.Str, "_"))
+}
+
+// orAcrossColumns returns whether at least two operands of OR refer to
+// different sets of columns. Operands without column references, e.g.
+// $1 IS NULL, don't count.
+func orAcrossColumns(expr nodes.BoolExpr) bool {
+	first := ""
+	for _, arg := range expr.Args.Items {
+		columns := map[string]bool{}
+		nodes.Walk(arg, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+			if ref, ok := node.(nodes.ColumnRef); ok {
+				columns[columnRefName(ref)] = true
+			}
+			return true
+		})
+		if len(columns) == 0 {
+			continue
+		}
+		names := []string{}
+		for name := range columns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		key := strings.Join(names, ",")
+		if first == "" {
+			first = key
+		} else if key != first {
+			return true
+		}
+	}
+	return false
+}
+
+func columnRefName(ref nodes.ColumnRef) string {
+	fields := []string{}
+	for _, field := range ref.Fields.Items {
+		if str, ok := field.(nodes.String); ok {
+			fields = append(fields, str.Str)
+		} else {
+			fields = append(fields, "*")
+		}
+	}
+	return strings.Join(fields, ".")
+}
+
+// fromGroup is a FROM item, or a join that relates its items, with the
+// names its columns can be qualified with
+type fromGroup struct {
+	names map[string]bool
+
+	// Qualifiers of the column references of a LATERAL subquery or function,
+	// which relate it to other items
+	lateralRefs []string
+}
+
+// fromGroups returns the groups of FROM items that are related by joins,
+// splitting CROSS JOINs into their sides
+func fromGroups(node nodes.Node) []*fromGroup {
+	switch node := node.(type) {
+	case nodes.JoinExpr:
+		if node.Quals == nil && len(node.UsingClause.Items) == 0 && !node.IsNatural && (node.Alias == nil || node.Alias.Aliasname == nil) {
+			return append(fromGroups(node.Larg), fromGroups(node.Rarg)...)
+		}
+		group := &fromGroup{names: map[string]bool{}}
+		for _, side := range append(fromGroups(node.Larg), fromGroups(node.Rarg)...) {
+			for name := range side.names {
+				group.names[name] = true
+			}
+			group.lateralRefs = append(group.lateralRefs, side.lateralRefs...)
+		}
+		if node.Alias != nil && node.Alias.Aliasname != nil {
+			group.names[*node.Alias.Aliasname] = true
+		}
+		return []*fromGroup{group}
+	case nodes.RangeVar:
+		group := &fromGroup{names: map[string]bool{}}
+		if node.Alias != nil && node.Alias.Aliasname != nil {
+			group.names[*node.Alias.Aliasname] = true
+		} else if node.Relname != nil {
+			group.names[*node.Relname] = true
+		}
+		return []*fromGroup{group}
+	case nodes.RangeSubselect:
+		group := &fromGroup{names: map[string]bool{}}
+		if node.Alias != nil && node.Alias.Aliasname != nil {
+			group.names[*node.Alias.Aliasname] = true
+		}
+		if node.Lateral {
+			group.lateralRefs = qualifiers(node.Subquery)
+		}
+		return []*fromGroup{group}
+	case nodes.RangeFunction:
+		// Functions can always refer to the items before them
+		group := &fromGroup{names: map[string]bool{}, lateralRefs: qualifiers(node.Functions)}
+		if node.Alias != nil && node.Alias.Aliasname != nil {
+			group.names[*node.Alias.Aliasname] = true
+		}
+		return []*fromGroup{group}
+	}
+	return []*fromGroup{{names: map[string]bool{}}}
+}
+
+// qualifiers returns the table names the column references of an expression
+// are qualified with, and "" for each unqualified reference
+func qualifiers(node nodes.Node) []string {
+	names := []string{}
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		ref, ok := node.(nodes.ColumnRef)
+		if !ok {
+			return true
+		}
+		// The last field is the column or *
+		fields := ref.Fields.Items
+		if len(fields) > 0 {
+			fields = fields[:len(fields)-1]
+		}
+		name := ""
+		if len(fields) > 0 {
+			if str, ok := fields[len(fields)-1].(nodes.String); ok {
+				name = str.Str
+			}
+		}
+		names = append(names, name)
+		return false
+	})
+	return names
+}
+
+// checkCartesianProduct sets CartesianProduct if the WHERE clause doesn't
+// relate all of the FROM items
+func (c *metricsCollector) checkCartesianProduct(items []nodes.Node, whereClause nodes.Node) {
+	groups := []*fromGroup{}
+	for _, item := range items {
+		groups = append(groups, fromGroups(item)...)
+	}
+	if len(groups) < 2 {
+		return
+	}
+
+	// Union-find over the groups
+	parents := make([]int, len(groups))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	relate := func(related []int) {
+		for _, i := range related {
+			parents[find(i)] = find(related[0])
+		}
+	}
+	// matching returns the groups qualified column references may refer to,
+	// which is any group for an unqualified one
+	matching := func(names []string) []int {
+		related := []int{}
+		for _, name := range names {
+			for i, group := range groups {
+				if name == "" || group.names[name] {
+					related = append(related, i)
+				}
+			}
+		}
+		return related
+	}
+
+	for i, group := range groups {
+		relate(append([]int{i}, matching(group.lateralRefs)...))
+	}
+	for _, conjunct := range conjuncts(whereClause) {
+		if names := qualifiers(conjunct); len(names) > 1 {
+			relate(matching(names))
+		}
+	}
+
+	for i := range groups {
+		if find(i) != find(0) {
+			c.metrics.CartesianProduct = true
+			return
+		}
+	}
+}
+
+// conjuncts returns the operands of the ANDs at the top of a condition
+func conjuncts(node nodes.Node) []nodes.Node {
+	if node == nil {
+		return nil
+	}
+	if boolExpr, ok := node.(nodes.BoolExpr); ok && boolExpr.Boolop == nodes.AND_EXPR {
+		result := []nodes.Node{}
+		for _, arg := range boolExpr.Args.Items {
+			result = append(result, conjuncts(arg)...)
+		}
+		return result
+	}
+	return []nodes.Node{node}
+}
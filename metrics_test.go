@@ -0,0 +1,64 @@
+package pg_query_test
+
+import (
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go"
+)
+
+var metricsTests = []struct {
+	input   string
+	metrics pg_query.QueryMetrics
+}{
+	{"SELECT a FROM t WHERE a = 1", pg_query.QueryMetrics{}},
+	{"SELECT * FROM t", pg_query.QueryMetrics{SelectStar: true}},
+	{"SELECT t.* FROM t", pg_query.QueryMetrics{SelectStar: true}},
+	{"SELECT count(*) FROM t WHERE EXISTS (SELECT * FROM u WHERE u.id = t.id)", pg_query.QueryMetrics{SubqueryDepth: 1, FunctionCalls: 1}},
+	{"SELECT t.a FROM t, u", pg_query.QueryMetrics{Joins: 1, CartesianProduct: true}},
+	{"SELECT t.a FROM t, u WHERE t.id = u.t_id AND t.a > 1", pg_query.QueryMetrics{Joins: 1}},
+	{"SELECT a FROM t, u WHERE t_id = id", pg_query.QueryMetrics{Joins: 1}},
+	{"SELECT a FROM t CROSS JOIN u", pg_query.QueryMetrics{Joins: 1, CartesianProduct: true}},
+	{"SELECT a FROM t JOIN u ON t.id = u.id JOIN v USING (id), w WHERE w.id = v.id", pg_query.QueryMetrics{Joins: 3}},
+	{"SELECT a FROM t JOIN u ON t.id = u.id, w WHERE w.a = 1", pg_query.QueryMetrics{Joins: 2, CartesianProduct: true}},
+	{"SELECT a FROM t, unnest(t.tags) tag", pg_query.QueryMetrics{Joins: 1, FunctionCalls: 1}},
+	{"SELECT a FROM t, LATERAL (SELECT b FROM u WHERE u.id = t.id) s", pg_query.QueryMetrics{Joins: 1, SubqueryDepth: 1}},
+	{"SELECT a FROM t, generate_series(1, 10) g", pg_query.QueryMetrics{Joins: 1, FunctionCalls: 1, CartesianProduct: true}},
+	{"UPDATE t SET a = 1", pg_query.QueryMetrics{UnboundedWrite: true}},
+	{"DELETE FROM t", pg_query.QueryMetrics{UnboundedWrite: true}},
+	{"WITH d AS (DELETE FROM t RETURNING id) SELECT id FROM d", pg_query.QueryMetrics{SubqueryDepth: 1, CTEs: 1, UnboundedWrite: true}},
+	{"UPDATE t SET a = u.a FROM u WHERE u.id = t.id", pg_query.QueryMetrics{Joins: 1}},
+	{"UPDATE t SET a = u.a FROM u WHERE t.id = 1", pg_query.QueryMetrics{Joins: 1, CartesianProduct: true}},
+	{"DELETE FROM t USING u WHERE u.id = t.id", pg_query.QueryMetrics{Joins: 1}},
+	{"SELECT a FROM t LIMIT 10 OFFSET 100", pg_query.QueryMetrics{MaxOffset: 100}},
+	{"SELECT a FROM t OFFSET 5000000000", pg_query.QueryMetrics{MaxOffset: 5000000000, LargeOffset: true}},
+	{"SELECT a FROM t WHERE name LIKE '%foo' OR name ILIKE 'x%'", pg_query.QueryMetrics{LeadingWildcardLike: true}},
+	{"SELECT a FROM t WHERE name NOT LIKE '_x'", pg_query.QueryMetrics{LeadingWildcardLike: true}},
+	{"SELECT a FROM t WHERE name LIKE 'x%'", pg_query.QueryMetrics{}},
+	{"SELECT a FROM t WHERE name LIKE '%x' ESCAPE '!' AND b SIMILAR TO 'y%'", pg_query.QueryMetrics{LeadingWildcardLike: true}},
+	{"SELECT a FROM t WHERE a NOT IN (SELECT b FROM u)", pg_query.QueryMetrics{SubqueryDepth: 1, NotInSubquery: true}},
+	{"SELECT a FROM t WHERE a <> ALL (SELECT b FROM u)", pg_query.QueryMetrics{SubqueryDepth: 1, NotInSubquery: true}},
+	{"SELECT a FROM t WHERE a NOT IN (1, 2)", pg_query.QueryMetrics{}},
+	{"SELECT a FROM t WHERE a = 1 OR b = 2", pg_query.QueryMetrics{OrAcrossColumns: true}},
+	{"SELECT a FROM t WHERE a = 1 OR a = 2 OR $1 IS NULL", pg_query.QueryMetrics{}},
+	{
+		"WITH x AS (SELECT 1), y AS (SELECT * FROM (SELECT (SELECT max(b) FROM u) FROM x) s) SELECT lower(a), upper(b) FROM y",
+		pg_query.QueryMetrics{SubqueryDepth: 3, CTEs: 2, FunctionCalls: 3, SelectStar: true},
+	},
+}
+
+func TestMetrics(t *testing.T) {
+	for _, test := range metricsTests {
+		metrics, err := pg_query.Metrics(test.input)
+		if err != nil {
+			t.Errorf("Metrics(%s): %s", test.input, err)
+			continue
+		}
+		if len(metrics) != 1 {
+			t.Errorf("Metrics(%s): expected 1 statement, got %d", test.input, len(metrics))
+			continue
+		}
+		if metrics[0] != test.metrics {
+			t.Errorf("Metrics(%s): expected %+v, got %+v", test.input, test.metrics, metrics[0])
+		}
+	}
+}
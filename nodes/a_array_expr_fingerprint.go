@@ -6,7 +6,7 @@ func (node A_ArrayExpr) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	ctx.WriteString("A_ArrayExpr")
 
 	if len(node.Elements.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Elements.Fingerprint(&subCtx, node, "Elements")
 
 		if len(subCtx.parts) > 0 {
@@ -3,5 +3,18 @@
 package pg_query
 
 func (node A_Const) Fingerprint(ctx FingerprintContext, parentNode Node, parentFieldName string) {
-	// Intentionally ignoring all fields for fingerprinting
+	// Constants are ignored, unless LIMIT/OFFSET values are significant
+	if node.Val != nil && fingerprintOptions(ctx).LimitConstants && (parentFieldName == "LimitCount" || parentFieldName == "LimitOffset") {
+		ctx.WriteString("A_Const")
+
+		subCtx := newFingerprintSubContext(ctx)
+		node.Val.Fingerprint(&subCtx, node, "Val")
+
+		if len(subCtx.parts) > 0 {
+			ctx.WriteString("val")
+			for _, part := range subCtx.parts {
+				ctx.WriteString(part)
+			}
+		}
+	}
 }
@@ -13,7 +13,7 @@ func (node A_Expr) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if node.Lexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Lexpr.Fingerprint(&subCtx, node, "Lexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -26,7 +26,7 @@ func (node A_Expr) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.Name.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Name.Fingerprint(&subCtx, node, "Name")
 
 		if len(subCtx.parts) > 0 {
@@ -38,7 +38,7 @@ func (node A_Expr) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if node.Rexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rexpr.Fingerprint(&subCtx, node, "Rexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -47,5 +47,10 @@ func (node A_Expr) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 				ctx.WriteString(part)
 			}
 		}
+
+		if list, ok := node.Rexpr.(List); ok && node.Kind == AEXPR_IN && fingerprintOptions(ctx).InListLength {
+			ctx.WriteString("rexprLength")
+			ctx.WriteString(strconv.Itoa(len(list.Items)))
+		}
 	}
 }
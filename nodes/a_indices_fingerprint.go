@@ -13,7 +13,7 @@ func (node A_Indices) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.Lidx != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Lidx.Fingerprint(&subCtx, node, "Lidx")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node A_Indices) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.Uidx != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Uidx.Fingerprint(&subCtx, node, "Uidx")
 
 		if len(subCtx.parts) > 0 {
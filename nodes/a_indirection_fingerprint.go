@@ -6,7 +6,7 @@ func (node A_Indirection) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	ctx.WriteString("A_Indirection")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node A_Indirection) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Indirection.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Indirection.Fingerprint(&subCtx, node, "Indirection")
 
 		if len(subCtx.parts) > 0 {
@@ -6,7 +6,7 @@ func (node AccessPriv) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("AccessPriv")
 
 	if len(node.Cols.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Cols.Fingerprint(&subCtx, node, "Cols")
 
 		if len(subCtx.parts) > 0 {
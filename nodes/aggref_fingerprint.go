@@ -8,7 +8,7 @@ func (node Aggref) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	ctx.WriteString("Aggref")
 
 	if len(node.Aggargtypes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Aggargtypes.Fingerprint(&subCtx, node, "Aggargtypes")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node Aggref) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if len(node.Aggdirectargs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Aggdirectargs.Fingerprint(&subCtx, node, "Aggdirectargs")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node Aggref) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if len(node.Aggdistinct.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Aggdistinct.Fingerprint(&subCtx, node, "Aggdistinct")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node Aggref) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if node.Aggfilter != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Aggfilter.Fingerprint(&subCtx, node, "Aggfilter")
 
 		if len(subCtx.parts) > 0 {
@@ -77,7 +77,7 @@ func (node Aggref) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if len(node.Aggorder.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Aggorder.Fingerprint(&subCtx, node, "Aggorder")
 
 		if len(subCtx.parts) > 0 {
@@ -114,7 +114,7 @@ func (node Aggref) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -133,7 +133,7 @@ func (node Aggref) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
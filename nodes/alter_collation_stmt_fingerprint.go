@@ -6,7 +6,7 @@ func (node AlterCollationStmt) Fingerprint(ctx FingerprintContext, parentNode No
 	ctx.WriteString("AlterCollationStmt")
 
 	if len(node.Collname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Collname.Fingerprint(&subCtx, node, "Collname")
 
 		if len(subCtx.parts) > 0 {
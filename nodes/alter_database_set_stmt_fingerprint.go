@@ -11,7 +11,7 @@ func (node AlterDatabaseSetStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if node.Setstmt != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Setstmt.Fingerprint(&subCtx, node, "Setstmt")
 
 		if len(subCtx.parts) > 0 {
@@ -11,7 +11,7 @@ func (node AlterDatabaseStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
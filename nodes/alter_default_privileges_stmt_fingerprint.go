@@ -6,7 +6,7 @@ func (node AlterDefaultPrivilegesStmt) Fingerprint(ctx FingerprintContext, paren
 	ctx.WriteString("AlterDefaultPrivilegesStmt")
 
 	if node.Action != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Action.Fingerprint(&subCtx, node, "Action")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node AlterDefaultPrivilegesStmt) Fingerprint(ctx FingerprintContext, paren
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
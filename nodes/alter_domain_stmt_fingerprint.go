@@ -13,7 +13,7 @@ func (node AlterDomainStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Def != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Def.Fingerprint(&subCtx, node, "Def")
 
 		if len(subCtx.parts) > 0 {
@@ -41,7 +41,7 @@ func (node AlterDomainStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.TypeName.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
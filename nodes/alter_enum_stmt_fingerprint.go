@@ -33,7 +33,7 @@ func (node AlterEnumStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.TypeName.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
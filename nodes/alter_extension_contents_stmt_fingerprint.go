@@ -18,7 +18,7 @@ func (node AlterExtensionContentsStmt) Fingerprint(ctx FingerprintContext, paren
 	}
 
 	if node.Object != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Object.Fingerprint(&subCtx, node, "Object")
 
 		if len(subCtx.parts) > 0 {
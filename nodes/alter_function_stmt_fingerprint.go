@@ -6,7 +6,7 @@ func (node AlterFunctionStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	ctx.WriteString("AlterFunctionStmt")
 
 	if len(node.Actions.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Actions.Fingerprint(&subCtx, node, "Actions")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node AlterFunctionStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Func != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Func.Fingerprint(&subCtx, node, "Func")
 
 		if len(subCtx.parts) > 0 {
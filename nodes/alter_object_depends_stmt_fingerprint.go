@@ -8,7 +8,7 @@ func (node AlterObjectDependsStmt) Fingerprint(ctx FingerprintContext, parentNod
 	ctx.WriteString("AlterObjectDependsStmt")
 
 	if node.Extname != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Extname.Fingerprint(&subCtx, node, "Extname")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node AlterObjectDependsStmt) Fingerprint(ctx FingerprintContext, parentNod
 	}
 
 	if node.Object != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Object.Fingerprint(&subCtx, node, "Object")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node AlterObjectDependsStmt) Fingerprint(ctx FingerprintContext, parentNod
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node AlterOpFamilyStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.Items.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Items.Fingerprint(&subCtx, node, "Items")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node AlterOpFamilyStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.Opfamilyname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opfamilyname.Fingerprint(&subCtx, node, "Opfamilyname")
 
 		if len(subCtx.parts) > 0 {
@@ -6,7 +6,7 @@ func (node AlterOperatorStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	ctx.WriteString("AlterOperatorStmt")
 
 	if node.Opername != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opername.Fingerprint(&subCtx, node, "Opername")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node AlterOperatorStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node AlterOwnerStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("AlterOwnerStmt")
 
 	if node.Newowner != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Newowner.Fingerprint(&subCtx, node, "Newowner")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node AlterOwnerStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Object != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Object.Fingerprint(&subCtx, node, "Object")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node AlterOwnerStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -11,7 +11,7 @@ func (node AlterPolicyStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Qual != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Qual.Fingerprint(&subCtx, node, "Qual")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node AlterPolicyStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Roles.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Roles.Fingerprint(&subCtx, node, "Roles")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node AlterPolicyStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Table != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Table.Fingerprint(&subCtx, node, "Table")
 
 		if len(subCtx.parts) > 0 {
@@ -47,7 +47,7 @@ func (node AlterPolicyStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.WithCheck != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WithCheck.Fingerprint(&subCtx, node, "WithCheck")
 
 		if len(subCtx.parts) > 0 {
@@ -13,7 +13,7 @@ func (node AlterPublicationStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node AlterPublicationStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Tables.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Tables.Fingerprint(&subCtx, node, "Tables")
 
 		if len(subCtx.parts) > 0 {
@@ -11,7 +11,7 @@ func (node AlterRoleSetStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Role != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Role.Fingerprint(&subCtx, node, "Role")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node AlterRoleSetStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Setstmt != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Setstmt.Fingerprint(&subCtx, node, "Setstmt")
 
 		if len(subCtx.parts) > 0 {
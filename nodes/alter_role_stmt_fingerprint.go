@@ -13,7 +13,7 @@ func (node AlterRoleStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node AlterRoleStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Role != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Role.Fingerprint(&subCtx, node, "Role")
 
 		if len(subCtx.parts) > 0 {
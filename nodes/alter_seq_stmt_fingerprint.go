@@ -18,7 +18,7 @@ func (node AlterSeqStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node AlterSeqStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if node.Sequence != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Sequence.Fingerprint(&subCtx, node, "Sequence")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node AlterSubscriptionStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node AlterSubscriptionStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Publication.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Publication.Fingerprint(&subCtx, node, "Publication")
 
 		if len(subCtx.parts) > 0 {
@@ -6,7 +6,7 @@ func (node AlterSystemStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("AlterSystemStmt")
 
 	if node.Setstmt != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Setstmt.Fingerprint(&subCtx, node, "Setstmt")
 
 		if len(subCtx.parts) > 0 {
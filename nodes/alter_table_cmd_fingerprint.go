@@ -13,7 +13,7 @@ func (node AlterTableCmd) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Def != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Def.Fingerprint(&subCtx, node, "Def")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node AlterTableCmd) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Newowner != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Newowner.Fingerprint(&subCtx, node, "Newowner")
 
 		if len(subCtx.parts) > 0 {
@@ -28,7 +28,7 @@ func (node AlterTableMoveAllStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Roles.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Roles.Fingerprint(&subCtx, node, "Roles")
 
 		if len(subCtx.parts) > 0 {
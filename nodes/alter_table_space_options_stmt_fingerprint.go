@@ -13,7 +13,7 @@ func (node AlterTableSpaceOptionsStmt) Fingerprint(ctx FingerprintContext, paren
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
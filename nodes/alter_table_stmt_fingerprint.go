@@ -8,7 +8,7 @@ func (node AlterTableStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("AlterTableStmt")
 
 	if len(node.Cmds.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Cmds.Fingerprint(&subCtx, node, "Cmds")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node AlterTableStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
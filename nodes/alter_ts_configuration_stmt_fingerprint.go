@@ -8,7 +8,7 @@ func (node AlterTSConfigurationStmt) Fingerprint(ctx FingerprintContext, parentN
 	ctx.WriteString("AlterTSConfigurationStmt")
 
 	if len(node.Cfgname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Cfgname.Fingerprint(&subCtx, node, "Cfgname")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node AlterTSConfigurationStmt) Fingerprint(ctx FingerprintContext, parentN
 	}
 
 	if len(node.Dicts.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Dicts.Fingerprint(&subCtx, node, "Dicts")
 
 		if len(subCtx.parts) > 0 {
@@ -52,7 +52,7 @@ func (node AlterTSConfigurationStmt) Fingerprint(ctx FingerprintContext, parentN
 	}
 
 	if len(node.Tokentype.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Tokentype.Fingerprint(&subCtx, node, "Tokentype")
 
 		if len(subCtx.parts) > 0 {
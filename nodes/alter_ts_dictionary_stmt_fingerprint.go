@@ -6,7 +6,7 @@ func (node AlterTSDictionaryStmt) Fingerprint(ctx FingerprintContext, parentNode
 	ctx.WriteString("AlterTSDictionaryStmt")
 
 	if len(node.Dictname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Dictname.Fingerprint(&subCtx, node, "Dictname")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node AlterTSDictionaryStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -6,7 +6,7 @@ func (node AlterUserMappingStmt) Fingerprint(ctx FingerprintContext, parentNode
 	ctx.WriteString("AlterUserMappingStmt")
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node AlterUserMappingStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if node.User != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.User.Fingerprint(&subCtx, node, "User")
 
 		if len(subCtx.parts) > 0 {
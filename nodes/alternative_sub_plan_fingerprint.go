@@ -6,7 +6,7 @@ func (node AlternativeSubPlan) Fingerprint(ctx FingerprintContext, parentNode No
 	ctx.WriteString("AlternativeSubPlan")
 
 	if len(node.Subplans.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Subplans.Fingerprint(&subCtx, node, "Subplans")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node AlternativeSubPlan) Fingerprint(ctx FingerprintContext, parentNode No
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
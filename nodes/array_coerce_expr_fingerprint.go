@@ -8,7 +8,7 @@ func (node ArrayCoerceExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("ArrayCoerceExpr")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -52,7 +52,7 @@ func (node ArrayCoerceExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node ArrayExpr) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Elements.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Elements.Fingerprint(&subCtx, node, "Elements")
 
 		if len(subCtx.parts) > 0 {
@@ -41,7 +41,7 @@ func (node ArrayExpr) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
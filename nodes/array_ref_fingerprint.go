@@ -13,7 +13,7 @@ func (node ArrayRef) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Refassgnexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Refassgnexpr.Fingerprint(&subCtx, node, "Refassgnexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node ArrayRef) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Refexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Refexpr.Fingerprint(&subCtx, node, "Refexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -47,7 +47,7 @@ func (node ArrayRef) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Reflowerindexpr.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Reflowerindexpr.Fingerprint(&subCtx, node, "Reflowerindexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -64,7 +64,7 @@ func (node ArrayRef) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Refupperindexpr.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Refupperindexpr.Fingerprint(&subCtx, node, "Refupperindexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -76,7 +76,7 @@ func (node ArrayRef) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
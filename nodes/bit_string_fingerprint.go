@@ -3,8 +3,8 @@
 package pg_query
 
 func (node BitString) Fingerprint(ctx FingerprintContext, parentNode Node, parentFieldName string) {
-	ctx.WriteString("BitString")
 	if len(node.Str) > 0 {
+		ctx.WriteString("BitString")
 		ctx.WriteString("str")
 		ctx.WriteString(node.Str)
 	}
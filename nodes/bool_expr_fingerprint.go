@@ -8,7 +8,7 @@ func (node BoolExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("BoolExpr")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -27,7 +27,7 @@ func (node BoolExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -14,4 +14,5 @@ type BoolExprType uint
 const (
 	AND_EXPR BoolExprType = iota
 	OR_EXPR
+	NOT_EXPR
 )
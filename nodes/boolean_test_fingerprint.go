@@ -8,7 +8,7 @@ func (node BooleanTest) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	ctx.WriteString("BooleanTest")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -27,7 +27,7 @@ func (node BooleanTest) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
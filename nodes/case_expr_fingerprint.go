@@ -8,7 +8,7 @@ func (node CaseExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("CaseExpr")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node CaseExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node CaseExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Defresult != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Defresult.Fingerprint(&subCtx, node, "Defresult")
 
 		if len(subCtx.parts) > 0 {
@@ -55,7 +55,7 @@ func (node CaseExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
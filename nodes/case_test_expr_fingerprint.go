@@ -23,7 +23,7 @@ func (node CaseTestExpr) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -6,7 +6,7 @@ func (node CaseWhen) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("CaseWhen")
 
 	if node.Expr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Expr.Fingerprint(&subCtx, node, "Expr")
 
 		if len(subCtx.parts) > 0 {
@@ -19,7 +19,7 @@ func (node CaseWhen) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Result != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Result.Fingerprint(&subCtx, node, "Result")
 
 		if len(subCtx.parts) > 0 {
@@ -31,7 +31,7 @@ func (node CaseWhen) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
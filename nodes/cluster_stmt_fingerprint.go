@@ -13,7 +13,7 @@ func (node ClusterStmt) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
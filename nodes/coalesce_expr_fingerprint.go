@@ -8,7 +8,7 @@ func (node CoalesceExpr) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	ctx.WriteString("CoalesceExpr")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node CoalesceExpr) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node CoerceToDomain) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("CoerceToDomain")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node CoerceToDomain) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node CoerceToDomainValue) Fingerprint(ctx FingerprintContext, parentNode N
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -6,7 +6,7 @@ func (node CollateClause) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	ctx.WriteString("CollateClause")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node CollateClause) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Collname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Collname.Fingerprint(&subCtx, node, "Collname")
 
 		if len(subCtx.parts) > 0 {
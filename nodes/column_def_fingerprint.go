@@ -8,7 +8,7 @@ func (node ColumnDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	ctx.WriteString("ColumnDef")
 
 	if node.CollClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.CollClause.Fingerprint(&subCtx, node, "CollClause")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node ColumnDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Constraints.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Constraints.Fingerprint(&subCtx, node, "Constraints")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node ColumnDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.CookedDefault != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.CookedDefault.Fingerprint(&subCtx, node, "CookedDefault")
 
 		if len(subCtx.parts) > 0 {
@@ -54,7 +54,7 @@ func (node ColumnDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Fdwoptions.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Fdwoptions.Fingerprint(&subCtx, node, "Fdwoptions")
 
 		if len(subCtx.parts) > 0 {
@@ -99,7 +99,7 @@ func (node ColumnDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.RawDefault != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.RawDefault.Fingerprint(&subCtx, node, "RawDefault")
 
 		if len(subCtx.parts) > 0 {
@@ -117,7 +117,7 @@ func (node ColumnDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.TypeName != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
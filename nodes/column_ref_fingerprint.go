@@ -6,7 +6,7 @@ func (node ColumnRef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	ctx.WriteString("ColumnRef")
 
 	if len(node.Fields.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Fields.Fingerprint(&subCtx, node, "Fields")
 
 		if len(subCtx.parts) > 0 {
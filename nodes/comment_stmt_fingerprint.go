@@ -13,7 +13,7 @@ func (node CommentStmt) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	}
 
 	if node.Object != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Object.Fingerprint(&subCtx, node, "Object")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node CommonTableExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("CommonTableExpr")
 
 	if len(node.Aliascolnames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Aliascolnames.Fingerprint(&subCtx, node, "Aliascolnames")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node CommonTableExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Ctecolcollations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Ctecolcollations.Fingerprint(&subCtx, node, "Ctecolcollations")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node CommonTableExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Ctecolnames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Ctecolnames.Fingerprint(&subCtx, node, "Ctecolnames")
 
 		if len(subCtx.parts) > 0 {
@@ -44,7 +44,7 @@ func (node CommonTableExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Ctecoltypes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Ctecoltypes.Fingerprint(&subCtx, node, "Ctecoltypes")
 
 		if len(subCtx.parts) > 0 {
@@ -56,7 +56,7 @@ func (node CommonTableExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Ctecoltypmods.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Ctecoltypmods.Fingerprint(&subCtx, node, "Ctecoltypmods")
 
 		if len(subCtx.parts) > 0 {
@@ -73,7 +73,7 @@ func (node CommonTableExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Ctequery != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Ctequery.Fingerprint(&subCtx, node, "Ctequery")
 
 		if len(subCtx.parts) > 0 {
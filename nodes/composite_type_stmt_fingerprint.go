@@ -6,7 +6,7 @@ func (node CompositeTypeStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	ctx.WriteString("CompositeTypeStmt")
 
 	if len(node.Coldeflist.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Coldeflist.Fingerprint(&subCtx, node, "Coldeflist")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node CompositeTypeStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Typevar != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Typevar.Fingerprint(&subCtx, node, "Typevar")
 
 		if len(subCtx.parts) > 0 {
@@ -33,7 +33,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Exclusions.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Exclusions.Fingerprint(&subCtx, node, "Exclusions")
 
 		if len(subCtx.parts) > 0 {
@@ -45,7 +45,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.FkAttrs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.FkAttrs.Fingerprint(&subCtx, node, "FkAttrs")
 
 		if len(subCtx.parts) > 0 {
@@ -106,7 +106,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Keys.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Keys.Fingerprint(&subCtx, node, "Keys")
 
 		if len(subCtx.parts) > 0 {
@@ -119,7 +119,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.OldConpfeqop.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OldConpfeqop.Fingerprint(&subCtx, node, "OldConpfeqop")
 
 		if len(subCtx.parts) > 0 {
@@ -136,7 +136,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -148,7 +148,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.PkAttrs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.PkAttrs.Fingerprint(&subCtx, node, "PkAttrs")
 
 		if len(subCtx.parts) > 0 {
@@ -160,7 +160,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Pktable != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Pktable.Fingerprint(&subCtx, node, "Pktable")
 
 		if len(subCtx.parts) > 0 {
@@ -172,7 +172,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.RawExpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.RawExpr.Fingerprint(&subCtx, node, "RawExpr")
 
 		if len(subCtx.parts) > 0 {
@@ -189,7 +189,7 @@ func (node Constraint) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.WhereClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WhereClause.Fingerprint(&subCtx, node, "WhereClause")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node ConstraintsSetStmt) Fingerprint(ctx FingerprintContext, parentNode No
 	ctx.WriteString("ConstraintsSetStmt")
 
 	if len(node.Constraints.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Constraints.Fingerprint(&subCtx, node, "Constraints")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node ConvertRowtypeExpr) Fingerprint(ctx FingerprintContext, parentNode No
 	ctx.WriteString("ConvertRowtypeExpr")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node ConvertRowtypeExpr) Fingerprint(ctx FingerprintContext, parentNode No
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node CopyStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("CopyStmt")
 
 	if len(node.Attlist.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Attlist.Fingerprint(&subCtx, node, "Attlist")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node CopyStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -47,7 +47,7 @@ func (node CopyStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Query != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Query.Fingerprint(&subCtx, node, "Query")
 
 		if len(subCtx.parts) > 0 {
@@ -59,7 +59,7 @@ func (node CopyStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
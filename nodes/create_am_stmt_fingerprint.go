@@ -17,7 +17,7 @@ func (node CreateAmStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if len(node.HandlerName.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.HandlerName.Fingerprint(&subCtx, node, "HandlerName")
 
 		if len(subCtx.parts) > 0 {
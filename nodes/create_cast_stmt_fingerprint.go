@@ -13,7 +13,7 @@ func (node CreateCastStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Func != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Func.Fingerprint(&subCtx, node, "Func")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node CreateCastStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Sourcetype != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Sourcetype.Fingerprint(&subCtx, node, "Sourcetype")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node CreateCastStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Targettype != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Targettype.Fingerprint(&subCtx, node, "Targettype")
 
 		if len(subCtx.parts) > 0 {
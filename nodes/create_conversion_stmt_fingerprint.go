@@ -8,7 +8,7 @@ func (node CreateConversionStmt) Fingerprint(ctx FingerprintContext, parentNode
 	ctx.WriteString("CreateConversionStmt")
 
 	if len(node.ConversionName.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ConversionName.Fingerprint(&subCtx, node, "ConversionName")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node CreateConversionStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.FuncName.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.FuncName.Fingerprint(&subCtx, node, "FuncName")
 
 		if len(subCtx.parts) > 0 {
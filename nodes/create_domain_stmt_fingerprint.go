@@ -6,7 +6,7 @@ func (node CreateDomainStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	ctx.WriteString("CreateDomainStmt")
 
 	if node.CollClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.CollClause.Fingerprint(&subCtx, node, "CollClause")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node CreateDomainStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.Constraints.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Constraints.Fingerprint(&subCtx, node, "Constraints")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node CreateDomainStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.Domainname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Domainname.Fingerprint(&subCtx, node, "Domainname")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node CreateDomainStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.TypeName != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
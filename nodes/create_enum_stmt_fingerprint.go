@@ -6,7 +6,7 @@ func (node CreateEnumStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("CreateEnumStmt")
 
 	if len(node.TypeName.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node CreateEnumStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Vals.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Vals.Fingerprint(&subCtx, node, "Vals")
 
 		if len(subCtx.parts) > 0 {
@@ -11,7 +11,7 @@ func (node CreateEventTrigStmt) Fingerprint(ctx FingerprintContext, parentNode N
 	}
 
 	if len(node.Funcname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funcname.Fingerprint(&subCtx, node, "Funcname")
 
 		if len(subCtx.parts) > 0 {
@@ -28,7 +28,7 @@ func (node CreateEventTrigStmt) Fingerprint(ctx FingerprintContext, parentNode N
 	}
 
 	if len(node.Whenclause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Whenclause.Fingerprint(&subCtx, node, "Whenclause")
 
 		if len(subCtx.parts) > 0 {
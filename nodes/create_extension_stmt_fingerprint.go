@@ -18,7 +18,7 @@ func (node CreateExtensionStmt) Fingerprint(ctx FingerprintContext, parentNode N
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -11,7 +11,7 @@ func (node CreateFdwStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.FuncOptions.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.FuncOptions.Fingerprint(&subCtx, node, "FuncOptions")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node CreateFdwStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node CreateForeignTableStmt) Fingerprint(ctx FingerprintContext, parentNod
 	node.Base.Fingerprint(ctx, node, "Base")
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node CreateFunctionStmt) Fingerprint(ctx FingerprintContext, parentNode No
 	ctx.WriteString("CreateFunctionStmt")
 
 	if len(node.Funcname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funcname.Fingerprint(&subCtx, node, "Funcname")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node CreateFunctionStmt) Fingerprint(ctx FingerprintContext, parentNode No
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node CreateFunctionStmt) Fingerprint(ctx FingerprintContext, parentNode No
 	}
 
 	if len(node.Parameters.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Parameters.Fingerprint(&subCtx, node, "Parameters")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node CreateFunctionStmt) Fingerprint(ctx FingerprintContext, parentNode No
 	}
 
 	if node.ReturnType != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ReturnType.Fingerprint(&subCtx, node, "ReturnType")
 
 		if len(subCtx.parts) > 0 {
@@ -61,7 +61,7 @@ func (node CreateFunctionStmt) Fingerprint(ctx FingerprintContext, parentNode No
 	}
 
 	if len(node.WithClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WithClause.Fingerprint(&subCtx, node, "WithClause")
 
 		if len(subCtx.parts) > 0 {
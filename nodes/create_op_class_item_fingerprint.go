@@ -8,7 +8,7 @@ func (node CreateOpClassItem) Fingerprint(ctx FingerprintContext, parentNode Nod
 	ctx.WriteString("CreateOpClassItem")
 
 	if len(node.ClassArgs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ClassArgs.Fingerprint(&subCtx, node, "ClassArgs")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node CreateOpClassItem) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Name != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Name.Fingerprint(&subCtx, node, "Name")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node CreateOpClassItem) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.OrderFamily.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OrderFamily.Fingerprint(&subCtx, node, "OrderFamily")
 
 		if len(subCtx.parts) > 0 {
@@ -54,7 +54,7 @@ func (node CreateOpClassItem) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Storedtype != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Storedtype.Fingerprint(&subCtx, node, "Storedtype")
 
 		if len(subCtx.parts) > 0 {
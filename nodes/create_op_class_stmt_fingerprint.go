@@ -13,7 +13,7 @@ func (node CreateOpClassStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Datatype != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Datatype.Fingerprint(&subCtx, node, "Datatype")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node CreateOpClassStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.Items.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Items.Fingerprint(&subCtx, node, "Items")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node CreateOpClassStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.Opclassname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opclassname.Fingerprint(&subCtx, node, "Opclassname")
 
 		if len(subCtx.parts) > 0 {
@@ -54,7 +54,7 @@ func (node CreateOpClassStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.Opfamilyname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opfamilyname.Fingerprint(&subCtx, node, "Opfamilyname")
 
 		if len(subCtx.parts) > 0 {
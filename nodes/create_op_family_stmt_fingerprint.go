@@ -11,7 +11,7 @@ func (node CreateOpFamilyStmt) Fingerprint(ctx FingerprintContext, parentNode No
 	}
 
 	if len(node.Opfamilyname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opfamilyname.Fingerprint(&subCtx, node, "Opfamilyname")
 
 		if len(subCtx.parts) > 0 {
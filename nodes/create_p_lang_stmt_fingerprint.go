@@ -8,7 +8,7 @@ func (node CreatePLangStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("CreatePLangStmt")
 
 	if len(node.Plhandler.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Plhandler.Fingerprint(&subCtx, node, "Plhandler")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node CreatePLangStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Plinline.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Plinline.Fingerprint(&subCtx, node, "Plinline")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node CreatePLangStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Plvalidator.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Plvalidator.Fingerprint(&subCtx, node, "Plvalidator")
 
 		if len(subCtx.parts) > 0 {
@@ -13,7 +13,7 @@ func (node CreatePublicationStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node CreatePublicationStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Tables.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Tables.Fingerprint(&subCtx, node, "Tables")
 
 		if len(subCtx.parts) > 0 {
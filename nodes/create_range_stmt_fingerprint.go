@@ -6,7 +6,7 @@ func (node CreateRangeStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("CreateRangeStmt")
 
 	if len(node.Params.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Params.Fingerprint(&subCtx, node, "Params")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node CreateRangeStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.TypeName.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
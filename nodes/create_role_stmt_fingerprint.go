@@ -8,7 +8,7 @@ func (node CreateRoleStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("CreateRoleStmt")
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node CreateSchemaStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	ctx.WriteString("CreateSchemaStmt")
 
 	if node.Authrole != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Authrole.Fingerprint(&subCtx, node, "Authrole")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node CreateSchemaStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.SchemaElts.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.SchemaElts.Fingerprint(&subCtx, node, "SchemaElts")
 
 		if len(subCtx.parts) > 0 {
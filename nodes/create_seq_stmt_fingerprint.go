@@ -18,7 +18,7 @@ func (node CreateSeqStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node CreateSeqStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Sequence != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Sequence.Fingerprint(&subCtx, node, "Sequence")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node CreateStatsStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("CreateStatsStmt")
 
 	if len(node.Defnames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Defnames.Fingerprint(&subCtx, node, "Defnames")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node CreateStatsStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Exprs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Exprs.Fingerprint(&subCtx, node, "Exprs")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node CreateStatsStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Relations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relations.Fingerprint(&subCtx, node, "Relations")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node CreateStatsStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.StatTypes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.StatTypes.Fingerprint(&subCtx, node, "StatTypes")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node CreateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("CreateStmt")
 
 	if len(node.Constraints.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Constraints.Fingerprint(&subCtx, node, "Constraints")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node CreateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.InhRelations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.InhRelations.Fingerprint(&subCtx, node, "InhRelations")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node CreateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.OfTypename != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OfTypename.Fingerprint(&subCtx, node, "OfTypename")
 
 		if len(subCtx.parts) > 0 {
@@ -54,7 +54,7 @@ func (node CreateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -66,7 +66,7 @@ func (node CreateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Partbound != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Partbound.Fingerprint(&subCtx, node, "Partbound")
 
 		if len(subCtx.parts) > 0 {
@@ -78,7 +78,7 @@ func (node CreateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Partspec != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Partspec.Fingerprint(&subCtx, node, "Partspec")
 
 		if len(subCtx.parts) > 0 {
@@ -90,7 +90,7 @@ func (node CreateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -102,7 +102,7 @@ func (node CreateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.TableElts.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TableElts.Fingerprint(&subCtx, node, "TableElts")
 
 		if len(subCtx.parts) > 0 {
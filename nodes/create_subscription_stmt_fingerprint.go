@@ -11,7 +11,7 @@ func (node CreateSubscriptionStmt) Fingerprint(ctx FingerprintContext, parentNod
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node CreateSubscriptionStmt) Fingerprint(ctx FingerprintContext, parentNod
 	}
 
 	if len(node.Publication.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Publication.Fingerprint(&subCtx, node, "Publication")
 
 		if len(subCtx.parts) > 0 {
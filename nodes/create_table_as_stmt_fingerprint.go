@@ -13,7 +13,7 @@ func (node CreateTableAsStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Into != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Into.Fingerprint(&subCtx, node, "Into")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node CreateTableAsStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Query != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Query.Fingerprint(&subCtx, node, "Query")
 
 		if len(subCtx.parts) > 0 {
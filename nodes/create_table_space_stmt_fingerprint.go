@@ -7,7 +7,7 @@ func (node CreateTableSpaceStmt) Fingerprint(ctx FingerprintContext, parentNode
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -19,7 +19,7 @@ func (node CreateTableSpaceStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if node.Owner != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Owner.Fingerprint(&subCtx, node, "Owner")
 
 		if len(subCtx.parts) > 0 {
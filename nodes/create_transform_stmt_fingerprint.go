@@ -8,7 +8,7 @@ func (node CreateTransformStmt) Fingerprint(ctx FingerprintContext, parentNode N
 	ctx.WriteString("CreateTransformStmt")
 
 	if node.Fromsql != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Fromsql.Fingerprint(&subCtx, node, "Fromsql")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node CreateTransformStmt) Fingerprint(ctx FingerprintContext, parentNode N
 	}
 
 	if node.Tosql != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Tosql.Fingerprint(&subCtx, node, "Tosql")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node CreateTransformStmt) Fingerprint(ctx FingerprintContext, parentNode N
 	}
 
 	if node.TypeName != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
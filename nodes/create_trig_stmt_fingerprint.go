@@ -8,7 +8,7 @@ func (node CreateTrigStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("CreateTrigStmt")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node CreateTrigStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Columns.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Columns.Fingerprint(&subCtx, node, "Columns")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node CreateTrigStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Constrrel != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Constrrel.Fingerprint(&subCtx, node, "Constrrel")
 
 		if len(subCtx.parts) > 0 {
@@ -54,7 +54,7 @@ func (node CreateTrigStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Funcname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funcname.Fingerprint(&subCtx, node, "Funcname")
 
 		if len(subCtx.parts) > 0 {
@@ -76,7 +76,7 @@ func (node CreateTrigStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -98,7 +98,7 @@ func (node CreateTrigStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.TransitionRels.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TransitionRels.Fingerprint(&subCtx, node, "TransitionRels")
 
 		if len(subCtx.parts) > 0 {
@@ -115,7 +115,7 @@ func (node CreateTrigStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.WhenClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WhenClause.Fingerprint(&subCtx, node, "WhenClause")
 
 		if len(subCtx.parts) > 0 {
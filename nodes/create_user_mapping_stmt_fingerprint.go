@@ -13,7 +13,7 @@ func (node CreateUserMappingStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node CreateUserMappingStmt) Fingerprint(ctx FingerprintContext, parentNode
 	}
 
 	if node.User != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.User.Fingerprint(&subCtx, node, "User")
 
 		if len(subCtx.parts) > 0 {
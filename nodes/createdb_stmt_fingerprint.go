@@ -11,7 +11,7 @@ func (node CreatedbStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node CurrentOfExpr) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
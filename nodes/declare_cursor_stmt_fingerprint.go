@@ -15,7 +15,7 @@ func (node DeclareCursorStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	// Intentionally ignoring node.Portalname for fingerprinting
 
 	if node.Query != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Query.Fingerprint(&subCtx, node, "Query")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node DefElem) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	ctx.WriteString("DefElem")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
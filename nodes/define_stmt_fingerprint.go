@@ -8,7 +8,7 @@ func (node DefineStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("DefineStmt")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node DefineStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Definition.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Definition.Fingerprint(&subCtx, node, "Definition")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node DefineStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Defnames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Defnames.Fingerprint(&subCtx, node, "Defnames")
 
 		if len(subCtx.parts) > 0 {
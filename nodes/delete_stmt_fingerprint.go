@@ -6,7 +6,7 @@ func (node DeleteStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("DeleteStmt")
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node DeleteStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.ReturningList.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ReturningList.Fingerprint(&subCtx, node, "ReturningList")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node DeleteStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.UsingClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.UsingClause.Fingerprint(&subCtx, node, "UsingClause")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node DeleteStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.WhereClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WhereClause.Fingerprint(&subCtx, node, "WhereClause")
 
 		if len(subCtx.parts) > 0 {
@@ -54,7 +54,7 @@ func (node DeleteStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.WithClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WithClause.Fingerprint(&subCtx, node, "WithClause")
 
 		if len(subCtx.parts) > 0 {
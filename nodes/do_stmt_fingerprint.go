@@ -6,7 +6,7 @@ func (node DoStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	ctx.WriteString("DoStmt")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -13,7 +13,7 @@ func (node DropOwnedStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Roles.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Roles.Fingerprint(&subCtx, node, "Roles")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node DropStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Objects.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Objects.Fingerprint(&subCtx, node, "Objects")
 
 		if len(subCtx.parts) > 0 {
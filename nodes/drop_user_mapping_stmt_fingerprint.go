@@ -18,7 +18,7 @@ func (node DropUserMappingStmt) Fingerprint(ctx FingerprintContext, parentNode N
 	}
 
 	if node.User != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.User.Fingerprint(&subCtx, node, "User")
 
 		if len(subCtx.parts) > 0 {
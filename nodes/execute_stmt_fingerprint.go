@@ -7,7 +7,7 @@ func (node ExecuteStmt) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	// Intentionally ignoring node.Name for fingerprinting
 
 	if len(node.Params.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Params.Fingerprint(&subCtx, node, "Params")
 
 		if len(subCtx.parts) > 0 {
@@ -6,7 +6,7 @@ func (node ExplainStmt) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	ctx.WriteString("ExplainStmt")
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node ExplainStmt) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	}
 
 	if node.Query != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Query.Fingerprint(&subCtx, node, "Query")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node FieldSelect) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	ctx.WriteString("FieldSelect")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -40,7 +40,7 @@ func (node FieldSelect) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
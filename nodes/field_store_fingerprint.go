@@ -8,7 +8,7 @@ func (node FieldStore) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("FieldStore")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node FieldStore) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Fieldnums.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Fieldnums.Fingerprint(&subCtx, node, "Fieldnums")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node FieldStore) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Newvals.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Newvals.Fingerprint(&subCtx, node, "Newvals")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node FieldStore) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -0,0 +1,129 @@
+package pg_query
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FingerprintToken is a single fingerprint part, annotated with the node that wrote it
+type FingerprintToken struct {
+	Value string // the fingerprint part itself
+	Node  string // type of the node that wrote the part, e.g. "ResTarget"
+	Path  string // JSON field path of that node, e.g. "stmt.targetList[0].val"
+}
+
+func (token FingerprintToken) String() string {
+	if token.Path == "" {
+		return fmt.Sprintf("%s (%s)", token.Value, token.Node)
+	}
+	return fmt.Sprintf("%s (%s at %s)", token.Value, token.Node, token.Path)
+}
+
+// FingerprintTokens returns the fingerprint parts of node in order, each
+// annotated with the (sub)node it belongs to
+func FingerprintTokens(node Node) []FingerprintToken {
+	node = derefNode(node)
+	if node == nil {
+		return nil
+	}
+	return annotateFingerprint(node, nil, "", "", 0)
+}
+
+// annotateFingerprint fingerprints node and then locates the parts written by
+// each of its children within the result, recursively. Children that were
+// reordered (e.g. sorted target lists) are found by searching the whole part
+// list, children that were deduplicated away are skipped.
+func annotateFingerprint(node Node, parentNode Node, parentFieldName string, path string, depth int) []FingerprintToken {
+	subCtx := FingerprintSubContext{depth: depth}
+	node.Fingerprint(&subCtx, parentNode, parentFieldName)
+
+	nodeName := reflect.TypeOf(node).Name()
+	tokens := make([]FingerprintToken, len(subCtx.parts))
+	for i, part := range subCtx.parts {
+		tokens[i] = FingerprintToken{Value: part, Node: nodeName, Path: path}
+	}
+
+	claimed := make([]bool, len(tokens))
+	cursor := 0
+	if _, ok := node.(List); !ok && len(tokens) > 0 {
+		// The first part is the type name of the node itself, which a child
+		// cut off by the depth limit right after its own type name could
+		// otherwise be matched to
+		claimed[0] = true
+		cursor = 1
+	}
+	for _, child := range fingerprintChildren(node, parentNode, parentFieldName, path) {
+		childTokens := annotateFingerprint(child.node, child.parentNode, child.parentFieldName, child.path, depth+child.depth)
+		if len(childTokens) == 0 {
+			continue
+		}
+
+		pos := findFingerprintTokens(tokens, claimed, childTokens, cursor)
+		if pos < 0 {
+			pos = findFingerprintTokens(tokens, claimed, childTokens, 0)
+		}
+		if pos < 0 {
+			continue
+		}
+
+		for i, childToken := range childTokens {
+			tokens[pos+i] = childToken
+			claimed[pos+i] = true
+		}
+		cursor = pos + len(childTokens)
+	}
+
+	return tokens
+}
+
+type fingerprintChild struct {
+	node            Node
+	parentNode      Node
+	parentFieldName string
+	path            string
+	depth           int // nesting below the node, as counted by the generated Fingerprint methods
+}
+
+func fingerprintChildren(node Node, parentNode Node, parentFieldName string, path string) []fingerprintChild {
+	// Lists pass their own parent through to their items
+	if list, ok := node.(List); ok {
+		children := []fingerprintChild{}
+		for i, item := range list.Items {
+			if item != nil {
+				children = append(children, fingerprintChild{derefNode(item), parentNode, parentFieldName, fmt.Sprintf("%s[%d]", path, i), 1})
+			}
+		}
+		return children
+	}
+
+	children := []fingerprintChild{}
+	for _, field := range Children(node) {
+		childPath := field.JSONName
+		if path != "" {
+			childPath = path + "." + childPath
+		}
+		for _, i := range field.Index {
+			childPath += fmt.Sprintf("[%d]", i)
+		}
+		// List fields are fingerprinted through a List (and a List per row
+		// of VALUES lists), each adding a level of nesting
+		children = append(children, fingerprintChild{field.Node, node, field.Name, childPath, 1 + len(field.Index)})
+	}
+	return children
+}
+
+func findFingerprintTokens(tokens []FingerprintToken, claimed []bool, needle []FingerprintToken, start int) int {
+	for pos := start; pos+len(needle) <= len(tokens); pos++ {
+		found := true
+		for i := range needle {
+			if claimed[pos+i] || tokens[pos+i].Value != needle[i].Value {
+				found = false
+				break
+			}
+		}
+		if found {
+			return pos
+		}
+	}
+	return -1
+}
@@ -3,8 +3,8 @@
 package pg_query
 
 func (node Float) Fingerprint(ctx FingerprintContext, parentNode Node, parentFieldName string) {
-	ctx.WriteString("Float")
 	if len(node.Str) > 0 {
+		ctx.WriteString("Float")
 		ctx.WriteString("str")
 		ctx.WriteString(node.Str)
 	}
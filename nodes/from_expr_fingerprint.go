@@ -6,7 +6,7 @@ func (node FromExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("FromExpr")
 
 	if len(node.Fromlist.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Fromlist.Fingerprint(&subCtx, node, "Fromlist")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node FromExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Quals != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Quals.Fingerprint(&subCtx, node, "Quals")
 
 		if len(subCtx.parts) > 0 {
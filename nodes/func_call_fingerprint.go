@@ -13,7 +13,7 @@ func (node FuncCall) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.AggFilter != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.AggFilter.Fingerprint(&subCtx, node, "AggFilter")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node FuncCall) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.AggOrder.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.AggOrder.Fingerprint(&subCtx, node, "AggOrder")
 
 		if len(subCtx.parts) > 0 {
@@ -47,7 +47,7 @@ func (node FuncCall) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -64,7 +64,7 @@ func (node FuncCall) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Funcname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funcname.Fingerprint(&subCtx, node, "Funcname")
 
 		if len(subCtx.parts) > 0 {
@@ -77,7 +77,7 @@ func (node FuncCall) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Over != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Over.Fingerprint(&subCtx, node, "Over")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node FuncExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("FuncExpr")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -57,7 +57,7 @@ func (node FuncExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node FunctionParameter) Fingerprint(ctx FingerprintContext, parentNode Nod
 	ctx.WriteString("FunctionParameter")
 
 	if node.ArgType != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ArgType.Fingerprint(&subCtx, node, "ArgType")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node FunctionParameter) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Defexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Defexpr.Fingerprint(&subCtx, node, "Defexpr")
 
 		if len(subCtx.parts) > 0 {
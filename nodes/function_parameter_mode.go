@@ -6,9 +6,9 @@ type FunctionParameterMode uint
 
 const (
 	/* the assigned enum values appear in pg_proc, don't change 'em! */
-	FUNC_PARAM_IN FunctionParameterMode = iota
-	FUNC_PARAM_OUT
-	FUNC_PARAM_INOUT
-	FUNC_PARAM_VARIADIC
-	FUNC_PARAM_TABLE
+	FUNC_PARAM_IN       FunctionParameterMode = 'i'
+	FUNC_PARAM_OUT      FunctionParameterMode = 'o'
+	FUNC_PARAM_INOUT    FunctionParameterMode = 'b'
+	FUNC_PARAM_VARIADIC FunctionParameterMode = 'v'
+	FUNC_PARAM_TABLE    FunctionParameterMode = 't'
 )
@@ -18,7 +18,7 @@ func (node GrantRoleStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.GrantedRoles.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.GrantedRoles.Fingerprint(&subCtx, node, "GrantedRoles")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node GrantRoleStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.GranteeRoles.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.GranteeRoles.Fingerprint(&subCtx, node, "GranteeRoles")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node GrantRoleStmt) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Grantor != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Grantor.Fingerprint(&subCtx, node, "Grantor")
 
 		if len(subCtx.parts) > 0 {
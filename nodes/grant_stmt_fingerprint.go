@@ -18,7 +18,7 @@ func (node GrantStmt) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Grantees.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Grantees.Fingerprint(&subCtx, node, "Grantees")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node GrantStmt) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Objects.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Objects.Fingerprint(&subCtx, node, "Objects")
 
 		if len(subCtx.parts) > 0 {
@@ -52,7 +52,7 @@ func (node GrantStmt) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Privileges.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Privileges.Fingerprint(&subCtx, node, "Privileges")
 
 		if len(subCtx.parts) > 0 {
@@ -13,7 +13,7 @@ func (node GroupingFunc) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node GroupingFunc) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if len(node.Cols.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Cols.Fingerprint(&subCtx, node, "Cols")
 
 		if len(subCtx.parts) > 0 {
@@ -38,7 +38,7 @@ func (node GroupingFunc) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.Refs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Refs.Fingerprint(&subCtx, node, "Refs")
 
 		if len(subCtx.parts) > 0 {
@@ -50,7 +50,7 @@ func (node GroupingFunc) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node GroupingSet) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	ctx.WriteString("GroupingSet")
 
 	if len(node.Content.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Content.Fingerprint(&subCtx, node, "Content")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node ImportForeignSchemaStmt) Fingerprint(ctx FingerprintContext, parentNo
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -40,7 +40,7 @@ func (node ImportForeignSchemaStmt) Fingerprint(ctx FingerprintContext, parentNo
 	}
 
 	if len(node.TableList.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TableList.Fingerprint(&subCtx, node, "TableList")
 
 		if len(subCtx.parts) > 0 {
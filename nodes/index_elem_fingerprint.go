@@ -8,7 +8,7 @@ func (node IndexElem) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	ctx.WriteString("IndexElem")
 
 	if len(node.Collation.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Collation.Fingerprint(&subCtx, node, "Collation")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node IndexElem) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.Expr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Expr.Fingerprint(&subCtx, node, "Expr")
 
 		if len(subCtx.parts) > 0 {
@@ -47,7 +47,7 @@ func (node IndexElem) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Opclass.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opclass.Fingerprint(&subCtx, node, "Opclass")
 
 		if len(subCtx.parts) > 0 {
@@ -23,7 +23,7 @@ func (node IndexStmt) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.ExcludeOpNames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ExcludeOpNames.Fingerprint(&subCtx, node, "ExcludeOpNames")
 
 		if len(subCtx.parts) > 0 {
@@ -55,7 +55,7 @@ func (node IndexStmt) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.IndexParams.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.IndexParams.Fingerprint(&subCtx, node, "IndexParams")
 
 		if len(subCtx.parts) > 0 {
@@ -82,7 +82,7 @@ func (node IndexStmt) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -99,7 +99,7 @@ func (node IndexStmt) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -126,7 +126,7 @@ func (node IndexStmt) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.WhereClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WhereClause.Fingerprint(&subCtx, node, "WhereClause")
 
 		if len(subCtx.parts) > 0 {
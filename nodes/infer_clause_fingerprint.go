@@ -11,7 +11,7 @@ func (node InferClause) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	}
 
 	if len(node.IndexElems.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.IndexElems.Fingerprint(&subCtx, node, "IndexElems")
 
 		if len(subCtx.parts) > 0 {
@@ -24,7 +24,7 @@ func (node InferClause) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.WhereClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WhereClause.Fingerprint(&subCtx, node, "WhereClause")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node InferenceElem) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	ctx.WriteString("InferenceElem")
 
 	if node.Expr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Expr.Fingerprint(&subCtx, node, "Expr")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node InferenceElem) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node InsertStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("InsertStmt")
 
 	if len(node.Cols.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Cols.Fingerprint(&subCtx, node, "Cols")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node InsertStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.OnConflictClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OnConflictClause.Fingerprint(&subCtx, node, "OnConflictClause")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node InsertStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node InsertStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.ReturningList.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ReturningList.Fingerprint(&subCtx, node, "ReturningList")
 
 		if len(subCtx.parts) > 0 {
@@ -61,7 +61,7 @@ func (node InsertStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.SelectStmt != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.SelectStmt.Fingerprint(&subCtx, node, "SelectStmt")
 
 		if len(subCtx.parts) > 0 {
@@ -73,7 +73,7 @@ func (node InsertStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.WithClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WithClause.Fingerprint(&subCtx, node, "WithClause")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node IntoClause) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("IntoClause")
 
 	if len(node.ColNames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ColNames.Fingerprint(&subCtx, node, "ColNames")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node IntoClause) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node IntoClause) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Rel != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rel.Fingerprint(&subCtx, node, "Rel")
 
 		if len(subCtx.parts) > 0 {
@@ -59,7 +59,7 @@ func (node IntoClause) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.ViewQuery != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ViewQuery.Fingerprint(&subCtx, node, "ViewQuery")
 
 		if len(subCtx.parts) > 0 {
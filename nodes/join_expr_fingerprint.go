@@ -8,7 +8,7 @@ func (node JoinExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("JoinExpr")
 
 	if node.Alias != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Alias.Fingerprint(&subCtx, node, "Alias")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node JoinExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Larg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Larg.Fingerprint(&subCtx, node, "Larg")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node JoinExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Quals != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Quals.Fingerprint(&subCtx, node, "Quals")
 
 		if len(subCtx.parts) > 0 {
@@ -54,7 +54,7 @@ func (node JoinExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Rarg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rarg.Fingerprint(&subCtx, node, "Rarg")
 
 		if len(subCtx.parts) > 0 {
@@ -71,7 +71,7 @@ func (node JoinExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.UsingClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.UsingClause.Fingerprint(&subCtx, node, "UsingClause")
 
 		if len(subCtx.parts) > 0 {
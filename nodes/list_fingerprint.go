@@ -5,12 +5,12 @@ package pg_query
 import "sort"
 
 func (node List) Fingerprint(ctx FingerprintContext, parentNode Node, parentFieldName string) {
-	if parentFieldName == "FromClause" || parentFieldName == "TargetList" || parentFieldName == "Cols" || parentFieldName == "Rexpr" {
+	if parentFieldName == "FromClause" || (parentFieldName == "TargetList" && !fingerprintOptions(ctx).TargetListOrder) || parentFieldName == "Cols" || parentFieldName == "Rexpr" || parentFieldName == "ValuesLists" {
 		var itemsFingerprints FingerprintSubContextSlice
 
 		for _, subNode := range node.Items {
 			if subNode != nil {
-				subCtx := FingerprintSubContext{}
+				subCtx := newFingerprintSubContext(ctx)
 				subNode.Fingerprint(&subCtx, parentNode, parentFieldName)
 				itemsFingerprints.AddIfUnique(subCtx)
 			}
@@ -24,10 +24,15 @@ func (node List) Fingerprint(ctx FingerprintContext, parentNode Node, parentFiel
 			}
 		}
 	} else {
+		subCtx := newFingerprintSubContext(ctx)
 		for _, subNode := range node.Items {
 			if subNode != nil {
-				subNode.Fingerprint(ctx, parentNode, parentFieldName)
+				subNode.Fingerprint(&subCtx, parentNode, parentFieldName)
 			}
 		}
+
+		for _, part := range subCtx.parts {
+			ctx.WriteString(part)
+		}
 	}
 }
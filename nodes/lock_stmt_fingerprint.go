@@ -18,7 +18,7 @@ func (node LockStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Relations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relations.Fingerprint(&subCtx, node, "Relations")
 
 		if len(subCtx.parts) > 0 {
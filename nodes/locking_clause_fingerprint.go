@@ -8,7 +8,7 @@ func (node LockingClause) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	ctx.WriteString("LockingClause")
 
 	if len(node.LockedRels.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.LockedRels.Fingerprint(&subCtx, node, "LockedRels")
 
 		if len(subCtx.parts) > 0 {
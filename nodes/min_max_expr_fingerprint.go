@@ -8,7 +8,7 @@ func (node MinMaxExpr) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("MinMaxExpr")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node MinMaxExpr) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
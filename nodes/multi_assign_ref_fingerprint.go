@@ -18,7 +18,7 @@ func (node MultiAssignRef) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Source != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Source.Fingerprint(&subCtx, node, "Source")
 
 		if len(subCtx.parts) > 0 {
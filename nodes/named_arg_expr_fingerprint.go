@@ -8,7 +8,7 @@ func (node NamedArgExpr) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	ctx.WriteString("NamedArgExpr")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node NamedArgExpr) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node NextValueExpr) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -2,24 +2,45 @@ package pg_query
 
 import (
 	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
 	"hash"
 	"io"
 	"reflect"
 	"strings"
 )
 
+// FingerprintVersion is encoded as the first byte of every hex fingerprint
+const FingerprintVersion uint = 2
+
 type FingerprintContext interface {
 	WriteString(string)
 }
 
+// FingerprintOptions control which differences between two queries are
+// significant for their fingerprints. The zero value matches FastFingerprint.
+type FingerprintOptions struct {
+	IgnoreSchema        bool // "s.t" and "t" fingerprint the same
+	TargetListOrder     bool // SELECT a, b and SELECT b, a fingerprint differently
+	InListLength        bool // IN (1, 2) and IN (1, 2, 3) fingerprint differently
+	LimitConstants      bool // LIMIT 10 and LIMIT 20 fingerprint differently
+	IgnoreSortDirection bool // ORDER BY a ASC and ORDER BY a DESC fingerprint the same
+}
+
 type FingerprintHashContext struct {
-	hash hash.Hash
+	hash    hash.Hash
+	options FingerprintOptions
 }
 
 func NewFingerprintHashContext() *FingerprintHashContext {
 	return &FingerprintHashContext{hash: sha1.New()}
 }
 
+func NewFingerprintHashContextWithOptions(options FingerprintOptions) *FingerprintHashContext {
+	return &FingerprintHashContext{hash: sha1.New(), options: options}
+}
+
 func (ctx FingerprintHashContext) WriteString(str string) {
 	io.WriteString(ctx.hash, str)
 }
@@ -28,10 +49,42 @@ func (ctx FingerprintHashContext) Sum() []byte {
 	return ctx.hash.Sum(nil)
 }
 
+// FingerprintNode returns the fingerprint of a single node and its children,
+// e.g. a WHERE clause, a subquery or a function call, in the same format as
+// the fingerprint of a whole statement. Constants and other fields that are
+// ignored for statements are ignored here too, so identical expressions get
+// identical fingerprints regardless of the query they appear in.
+func FingerprintNode(node Node) string {
+	return fmt.Sprintf("%02x%s", FingerprintVersion, hex.EncodeToString(fingerprintNodeSum(node)))
+}
+
+// FingerprintNodeUint64 - Like FingerprintNode, but returns the first 8 bytes
+// of the hash as a number, e.g. for use as a map key
+func FingerprintNodeUint64(node Node) uint64 {
+	return binary.BigEndian.Uint64(fingerprintNodeSum(node))
+}
+
+func fingerprintNodeSum(node Node) []byte {
+	ctx := NewFingerprintHashContext()
+	if node = derefNode(node); node != nil {
+		node.Fingerprint(ctx, nil, "")
+	}
+	return ctx.Sum()
+}
+
 // ...
 
+// The C implementation (pg_query_fingerprint.c) cuts off overly complex parse
+// trees at this depth, and only compares the first bytes of list items when
+// sorting them - both are mirrored here so that Fingerprint and FastFingerprint
+// always agree
+const fingerprintMaxDepth = 100
+const fingerprintCompareLength = 1024
+
 type FingerprintSubContext struct {
-	parts []string
+	parts   []string
+	depth   int // nesting below the top-level statements
+	options FingerprintOptions
 }
 
 type FingerprintSubContextSlice []FingerprintSubContext
@@ -40,7 +93,32 @@ func NewFingerprintSubContext() *FingerprintSubContext {
 	return &FingerprintSubContext{parts: []string{}}
 }
 
+// newFingerprintSubContext returns a context for the children of nodes that
+// are being fingerprinted into ctx
+func newFingerprintSubContext(ctx FingerprintContext) FingerprintSubContext {
+	if subCtx, ok := ctx.(*FingerprintSubContext); ok {
+		return FingerprintSubContext{depth: subCtx.depth + 1, options: subCtx.options}
+	}
+	return FingerprintSubContext{depth: 1, options: fingerprintOptions(ctx)}
+}
+
+func fingerprintOptions(ctx FingerprintContext) FingerprintOptions {
+	switch ctx := ctx.(type) {
+	case *FingerprintSubContext:
+		return ctx.options
+	case *FingerprintHashContext:
+		return ctx.options
+	case FingerprintHashContext:
+		return ctx.options
+	}
+	return FingerprintOptions{}
+}
+
 func (ctx *FingerprintSubContext) WriteString(str string) {
+	// Statements are at depth 1 in the C implementation
+	if ctx.depth+1 >= fingerprintMaxDepth {
+		return
+	}
 	ctx.parts = append(ctx.parts, str)
 }
 
@@ -49,17 +127,23 @@ func (ctx FingerprintSubContext) Sum() []string {
 }
 
 func (ctx FingerprintSubContext) Equal(other FingerprintSubContext) bool {
-	return reflect.DeepEqual(ctx, other)
+	return reflect.DeepEqual(ctx.parts, other.parts)
 }
 
 func (p FingerprintSubContextSlice) Len() int {
 	return len(p)
 }
 
+func (ctx FingerprintSubContext) compareKey() string {
+	key := strings.Join(ctx.parts, "")
+	if len(key) > fingerprintCompareLength {
+		key = key[:fingerprintCompareLength]
+	}
+	return key
+}
+
 func (p FingerprintSubContextSlice) Less(i, j int) bool {
-	left := strings.Join(p[i].parts, "")
-	right := strings.Join(p[j].parts, "")
-	return left < right
+	return p[i].compareKey() < p[j].compareKey()
 }
 
 func (p FingerprintSubContextSlice) Swap(i, j int) {
@@ -67,8 +151,9 @@ func (p FingerprintSubContextSlice) Swap(i, j int) {
 }
 
 func (p *FingerprintSubContextSlice) AddIfUnique(ctx FingerprintSubContext) {
+	key := ctx.compareKey()
 	for _, existing := range *p {
-		if ctx.Equal(existing) {
+		if key == existing.compareKey() {
 			return
 		}
 	}
@@ -13,7 +13,7 @@ func (node ObjectWithArgs) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Objargs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Objargs.Fingerprint(&subCtx, node, "Objargs")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node ObjectWithArgs) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Objname.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Objname.Fingerprint(&subCtx, node, "Objname")
 
 		if len(subCtx.parts) > 0 {
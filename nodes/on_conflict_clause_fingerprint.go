@@ -13,7 +13,7 @@ func (node OnConflictClause) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Infer != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Infer.Fingerprint(&subCtx, node, "Infer")
 
 		if len(subCtx.parts) > 0 {
@@ -26,7 +26,7 @@ func (node OnConflictClause) Fingerprint(ctx FingerprintContext, parentNode Node
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.TargetList.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TargetList.Fingerprint(&subCtx, node, "TargetList")
 
 		if len(subCtx.parts) > 0 {
@@ -38,7 +38,7 @@ func (node OnConflictClause) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.WhereClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WhereClause.Fingerprint(&subCtx, node, "WhereClause")
 
 		if len(subCtx.parts) > 0 {
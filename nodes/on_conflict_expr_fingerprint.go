@@ -13,7 +13,7 @@ func (node OnConflictExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.ArbiterElems.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ArbiterElems.Fingerprint(&subCtx, node, "ArbiterElems")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node OnConflictExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.ArbiterWhere != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ArbiterWhere.Fingerprint(&subCtx, node, "ArbiterWhere")
 
 		if len(subCtx.parts) > 0 {
@@ -47,7 +47,7 @@ func (node OnConflictExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.ExclRelTlist.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ExclRelTlist.Fingerprint(&subCtx, node, "ExclRelTlist")
 
 		if len(subCtx.parts) > 0 {
@@ -59,7 +59,7 @@ func (node OnConflictExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.OnConflictSet.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OnConflictSet.Fingerprint(&subCtx, node, "OnConflictSet")
 
 		if len(subCtx.parts) > 0 {
@@ -71,7 +71,7 @@ func (node OnConflictExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.OnConflictWhere != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OnConflictWhere.Fingerprint(&subCtx, node, "OnConflictWhere")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node OpExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	ctx.WriteString("OpExpr")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -52,7 +52,7 @@ func (node OpExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
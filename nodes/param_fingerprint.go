@@ -34,7 +34,7 @@ func (node Param) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
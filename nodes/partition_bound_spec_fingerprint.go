@@ -6,7 +6,7 @@ func (node PartitionBoundSpec) Fingerprint(ctx FingerprintContext, parentNode No
 	ctx.WriteString("PartitionBoundSpec")
 
 	if len(node.Listdatums.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Listdatums.Fingerprint(&subCtx, node, "Listdatums")
 
 		if len(subCtx.parts) > 0 {
@@ -19,7 +19,7 @@ func (node PartitionBoundSpec) Fingerprint(ctx FingerprintContext, parentNode No
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.Lowerdatums.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Lowerdatums.Fingerprint(&subCtx, node, "Lowerdatums")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node PartitionBoundSpec) Fingerprint(ctx FingerprintContext, parentNode No
 	}
 
 	if len(node.Upperdatums.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Upperdatums.Fingerprint(&subCtx, node, "Upperdatums")
 
 		if len(subCtx.parts) > 0 {
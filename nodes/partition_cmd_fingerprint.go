@@ -6,7 +6,7 @@ func (node PartitionCmd) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	ctx.WriteString("PartitionCmd")
 
 	if node.Bound != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Bound.Fingerprint(&subCtx, node, "Bound")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node PartitionCmd) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if node.Name != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Name.Fingerprint(&subCtx, node, "Name")
 
 		if len(subCtx.parts) > 0 {
@@ -6,7 +6,7 @@ func (node PartitionElem) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	ctx.WriteString("PartitionElem")
 
 	if len(node.Collation.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Collation.Fingerprint(&subCtx, node, "Collation")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node PartitionElem) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Expr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Expr.Fingerprint(&subCtx, node, "Expr")
 
 		if len(subCtx.parts) > 0 {
@@ -36,7 +36,7 @@ func (node PartitionElem) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Opclass.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opclass.Fingerprint(&subCtx, node, "Opclass")
 
 		if len(subCtx.parts) > 0 {
@@ -15,7 +15,7 @@ func (node PartitionRangeDatum) Fingerprint(ctx FingerprintContext, parentNode N
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Value != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Value.Fingerprint(&subCtx, node, "Value")
 
 		if len(subCtx.parts) > 0 {
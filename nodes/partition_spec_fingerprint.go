@@ -7,7 +7,7 @@ func (node PartitionSpec) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.PartParams.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.PartParams.Fingerprint(&subCtx, node, "PartParams")
 
 		if len(subCtx.parts) > 0 {
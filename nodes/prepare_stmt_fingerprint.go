@@ -6,7 +6,7 @@ func (node PrepareStmt) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	ctx.WriteString("PrepareStmt")
 
 	if len(node.Argtypes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Argtypes.Fingerprint(&subCtx, node, "Argtypes")
 
 		if len(subCtx.parts) > 0 {
@@ -19,7 +19,7 @@ func (node PrepareStmt) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	// Intentionally ignoring node.Name for fingerprinting
 
 	if node.Query != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Query.Fingerprint(&subCtx, node, "Query")
 
 		if len(subCtx.parts) > 0 {
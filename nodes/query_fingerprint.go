@@ -18,7 +18,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.ConstraintDeps.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ConstraintDeps.Fingerprint(&subCtx, node, "ConstraintDeps")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.CteList.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.CteList.Fingerprint(&subCtx, node, "CteList")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.DistinctClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.DistinctClause.Fingerprint(&subCtx, node, "DistinctClause")
 
 		if len(subCtx.parts) > 0 {
@@ -54,7 +54,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.GroupClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.GroupClause.Fingerprint(&subCtx, node, "GroupClause")
 
 		if len(subCtx.parts) > 0 {
@@ -66,7 +66,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.GroupingSets.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.GroupingSets.Fingerprint(&subCtx, node, "GroupingSets")
 
 		if len(subCtx.parts) > 0 {
@@ -123,7 +123,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if node.HavingQual != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.HavingQual.Fingerprint(&subCtx, node, "HavingQual")
 
 		if len(subCtx.parts) > 0 {
@@ -135,7 +135,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if node.Jointree != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Jointree.Fingerprint(&subCtx, node, "Jointree")
 
 		if len(subCtx.parts) > 0 {
@@ -147,7 +147,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if node.LimitCount != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.LimitCount.Fingerprint(&subCtx, node, "LimitCount")
 
 		if len(subCtx.parts) > 0 {
@@ -159,7 +159,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if node.LimitOffset != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.LimitOffset.Fingerprint(&subCtx, node, "LimitOffset")
 
 		if len(subCtx.parts) > 0 {
@@ -171,7 +171,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if node.OnConflict != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OnConflict.Fingerprint(&subCtx, node, "OnConflict")
 
 		if len(subCtx.parts) > 0 {
@@ -203,7 +203,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.ReturningList.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ReturningList.Fingerprint(&subCtx, node, "ReturningList")
 
 		if len(subCtx.parts) > 0 {
@@ -215,7 +215,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.RowMarks.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.RowMarks.Fingerprint(&subCtx, node, "RowMarks")
 
 		if len(subCtx.parts) > 0 {
@@ -227,7 +227,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.Rtable.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rtable.Fingerprint(&subCtx, node, "Rtable")
 
 		if len(subCtx.parts) > 0 {
@@ -239,7 +239,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if node.SetOperations != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.SetOperations.Fingerprint(&subCtx, node, "SetOperations")
 
 		if len(subCtx.parts) > 0 {
@@ -251,7 +251,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.SortClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.SortClause.Fingerprint(&subCtx, node, "SortClause")
 
 		if len(subCtx.parts) > 0 {
@@ -273,7 +273,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.TargetList.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TargetList.Fingerprint(&subCtx, node, "TargetList")
 
 		if len(subCtx.parts) > 0 {
@@ -285,7 +285,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if node.UtilityStmt != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.UtilityStmt.Fingerprint(&subCtx, node, "UtilityStmt")
 
 		if len(subCtx.parts) > 0 {
@@ -297,7 +297,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.WindowClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WindowClause.Fingerprint(&subCtx, node, "WindowClause")
 
 		if len(subCtx.parts) > 0 {
@@ -309,7 +309,7 @@ func (node Query) Fingerprint(ctx FingerprintContext, parentNode Node, parentFie
 	}
 
 	if len(node.WithCheckOptions.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WithCheckOptions.Fingerprint(&subCtx, node, "WithCheckOptions")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node RangeFunction) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	ctx.WriteString("RangeFunction")
 
 	if node.Alias != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Alias.Fingerprint(&subCtx, node, "Alias")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node RangeFunction) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Coldeflist.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Coldeflist.Fingerprint(&subCtx, node, "Coldeflist")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node RangeFunction) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Functions.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Functions.Fingerprint(&subCtx, node, "Functions")
 
 		if len(subCtx.parts) > 0 {
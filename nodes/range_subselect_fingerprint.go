@@ -8,7 +8,7 @@ func (node RangeSubselect) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("RangeSubselect")
 
 	if node.Alias != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Alias.Fingerprint(&subCtx, node, "Alias")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node RangeSubselect) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Subquery != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Subquery.Fingerprint(&subCtx, node, "Subquery")
 
 		if len(subCtx.parts) > 0 {
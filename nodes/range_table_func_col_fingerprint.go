@@ -8,7 +8,7 @@ func (node RangeTableFuncCol) Fingerprint(ctx FingerprintContext, parentNode Nod
 	ctx.WriteString("RangeTableFuncCol")
 
 	if node.Coldefexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Coldefexpr.Fingerprint(&subCtx, node, "Coldefexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node RangeTableFuncCol) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Colexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Colexpr.Fingerprint(&subCtx, node, "Colexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node RangeTableFuncCol) Fingerprint(ctx FingerprintContext, parentNode Nod
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.TypeName != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
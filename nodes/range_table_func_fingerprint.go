@@ -8,7 +8,7 @@ func (node RangeTableFunc) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("RangeTableFunc")
 
 	if node.Alias != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Alias.Fingerprint(&subCtx, node, "Alias")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node RangeTableFunc) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Columns.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Columns.Fingerprint(&subCtx, node, "Columns")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node RangeTableFunc) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Docexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Docexpr.Fingerprint(&subCtx, node, "Docexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -51,7 +51,7 @@ func (node RangeTableFunc) Fingerprint(ctx FingerprintContext, parentNode Node,
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.Namespaces.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Namespaces.Fingerprint(&subCtx, node, "Namespaces")
 
 		if len(subCtx.parts) > 0 {
@@ -63,7 +63,7 @@ func (node RangeTableFunc) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Rowexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rowexpr.Fingerprint(&subCtx, node, "Rowexpr")
 
 		if len(subCtx.parts) > 0 {
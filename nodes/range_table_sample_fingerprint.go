@@ -6,7 +6,7 @@ func (node RangeTableSample) Fingerprint(ctx FingerprintContext, parentNode Node
 	ctx.WriteString("RangeTableSample")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -19,7 +19,7 @@ func (node RangeTableSample) Fingerprint(ctx FingerprintContext, parentNode Node
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.Method.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Method.Fingerprint(&subCtx, node, "Method")
 
 		if len(subCtx.parts) > 0 {
@@ -31,7 +31,7 @@ func (node RangeTableSample) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -43,7 +43,7 @@ func (node RangeTableSample) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Repeatable != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Repeatable.Fingerprint(&subCtx, node, "Repeatable")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	ctx.WriteString("RangeTblEntry")
 
 	if node.Alias != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Alias.Fingerprint(&subCtx, node, "Alias")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Colcollations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Colcollations.Fingerprint(&subCtx, node, "Colcollations")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Coltypes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Coltypes.Fingerprint(&subCtx, node, "Coltypes")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Coltypmods.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Coltypmods.Fingerprint(&subCtx, node, "Coltypmods")
 
 		if len(subCtx.parts) > 0 {
@@ -79,7 +79,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	ctx.WriteString(strconv.FormatFloat(float64(node.Enrtuples), 'E', -1, 64))
 
 	if node.Eref != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Eref.Fingerprint(&subCtx, node, "Eref")
 
 		if len(subCtx.parts) > 0 {
@@ -96,7 +96,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Functions.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Functions.Fingerprint(&subCtx, node, "Functions")
 
 		if len(subCtx.parts) > 0 {
@@ -123,7 +123,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.Joinaliasvars.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Joinaliasvars.Fingerprint(&subCtx, node, "Joinaliasvars")
 
 		if len(subCtx.parts) > 0 {
@@ -166,7 +166,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.SecurityQuals.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.SecurityQuals.Fingerprint(&subCtx, node, "SecurityQuals")
 
 		if len(subCtx.parts) > 0 {
@@ -193,7 +193,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Subquery != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Subquery.Fingerprint(&subCtx, node, "Subquery")
 
 		if len(subCtx.parts) > 0 {
@@ -205,7 +205,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Tablefunc != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Tablefunc.Fingerprint(&subCtx, node, "Tablefunc")
 
 		if len(subCtx.parts) > 0 {
@@ -217,7 +217,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if node.Tablesample != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Tablesample.Fingerprint(&subCtx, node, "Tablesample")
 
 		if len(subCtx.parts) > 0 {
@@ -233,7 +233,7 @@ func (node RangeTblEntry) Fingerprint(ctx FingerprintContext, parentNode Node, p
 	}
 
 	if len(node.ValuesLists.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ValuesLists.Fingerprint(&subCtx, node, "ValuesLists")
 
 		if len(subCtx.parts) > 0 {
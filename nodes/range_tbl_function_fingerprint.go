@@ -8,7 +8,7 @@ func (node RangeTblFunction) Fingerprint(ctx FingerprintContext, parentNode Node
 	ctx.WriteString("RangeTblFunction")
 
 	if len(node.Funccolcollations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funccolcollations.Fingerprint(&subCtx, node, "Funccolcollations")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node RangeTblFunction) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.Funccolnames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funccolnames.Fingerprint(&subCtx, node, "Funccolnames")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node RangeTblFunction) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.Funccoltypes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funccoltypes.Fingerprint(&subCtx, node, "Funccoltypes")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node RangeTblFunction) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.Funccoltypmods.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funccoltypmods.Fingerprint(&subCtx, node, "Funccoltypmods")
 
 		if len(subCtx.parts) > 0 {
@@ -61,7 +61,7 @@ func (node RangeTblFunction) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Funcexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Funcexpr.Fingerprint(&subCtx, node, "Funcexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node RangeVar) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("RangeVar")
 
 	if node.Alias != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Alias.Fingerprint(&subCtx, node, "Alias")
 
 		if len(subCtx.parts) > 0 {
@@ -42,7 +42,7 @@ func (node RangeVar) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 
 	}
 
-	if node.Schemaname != nil {
+	if node.Schemaname != nil && !fingerprintOptions(ctx).IgnoreSchema {
 		ctx.WriteString("schemaname")
 		ctx.WriteString(*node.Schemaname)
 	}
@@ -6,7 +6,7 @@ func (node RawStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	ctx.WriteString("RawStmt")
 
 	if node.Stmt != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Stmt.Fingerprint(&subCtx, node, "Stmt")
 
 		if len(subCtx.parts) > 0 {
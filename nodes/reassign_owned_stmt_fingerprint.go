@@ -6,7 +6,7 @@ func (node ReassignOwnedStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	ctx.WriteString("ReassignOwnedStmt")
 
 	if node.Newrole != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Newrole.Fingerprint(&subCtx, node, "Newrole")
 
 		if len(subCtx.parts) > 0 {
@@ -18,7 +18,7 @@ func (node ReassignOwnedStmt) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if len(node.Roles.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Roles.Fingerprint(&subCtx, node, "Roles")
 
 		if len(subCtx.parts) > 0 {
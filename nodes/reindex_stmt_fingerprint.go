@@ -23,7 +23,7 @@ func (node ReindexStmt) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node RelabelType) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	ctx.WriteString("RelabelType")
 
 	if node.Arg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Arg.Fingerprint(&subCtx, node, "Arg")
 
 		if len(subCtx.parts) > 0 {
@@ -41,7 +41,7 @@ func (node RelabelType) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
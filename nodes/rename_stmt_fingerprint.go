@@ -23,7 +23,7 @@ func (node RenameStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Object != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Object.Fingerprint(&subCtx, node, "Object")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node RenameStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
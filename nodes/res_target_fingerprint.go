@@ -6,7 +6,7 @@ func (node ResTarget) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	ctx.WriteString("ResTarget")
 
 	if len(node.Indirection.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Indirection.Fingerprint(&subCtx, node, "Indirection")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node ResTarget) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.Val != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Val.Fingerprint(&subCtx, node, "Val")
 
 		if len(subCtx.parts) > 0 {
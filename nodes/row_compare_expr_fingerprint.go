@@ -8,7 +8,7 @@ func (node RowCompareExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("RowCompareExpr")
 
 	if len(node.Inputcollids.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Inputcollids.Fingerprint(&subCtx, node, "Inputcollids")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node RowCompareExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Largs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Largs.Fingerprint(&subCtx, node, "Largs")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node RowCompareExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Opfamilies.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opfamilies.Fingerprint(&subCtx, node, "Opfamilies")
 
 		if len(subCtx.parts) > 0 {
@@ -44,7 +44,7 @@ func (node RowCompareExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Opnos.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Opnos.Fingerprint(&subCtx, node, "Opnos")
 
 		if len(subCtx.parts) > 0 {
@@ -56,7 +56,7 @@ func (node RowCompareExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if len(node.Rargs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rargs.Fingerprint(&subCtx, node, "Rargs")
 
 		if len(subCtx.parts) > 0 {
@@ -73,7 +73,7 @@ func (node RowCompareExpr) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node RowExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	ctx.WriteString("RowExpr")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node RowExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if len(node.Colnames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Colnames.Fingerprint(&subCtx, node, "Colnames")
 
 		if len(subCtx.parts) > 0 {
@@ -43,7 +43,7 @@ func (node RowExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
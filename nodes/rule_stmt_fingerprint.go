@@ -8,7 +8,7 @@ func (node RuleStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("RuleStmt")
 
 	if len(node.Actions.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Actions.Fingerprint(&subCtx, node, "Actions")
 
 		if len(subCtx.parts) > 0 {
@@ -30,7 +30,7 @@ func (node RuleStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -52,7 +52,7 @@ func (node RuleStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.WhereClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WhereClause.Fingerprint(&subCtx, node, "WhereClause")
 
 		if len(subCtx.parts) > 0 {
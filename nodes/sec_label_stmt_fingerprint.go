@@ -13,7 +13,7 @@ func (node SecLabelStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if node.Object != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Object.Fingerprint(&subCtx, node, "Object")
 
 		if len(subCtx.parts) > 0 {
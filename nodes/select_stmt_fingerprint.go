@@ -2,10 +2,7 @@
 
 package pg_query
 
-import (
-	"sort"
-	"strconv"
-)
+import "strconv"
 
 func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parentFieldName string) {
 	ctx.WriteString("SelectStmt")
@@ -16,7 +13,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.DistinctClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.DistinctClause.Fingerprint(&subCtx, node, "DistinctClause")
 
 		if len(subCtx.parts) > 0 {
@@ -28,7 +25,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.FromClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.FromClause.Fingerprint(&subCtx, node, "FromClause")
 
 		if len(subCtx.parts) > 0 {
@@ -40,7 +37,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.GroupClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.GroupClause.Fingerprint(&subCtx, node, "GroupClause")
 
 		if len(subCtx.parts) > 0 {
@@ -52,7 +49,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.HavingClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.HavingClause.Fingerprint(&subCtx, node, "HavingClause")
 
 		if len(subCtx.parts) > 0 {
@@ -64,7 +61,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.IntoClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.IntoClause.Fingerprint(&subCtx, node, "IntoClause")
 
 		if len(subCtx.parts) > 0 {
@@ -76,7 +73,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Larg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Larg.Fingerprint(&subCtx, node, "Larg")
 
 		if len(subCtx.parts) > 0 {
@@ -88,7 +85,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.LimitCount != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.LimitCount.Fingerprint(&subCtx, node, "LimitCount")
 
 		if len(subCtx.parts) > 0 {
@@ -100,7 +97,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.LimitOffset != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.LimitOffset.Fingerprint(&subCtx, node, "LimitOffset")
 
 		if len(subCtx.parts) > 0 {
@@ -112,7 +109,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.LockingClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.LockingClause.Fingerprint(&subCtx, node, "LockingClause")
 
 		if len(subCtx.parts) > 0 {
@@ -129,7 +126,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Rarg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rarg.Fingerprint(&subCtx, node, "Rarg")
 
 		if len(subCtx.parts) > 0 {
@@ -141,7 +138,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.SortClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.SortClause.Fingerprint(&subCtx, node, "SortClause")
 
 		if len(subCtx.parts) > 0 {
@@ -153,7 +150,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.TargetList.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TargetList.Fingerprint(&subCtx, node, "TargetList")
 
 		if len(subCtx.parts) > 0 {
@@ -165,34 +162,24 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.ValuesLists) > 0 {
-		var itemsFingerprints FingerprintSubContextSlice
-
+		valuesLists := List{}
 		for _, nodeList := range node.ValuesLists {
-			subCtx := FingerprintSubContext{}
-			for _, subNode := range nodeList {
-				subNode.Fingerprint(&subCtx, node, "ValuesLists")
-			}
-			itemsFingerprints.AddIfUnique(subCtx)
+			valuesLists.Items = append(valuesLists.Items, List{Items: nodeList})
 		}
 
-		sort.Sort(itemsFingerprints)
+		subCtx := newFingerprintSubContext(ctx)
+		valuesLists.Fingerprint(&subCtx, node, "ValuesLists")
 
-		if len(itemsFingerprints) > 0 {
-			firstItem := true
-			for _, fingerprint := range itemsFingerprints {
-				for _, part := range fingerprint.parts {
-					if firstItem {
-						ctx.WriteString("valuesLists")
-						firstItem = false
-					}
-					ctx.WriteString(part)
-				}
+		if len(subCtx.parts) > 0 {
+			ctx.WriteString("valuesLists")
+			for _, part := range subCtx.parts {
+				ctx.WriteString(part)
 			}
 		}
 	}
 
 	if node.WhereClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WhereClause.Fingerprint(&subCtx, node, "WhereClause")
 
 		if len(subCtx.parts) > 0 {
@@ -204,7 +191,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.WindowClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WindowClause.Fingerprint(&subCtx, node, "WindowClause")
 
 		if len(subCtx.parts) > 0 {
@@ -216,7 +203,7 @@ func (node SelectStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.WithClause != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.WithClause.Fingerprint(&subCtx, node, "WithClause")
 
 		if len(subCtx.parts) > 0 {
@@ -13,7 +13,7 @@ func (node SetOperationStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.ColCollations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ColCollations.Fingerprint(&subCtx, node, "ColCollations")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node SetOperationStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.ColTypes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ColTypes.Fingerprint(&subCtx, node, "ColTypes")
 
 		if len(subCtx.parts) > 0 {
@@ -37,7 +37,7 @@ func (node SetOperationStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.ColTypmods.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ColTypmods.Fingerprint(&subCtx, node, "ColTypmods")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node SetOperationStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if len(node.GroupClauses.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.GroupClauses.Fingerprint(&subCtx, node, "GroupClauses")
 
 		if len(subCtx.parts) > 0 {
@@ -61,7 +61,7 @@ func (node SetOperationStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Larg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Larg.Fingerprint(&subCtx, node, "Larg")
 
 		if len(subCtx.parts) > 0 {
@@ -78,7 +78,7 @@ func (node SetOperationStmt) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Rarg != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rarg.Fingerprint(&subCtx, node, "Rarg")
 
 		if len(subCtx.parts) > 0 {
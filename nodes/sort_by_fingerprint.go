@@ -9,7 +9,7 @@ func (node SortBy) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.Node != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Node.Fingerprint(&subCtx, node, "Node")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node SortBy) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 		}
 	}
 
-	if int(node.SortbyDir) != 0 {
+	if int(node.SortbyDir) != 0 && !fingerprintOptions(ctx).IgnoreSortDirection {
 		ctx.WriteString("sortby_dir")
 		ctx.WriteString(strconv.Itoa(int(node.SortbyDir)))
 	}
@@ -31,7 +31,7 @@ func (node SortBy) Fingerprint(ctx FingerprintContext, parentNode Node, parentFi
 	}
 
 	if len(node.UseOp.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.UseOp.Fingerprint(&subCtx, node, "UseOp")
 
 		if len(subCtx.parts) > 0 {
@@ -24,7 +24,7 @@ func (node SQLValueFunction) Fingerprint(ctx FingerprintContext, parentNode Node
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -4,8 +4,6 @@ package pg_query
 
 func (node String) Fingerprint(ctx FingerprintContext, parentNode Node, parentFieldName string) {
 	ctx.WriteString("String")
-	if len(node.Str) > 0 {
-		ctx.WriteString("str")
-		ctx.WriteString(node.Str)
-	}
+	ctx.WriteString("str")
+	ctx.WriteString(node.Str)
 }
@@ -9,7 +9,7 @@ func (node SubLink) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.OperName.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OperName.Fingerprint(&subCtx, node, "OperName")
 
 		if len(subCtx.parts) > 0 {
@@ -31,7 +31,7 @@ func (node SubLink) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if node.Subselect != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Subselect.Fingerprint(&subCtx, node, "Subselect")
 
 		if len(subCtx.parts) > 0 {
@@ -43,7 +43,7 @@ func (node SubLink) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if node.Testexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Testexpr.Fingerprint(&subCtx, node, "Testexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -55,7 +55,7 @@ func (node SubLink) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node SubPlan) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	ctx.WriteString("SubPlan")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node SubPlan) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if len(node.ParParam.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ParParam.Fingerprint(&subCtx, node, "ParParam")
 
 		if len(subCtx.parts) > 0 {
@@ -52,7 +52,7 @@ func (node SubPlan) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if len(node.ParamIds.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ParamIds.Fingerprint(&subCtx, node, "ParamIds")
 
 		if len(subCtx.parts) > 0 {
@@ -76,7 +76,7 @@ func (node SubPlan) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if len(node.SetParam.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.SetParam.Fingerprint(&subCtx, node, "SetParam")
 
 		if len(subCtx.parts) > 0 {
@@ -95,7 +95,7 @@ func (node SubPlan) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if node.Testexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Testexpr.Fingerprint(&subCtx, node, "Testexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -117,7 +117,7 @@ func (node SubPlan) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	ctx.WriteString("TableFunc")
 
 	if len(node.Colcollations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Colcollations.Fingerprint(&subCtx, node, "Colcollations")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Coldefexprs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Coldefexprs.Fingerprint(&subCtx, node, "Coldefexprs")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Colexprs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Colexprs.Fingerprint(&subCtx, node, "Colexprs")
 
 		if len(subCtx.parts) > 0 {
@@ -44,7 +44,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Colnames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Colnames.Fingerprint(&subCtx, node, "Colnames")
 
 		if len(subCtx.parts) > 0 {
@@ -56,7 +56,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Coltypes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Coltypes.Fingerprint(&subCtx, node, "Coltypes")
 
 		if len(subCtx.parts) > 0 {
@@ -68,7 +68,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.Coltypmods.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Coltypmods.Fingerprint(&subCtx, node, "Coltypmods")
 
 		if len(subCtx.parts) > 0 {
@@ -80,7 +80,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.Docexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Docexpr.Fingerprint(&subCtx, node, "Docexpr")
 
 		if len(subCtx.parts) > 0 {
@@ -98,7 +98,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.NsNames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.NsNames.Fingerprint(&subCtx, node, "NsNames")
 
 		if len(subCtx.parts) > 0 {
@@ -110,7 +110,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.NsUris.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.NsUris.Fingerprint(&subCtx, node, "NsUris")
 
 		if len(subCtx.parts) > 0 {
@@ -127,7 +127,7 @@ func (node TableFunc) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.Rowexpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Rowexpr.Fingerprint(&subCtx, node, "Rowexpr")
 
 		if len(subCtx.parts) > 0 {
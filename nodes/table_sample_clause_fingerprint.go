@@ -8,7 +8,7 @@ func (node TableSampleClause) Fingerprint(ctx FingerprintContext, parentNode Nod
 	ctx.WriteString("TableSampleClause")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node TableSampleClause) Fingerprint(ctx FingerprintContext, parentNode Nod
 	}
 
 	if node.Repeatable != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Repeatable.Fingerprint(&subCtx, node, "Repeatable")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node TargetEntry) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	ctx.WriteString("TargetEntry")
 
 	if node.Expr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Expr.Fingerprint(&subCtx, node, "Expr")
 
 		if len(subCtx.parts) > 0 {
@@ -50,7 +50,7 @@ func (node TargetEntry) Fingerprint(ctx FingerprintContext, parentNode Node, par
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -13,7 +13,7 @@ func (node TruncateStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if len(node.Relations.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relations.Fingerprint(&subCtx, node, "Relations")
 
 		if len(subCtx.parts) > 0 {
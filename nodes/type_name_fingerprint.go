@@ -8,7 +8,7 @@ func (node TypeName) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("TypeName")
 
 	if len(node.ArrayBounds.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ArrayBounds.Fingerprint(&subCtx, node, "ArrayBounds")
 
 		if len(subCtx.parts) > 0 {
@@ -21,7 +21,7 @@ func (node TypeName) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if len(node.Names.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Names.Fingerprint(&subCtx, node, "Names")
 
 		if len(subCtx.parts) > 0 {
@@ -53,7 +53,7 @@ func (node TypeName) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Typmods.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Typmods.Fingerprint(&subCtx, node, "Typmods")
 
 		if len(subCtx.parts) > 0 {
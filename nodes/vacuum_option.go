@@ -13,7 +13,7 @@ package pg_query
 type VacuumOption uint
 
 const (
-	VACOPT_VACUUM VacuumOption = iota
+	VACOPT_VACUUM VacuumOption = 1 << iota
 	VACOPT_ANALYZE
 	VACOPT_VERBOSE
 	VACOPT_FREEZE
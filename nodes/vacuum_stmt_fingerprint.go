@@ -13,7 +13,7 @@ func (node VacuumStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Relation != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Relation.Fingerprint(&subCtx, node, "Relation")
 
 		if len(subCtx.parts) > 0 {
@@ -25,7 +25,7 @@ func (node VacuumStmt) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.VaCols.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.VaCols.Fingerprint(&subCtx, node, "VaCols")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node Var) Fingerprint(ctx FingerprintContext, parentNode Node, parentField
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
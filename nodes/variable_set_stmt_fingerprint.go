@@ -8,7 +8,7 @@ func (node VariableSetStmt) Fingerprint(ctx FingerprintContext, parentNode Node,
 	ctx.WriteString("VariableSetStmt")
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
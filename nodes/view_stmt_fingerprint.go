@@ -8,7 +8,7 @@ func (node ViewStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	ctx.WriteString("ViewStmt")
 
 	if len(node.Aliases.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Aliases.Fingerprint(&subCtx, node, "Aliases")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node ViewStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if len(node.Options.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Options.Fingerprint(&subCtx, node, "Options")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node ViewStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.Query != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Query.Fingerprint(&subCtx, node, "Query")
 
 		if len(subCtx.parts) > 0 {
@@ -49,7 +49,7 @@ func (node ViewStmt) Fingerprint(ctx FingerprintContext, parentNode Node, parent
 	}
 
 	if node.View != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.View.Fingerprint(&subCtx, node, "View")
 
 		if len(subCtx.parts) > 0 {
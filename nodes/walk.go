@@ -0,0 +1,197 @@
+package pg_query
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NodeField describes a node referenced from a field of its parent node
+type NodeField struct {
+	Node     Node   // the referenced node, pointers are dereferenced
+	Name     string // Go field name, as passed to Fingerprint as parentFieldName
+	JSONName string // field name in the JSON parse tree
+	Index    []int  // position within a List or [][]Node field, nil otherwise
+}
+
+type walkField struct {
+	index    int
+	name     string
+	jsonName string
+}
+
+var nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+var listType = reflect.TypeOf(List{})
+var nodeArrayArrayType = reflect.TypeOf([][]Node{})
+
+var walkFieldCache sync.Map
+
+// walkFields returns the fields of a node struct that can reference other
+// nodes, sorted by JSON name (the same order used for fingerprinting)
+func walkFields(t reflect.Type) []walkField {
+	if cached, ok := walkFieldCache.Load(t); ok {
+		return cached.([]walkField)
+	}
+
+	fields := []walkField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		switch {
+		case field.Type == listType, field.Type == nodeArrayArrayType, field.Type == nodeType:
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem() == nodeType:
+		case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct && field.Type.Implements(nodeType):
+		case field.Type.Kind() == reflect.Struct && field.Type.Implements(nodeType):
+		default:
+			continue
+		}
+
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		fields = append(fields, walkField{index: i, name: field.Name, jsonName: jsonName})
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].jsonName < fields[j].jsonName
+	})
+
+	walkFieldCache.Store(t, fields)
+	return fields
+}
+
+// Children returns the nodes directly referenced by node. Items of List
+// fields are returned individually, whereas a List stored in a Node field
+// (e.g. the right side of an IN expression) is returned as a single child.
+func Children(node Node) []NodeField {
+	value := reflect.ValueOf(node)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var children []NodeField
+	for _, field := range walkFields(value.Type()) {
+		fieldValue := value.Field(field.index)
+
+		switch {
+		case fieldValue.Type() == listType:
+			for i, item := range fieldValue.Interface().(List).Items {
+				if item != nil {
+					children = append(children, NodeField{Node: derefNode(item), Name: field.name, JSONName: field.jsonName, Index: []int{i}})
+				}
+			}
+		case fieldValue.Type() == nodeArrayArrayType:
+			for i, nodeList := range fieldValue.Interface().([][]Node) {
+				for j, item := range nodeList {
+					if item != nil {
+						children = append(children, NodeField{Node: derefNode(item), Name: field.name, JSONName: field.jsonName, Index: []int{i, j}})
+					}
+				}
+			}
+		case fieldValue.Kind() == reflect.Slice:
+			for i, item := range fieldValue.Interface().([]Node) {
+				if item != nil {
+					children = append(children, NodeField{Node: derefNode(item), Name: field.name, JSONName: field.jsonName, Index: []int{i}})
+				}
+			}
+		default:
+			if (fieldValue.Kind() == reflect.Ptr || fieldValue.Kind() == reflect.Interface) && fieldValue.IsNil() {
+				continue
+			}
+			child := derefNode(fieldValue.Interface().(Node))
+			if child != nil {
+				children = append(children, NodeField{Node: child, Name: field.name, JSONName: field.jsonName})
+			}
+		}
+	}
+
+	return children
+}
+
+func derefNode(node Node) Node {
+	value := reflect.ValueOf(node)
+	if value.Kind() != reflect.Ptr {
+		return node
+	}
+	if value.IsNil() {
+		return nil
+	}
+	if deref, ok := value.Elem().Interface().(Node); ok {
+		return deref
+	}
+	return node
+}
+
+// Walk traverses the tree rooted at node in depth-first order, calling fn for
+// every node with the parent node and Go field name it was referenced from.
+// Lists are transparent: fn is not called for them, and their items are
+// reported with the parent of the List, matching Fingerprint. If fn returns
+// false the children of that node are skipped.
+func Walk(node Node, fn func(node Node, parentNode Node, parentFieldName string) bool) {
+	walk(derefNode(node), nil, "", fn)
+}
+
+func walk(node Node, parentNode Node, parentFieldName string, fn func(Node, Node, string) bool) {
+	if node == nil {
+		return
+	}
+
+	if list, ok := node.(List); ok {
+		for _, item := range list.Items {
+			walk(derefNode(item), parentNode, parentFieldName, fn)
+		}
+		return
+	}
+
+	if !fn(node, parentNode, parentFieldName) {
+		return
+	}
+
+	for _, child := range Children(node) {
+		walk(child.Node, node, child.Name, fn)
+	}
+}
+
+// Location returns the byte offset of node within the parsed SQL text, or -1
+// if the node doesn't have a location or it is unknown
+func Location(node Node) int {
+	value := reflect.ValueOf(node)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return -1
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return -1
+	}
+	field := value.FieldByName("Location")
+	if !field.IsValid() || field.Kind() != reflect.Int {
+		return -1
+	}
+	return int(field.Int())
+}
+
+// UnwrapRawStmt returns the statement a top-level RawStmt of a parse tree
+// wraps, and its location in the parsed SQL text. Any other node is returned
+// as-is, with location 0.
+func UnwrapRawStmt(node Node) (Node, int) {
+	switch rawStmt := node.(type) {
+	case RawStmt:
+		return rawStmt.Stmt, rawStmt.StmtLocation
+	case *RawStmt:
+		return rawStmt.Stmt, rawStmt.StmtLocation
+	}
+	return node, 0
+}
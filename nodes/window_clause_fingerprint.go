@@ -13,7 +13,7 @@ func (node WindowClause) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if node.EndOffset != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.EndOffset.Fingerprint(&subCtx, node, "EndOffset")
 
 		if len(subCtx.parts) > 0 {
@@ -35,7 +35,7 @@ func (node WindowClause) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if len(node.OrderClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OrderClause.Fingerprint(&subCtx, node, "OrderClause")
 
 		if len(subCtx.parts) > 0 {
@@ -47,7 +47,7 @@ func (node WindowClause) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if len(node.PartitionClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.PartitionClause.Fingerprint(&subCtx, node, "PartitionClause")
 
 		if len(subCtx.parts) > 0 {
@@ -64,7 +64,7 @@ func (node WindowClause) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	}
 
 	if node.StartOffset != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.StartOffset.Fingerprint(&subCtx, node, "StartOffset")
 
 		if len(subCtx.parts) > 0 {
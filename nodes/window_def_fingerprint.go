@@ -8,7 +8,7 @@ func (node WindowDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	ctx.WriteString("WindowDef")
 
 	if node.EndOffset != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.EndOffset.Fingerprint(&subCtx, node, "EndOffset")
 
 		if len(subCtx.parts) > 0 {
@@ -32,7 +32,7 @@ func (node WindowDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.OrderClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.OrderClause.Fingerprint(&subCtx, node, "OrderClause")
 
 		if len(subCtx.parts) > 0 {
@@ -44,7 +44,7 @@ func (node WindowDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if len(node.PartitionClause.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.PartitionClause.Fingerprint(&subCtx, node, "PartitionClause")
 
 		if len(subCtx.parts) > 0 {
@@ -61,7 +61,7 @@ func (node WindowDef) Fingerprint(ctx FingerprintContext, parentNode Node, paren
 	}
 
 	if node.StartOffset != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.StartOffset.Fingerprint(&subCtx, node, "StartOffset")
 
 		if len(subCtx.parts) > 0 {
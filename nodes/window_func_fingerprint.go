@@ -8,7 +8,7 @@ func (node WindowFunc) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("WindowFunc")
 
 	if node.Aggfilter != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Aggfilter.Fingerprint(&subCtx, node, "Aggfilter")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node WindowFunc) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -69,7 +69,7 @@ func (node WindowFunc) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
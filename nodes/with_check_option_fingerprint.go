@@ -23,7 +23,7 @@ func (node WithCheckOption) Fingerprint(ctx FingerprintContext, parentNode Node,
 	}
 
 	if node.Qual != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Qual.Fingerprint(&subCtx, node, "Qual")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node WithClause) Fingerprint(ctx FingerprintContext, parentNode Node, pare
 	ctx.WriteString("WithClause")
 
 	if len(node.Ctes.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Ctes.Fingerprint(&subCtx, node, "Ctes")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node XmlExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	ctx.WriteString("XmlExpr")
 
 	if len(node.ArgNames.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.ArgNames.Fingerprint(&subCtx, node, "ArgNames")
 
 		if len(subCtx.parts) > 0 {
@@ -20,7 +20,7 @@ func (node XmlExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if len(node.Args.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Args.Fingerprint(&subCtx, node, "Args")
 
 		if len(subCtx.parts) > 0 {
@@ -38,7 +38,7 @@ func (node XmlExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if len(node.NamedArgs.Items) > 0 {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.NamedArgs.Fingerprint(&subCtx, node, "NamedArgs")
 
 		if len(subCtx.parts) > 0 {
@@ -70,7 +70,7 @@ func (node XmlExpr) Fingerprint(ctx FingerprintContext, parentNode Node, parentF
 	}
 
 	if node.Xpr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Xpr.Fingerprint(&subCtx, node, "Xpr")
 
 		if len(subCtx.parts) > 0 {
@@ -8,7 +8,7 @@ func (node XmlSerialize) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	ctx.WriteString("XmlSerialize")
 
 	if node.Expr != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.Expr.Fingerprint(&subCtx, node, "Expr")
 
 		if len(subCtx.parts) > 0 {
@@ -21,7 +21,7 @@ func (node XmlSerialize) Fingerprint(ctx FingerprintContext, parentNode Node, pa
 	// Intentionally ignoring node.Location for fingerprinting
 
 	if node.TypeName != nil {
-		subCtx := FingerprintSubContext{}
+		subCtx := newFingerprintSubContext(ctx)
 		node.TypeName.Fingerprint(&subCtx, node, "TypeName")
 
 		if len(subCtx.parts) > 0 {
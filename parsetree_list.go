@@ -10,6 +10,11 @@ import (
 
 type ParsetreeList struct {
 	Statements []nodes.Node
+
+	// Comments of the parsed SQL text, only set by ParseWithComments
+	Comments []Comment
+
+	source string // parsed SQL text, only set by ParseWithComments
 }
 
 func (input ParsetreeList) MarshalJSON() ([]byte, error) {
@@ -36,13 +41,40 @@ func (output *ParsetreeList) UnmarshalJSON(input []byte) (err error) {
 	return
 }
 
+// FingerprintOptions control which differences between queries are
+// significant for ParsetreeList.FingerprintWithOptions
+type FingerprintOptions = nodes.FingerprintOptions
+
 func (input ParsetreeList) Fingerprint() string {
-	const fingerprintVersion uint = 2
+	return input.FingerprintWithOptions(FingerprintOptions{})
+}
 
-	ctx := nodes.NewFingerprintHashContext()
+// FingerprintWithOptions - Like Fingerprint, but with configurable grouping
+// granularity. Note that with non-default options the result will differ from
+// FastFingerprint.
+func (input ParsetreeList) FingerprintWithOptions(options FingerprintOptions) string {
+	ctx := nodes.NewFingerprintHashContextWithOptions(options)
 	for _, node := range input.Statements {
 		node.Fingerprint(ctx, nil, "")
 	}
 
-	return fmt.Sprintf("%02x%s", fingerprintVersion, hex.EncodeToString(ctx.Sum()))
+	return fmt.Sprintf("%02x%s", nodes.FingerprintVersion, hex.EncodeToString(ctx.Sum()))
+}
+
+// FingerprintTokens returns the parts fed into the fingerprint hash, each
+// annotated with the node that produced it. Paths start with the index of the
+// statement, e.g. "[0].stmt.targetList[0]".
+func (input ParsetreeList) FingerprintTokens() []nodes.FingerprintToken {
+	tokens := []nodes.FingerprintToken{}
+	for i, node := range input.Statements {
+		for _, token := range nodes.FingerprintTokens(node) {
+			if token.Path == "" {
+				token.Path = fmt.Sprintf("[%d]", i)
+			} else {
+				token.Path = fmt.Sprintf("[%d].%s", i, token.Path)
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"runtime/debug"
 
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
 	"github.com/tomaszjonak/pg_query_go/parser"
 )
 
@@ -43,6 +44,16 @@ func Normalize(input string) (result string, err error) {
 	return parser.Normalize(input)
 }
 
+// FingerprintTokens - Returns the annotated token stream that the fingerprint
+// of the passed SQL statement is computed from
+func FingerprintTokens(input string) (tokens []nodes.FingerprintToken, err error) {
+	tree, err := Parse(input)
+	if err != nil {
+		return
+	}
+	return tree.FingerprintTokens(), nil
+}
+
 // FastFingerprint - Fingerprint the passed SQL statement using the C extension
 func FastFingerprint(input string) (result string, err error) {
 	return parser.FastFingerprint(input)
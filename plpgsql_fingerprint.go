@@ -0,0 +1,443 @@
+package pg_query
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// PlPgSqlFunctionFingerprint - Fingerprints of a PL/pgSQL function and of the
+// SQL statements embedded in it
+type PlPgSqlFunctionFingerprint struct {
+	Name string // qualified function name
+
+	// Fingerprint of the whole function including its parameter and return
+	// types, ignoring formatting and the names of parameters and variables,
+	// but not constants
+	Fingerprint string
+
+	Statements []PlPgSqlStatementFingerprint
+}
+
+// PlPgSqlStatementFingerprint - Fingerprint of a SQL statement embedded in a
+// PL/pgSQL function
+type PlPgSqlStatementFingerprint struct {
+	Kind   string // e.g. "PLpgSQL_stmt_execsql"
+	Lineno int
+
+	// Query as sent at runtime, with references to PL/pgSQL variables replaced
+	// by parameters, and its fingerprint - this matches the fingerprint of the
+	// query as seen in the server's statistics and logs. Variables are numbered
+	// the way PL/pgSQL numbers them: the parameters first, then the implicit
+	// FOUND variable and then the declared variables, so the first variable
+	// declared in a function with two parameters becomes $4.
+	Query       string
+	Fingerprint string
+}
+
+// Statements whose query is executed as-is (apart from variable substitution)
+var plpgsqlStatementQueryFields = map[string]string{
+	"PLpgSQL_stmt_execsql":      "sqlstmt",
+	"PLpgSQL_stmt_perform":      "expr",
+	"PLpgSQL_stmt_fors":         "query",
+	"PLpgSQL_stmt_return_query": "query",
+}
+
+// Fields that only hold names or source positions
+var plpgsqlIgnoredFields = map[string]bool{
+	"lineno":  true,
+	"refname": true,
+	"name":    true,
+	"label":   true,
+}
+
+// FingerprintPlPgSql - Fingerprints every function in the given CREATE
+// FUNCTION statement(s), together with the SQL statements they run. Functions
+// in other languages than PL/pgSQL are fingerprinted as plain statements,
+// without embedded statements.
+func FingerprintPlPgSql(input string) (result []PlPgSqlFunctionFingerprint, err error) {
+	tree, err := Parse(input)
+	if err != nil {
+		return
+	}
+
+	for _, node := range tree.Statements {
+		rawStmt, ok := node.(nodes.RawStmt)
+		if !ok {
+			continue
+		}
+		stmt, ok := rawStmt.Stmt.(nodes.CreateFunctionStmt)
+		if !ok {
+			continue
+		}
+
+		var function PlPgSqlFunctionFingerprint
+		if strings.ToLower(functionLanguage(stmt)) == "plpgsql" {
+			function, err = fingerprintPlPgSqlFunction(stmt, statementSource(input, rawStmt))
+			if err != nil {
+				return
+			}
+		} else {
+			function = PlPgSqlFunctionFingerprint{
+				Name:        functionName(stmt),
+				Fingerprint: ParsetreeList{Statements: []nodes.Node{rawStmt}}.Fingerprint(),
+			}
+		}
+		result = append(result, function)
+	}
+
+	return
+}
+
+// fingerprintPlPgSqlFunction fingerprints a single PL/pgSQL function, given
+// its CREATE FUNCTION statement and the source of that statement
+func fingerprintPlPgSqlFunction(stmt nodes.CreateFunctionStmt, source string) (function PlPgSqlFunctionFingerprint, err error) {
+	funcsJSON, err := ParsePlPgSqlToJSON(source)
+	if err != nil {
+		return
+	}
+
+	var funcs []map[string]interface{}
+	err = json.Unmarshal([]byte(funcsJSON), &funcs)
+	if err != nil {
+		return
+	}
+	if len(funcs) != 1 {
+		err = fmt.Errorf("Expected 1 PL/pgSQL function, got %d", len(funcs))
+		return
+	}
+
+	fp := plpgsqlFingerprinter{variables: map[string]int{}}
+	err = fp.addFunctionStmt(stmt)
+	if err != nil {
+		return
+	}
+
+	functionJSON, ok := funcs[0]["PLpgSQL_function"].(map[string]interface{})
+	if !ok {
+		err = fmt.Errorf("Unexpected PL/pgSQL parse result: %v", funcs[0])
+		return
+	}
+	if datums, ok := functionJSON["datums"].([]interface{}); ok {
+		fp.addDatums(datums)
+	}
+
+	ctx := nodes.NewFingerprintHashContext()
+	for _, part := range fp.signature {
+		ctx.WriteString(part)
+	}
+	fp.writeValue(ctx, "", functionJSON)
+
+	function = PlPgSqlFunctionFingerprint{
+		Name:        fp.name,
+		Fingerprint: fmt.Sprintf("%02x%s", nodes.FingerprintVersion, hex.EncodeToString(ctx.Sum())),
+		Statements:  fp.statements,
+	}
+	return
+}
+
+// statementSource returns the text of a top-level statement of input
+func statementSource(input string, rawStmt nodes.RawStmt) string {
+	if rawStmt.StmtLen == 0 {
+		// The last statement extends to the end of the input
+		return input[rawStmt.StmtLocation:]
+	}
+	return input[rawStmt.StmtLocation : rawStmt.StmtLocation+rawStmt.StmtLen]
+}
+
+func functionName(stmt nodes.CreateFunctionStmt) string {
+	names := []string{}
+	for _, item := range stmt.Funcname.Items {
+		if str, ok := item.(nodes.String); ok {
+			names = append(names, str.Str)
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+// functionLanguage returns the LANGUAGE of a CREATE FUNCTION statement, or ""
+func functionLanguage(stmt nodes.CreateFunctionStmt) string {
+	for _, item := range stmt.Options.Items {
+		if defElem, ok := item.(nodes.DefElem); ok && defElem.Defname != nil && *defElem.Defname == "language" {
+			if str, ok := defElem.Arg.(nodes.String); ok {
+				return str.Str
+			}
+		}
+	}
+	return ""
+}
+
+type plpgsqlFingerprinter struct {
+	name       string
+	signature  []string       // modes and types of the parameters, and the return type
+	variables  map[string]int // variable name => parameter number used in its place, in declaration order
+	statements []PlPgSqlStatementFingerprint
+}
+
+func (fp *plpgsqlFingerprinter) addVariable(name string) {
+	if _, exists := fp.variables[name]; !exists {
+		fp.variables[name] = len(fp.variables) + 1
+	}
+}
+
+// addFunctionStmt records the name and signature of the function, as the
+// datums of the PL/pgSQL parse result don't carry the actual types
+func (fp *plpgsqlFingerprinter) addFunctionStmt(stmt nodes.CreateFunctionStmt) error {
+	fp.name = functionName(stmt)
+
+	for _, item := range stmt.Parameters.Items {
+		param, ok := item.(nodes.FunctionParameter)
+		if !ok {
+			continue
+		}
+		if param.Name != nil {
+			fp.addVariable(*param.Name)
+		}
+		fp.signature = append(fp.signature, string(rune(param.Mode)))
+		if param.ArgType != nil {
+			argType, err := DeparseItem(param.ArgType)
+			if err != nil {
+				return err
+			}
+			fp.signature = append(fp.signature, argType)
+		}
+	}
+
+	if stmt.ReturnType != nil {
+		returnType, err := DeparseItem(stmt.ReturnType)
+		if err != nil {
+			return err
+		}
+		fp.signature = append(fp.signature, "returns", returnType)
+	}
+	return nil
+}
+
+func (fp *plpgsqlFingerprinter) addDatums(datums []interface{}) {
+	for _, datum := range datums {
+		datumMap, ok := datum.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, fields := range datumMap {
+			if fieldsMap, ok := fields.(map[string]interface{}); ok {
+				if refname, ok := fieldsMap["refname"].(string); ok && refname != "*internal*" {
+					fp.addVariable(refname)
+				}
+			}
+		}
+	}
+}
+
+func (fp *plpgsqlFingerprinter) writeValue(ctx nodes.FingerprintContext, key string, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if plpgsqlIgnoredFields[k] {
+				continue
+			}
+			if queryField, ok := plpgsqlStatementQueryFields[k]; ok {
+				fp.addStatement(k, queryField, value[k])
+			}
+			ctx.WriteString(k)
+			fp.writeValue(ctx, k, value[k])
+		}
+	case []interface{}:
+		for _, item := range value {
+			fp.writeValue(ctx, key, item)
+		}
+	case string:
+		switch key {
+		case "query":
+			fp.writeQuery(ctx, value)
+		default:
+			ctx.WriteString(strings.TrimSpace(value))
+		}
+	case float64:
+		ctx.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+	case bool:
+		ctx.WriteString(strconv.FormatBool(value))
+	}
+}
+
+// writeQuery writes the structure of an embedded query, as well as its
+// constants, so that only formatting and variable names are ignored
+func (fp *plpgsqlFingerprinter) writeQuery(ctx nodes.FingerprintContext, query string) {
+	runtimeQuery, tree, err := fp.runtimeQuery(query)
+	if err != nil {
+		ctx.WriteString(strings.Join(strings.Fields(runtimeQuery), " "))
+		return
+	}
+
+	ctx.WriteString(tree.Fingerprint())
+	for _, node := range tree.Statements {
+		nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+			if aConst, ok := node.(nodes.A_Const); ok {
+				switch val := aConst.Val.(type) {
+				case nodes.Integer:
+					ctx.WriteString(strconv.FormatInt(val.Ival, 10))
+				case nodes.Float:
+					ctx.WriteString(val.Str)
+				case nodes.String:
+					ctx.WriteString(val.Str)
+				case nodes.BitString:
+					ctx.WriteString(val.Str)
+				case nodes.Null:
+					ctx.WriteString("NULL")
+				}
+			}
+			return true
+		})
+	}
+}
+
+func (fp *plpgsqlFingerprinter) addStatement(kind string, queryField string, value interface{}) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	expr, ok := fields[queryField].(map[string]interface{})
+	if !ok {
+		return
+	}
+	exprFields, ok := expr["PLpgSQL_expr"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	query, ok := exprFields["query"].(string)
+	if !ok {
+		return
+	}
+
+	stmt := PlPgSqlStatementFingerprint{Kind: kind}
+	if lineno, ok := fields["lineno"].(float64); ok {
+		stmt.Lineno = int(lineno)
+	}
+
+	runtimeQuery, tree, err := fp.runtimeQuery(query)
+	stmt.Query = runtimeQuery
+	if err == nil {
+		stmt.Fingerprint = tree.Fingerprint()
+	}
+
+	fp.statements = append(fp.statements, stmt)
+}
+
+// runtimeQuery replaces references to PL/pgSQL variables with parameters,
+// the same way PL/pgSQL does when it runs the query
+func (fp *plpgsqlFingerprinter) runtimeQuery(query string) (string, ParsetreeList, error) {
+	tree, err := Parse(query)
+	if err != nil {
+		return query, tree, err
+	}
+
+	type replacement struct {
+		start, end int
+		param      int
+	}
+	replacements := []replacement{}
+
+	for _, node := range tree.Statements {
+		nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+			columnRef, ok := node.(nodes.ColumnRef)
+			if !ok || len(columnRef.Fields.Items) == 0 {
+				return true
+			}
+			first, ok := columnRef.Fields.Items[0].(nodes.String)
+			if !ok {
+				return true
+			}
+			param, ok := fp.variables[first.Str]
+			if !ok {
+				return true
+			}
+			end := scanQualifiedName(query, columnRef.Location, len(columnRef.Fields.Items))
+			if end > columnRef.Location {
+				replacements = append(replacements, replacement{columnRef.Location, end, param})
+			}
+			return true
+		})
+	}
+
+	if len(replacements) == 0 {
+		return query, tree, nil
+	}
+
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].start > replacements[j].start
+	})
+	for _, r := range replacements {
+		query = fmt.Sprintf("%s$%d%s", query[:r.start], r.param, query[r.end:])
+	}
+
+	tree, err = Parse(query)
+	return query, tree, err
+}
+
+// scanQualifiedName returns the end offset of a name made up of count
+// (possibly quoted) identifiers separated by dots, starting at start, or -1
+func scanQualifiedName(query string, start int, count int) int {
+	pos := start
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			for pos < len(query) && query[pos] == ' ' {
+				pos++
+			}
+			if pos >= len(query) || query[pos] != '.' {
+				return -1
+			}
+			pos++
+			for pos < len(query) && query[pos] == ' ' {
+				pos++
+			}
+		}
+
+		if pos >= len(query) {
+			return -1
+		}
+
+		switch c := query[pos]; {
+		case c == '"':
+			pos++
+			for {
+				next := strings.IndexByte(query[pos:], '"')
+				if next < 0 {
+					return -1
+				}
+				pos += next + 1
+				if pos < len(query) && query[pos] == '"' {
+					pos++
+					continue
+				}
+				break
+			}
+		case c == '*':
+			pos++
+		default:
+			identStart := pos
+			for pos < len(query) && isIdentChar(query[pos]) {
+				pos++
+			}
+			if pos == identStart {
+				return -1
+			}
+		}
+	}
+	return pos
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
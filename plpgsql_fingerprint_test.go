@@ -0,0 +1,158 @@
+package pg_query_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go"
+)
+
+const plpgsqlFunction = `CREATE FUNCTION add_item(v_owner int, v_name varchar) RETURNS int AS $$
+DECLARE
+  total int := 0;
+BEGIN
+  INSERT INTO items (owner, name) VALUES (v_owner, v_name);
+  SELECT count(*) INTO total FROM items WHERE owner = v_owner;
+  IF total > 10 THEN
+    UPDATE owners SET item_count = total WHERE id = v_owner;
+  END IF;
+  RETURN total;
+END;
+$$ LANGUAGE plpgsql;`
+
+const plpgsqlFunctionRenamed = `CREATE FUNCTION add_item(p_owner int, p_name varchar) RETURNS int AS $$
+DECLARE n int := 0;
+BEGIN
+  INSERT INTO items (owner, name)
+    VALUES (p_owner, p_name);
+  SELECT count(*) INTO n FROM items WHERE owner = p_owner;
+  IF n > 10 THEN UPDATE owners SET item_count = n WHERE id = p_owner; END IF;
+  RETURN n;
+END;
+$$ LANGUAGE plpgsql;`
+
+const plpgsqlFunctionChanged = `CREATE FUNCTION add_item(v_owner int, v_name varchar) RETURNS int AS $$
+DECLARE
+  total int := 0;
+BEGIN
+  INSERT INTO items (owner, name) VALUES (v_owner, v_name);
+  SELECT count(*) INTO total FROM items WHERE owner = v_owner;
+  IF total > 20 THEN
+    UPDATE owners SET item_count = total WHERE id = v_owner;
+  END IF;
+  RETURN total;
+END;
+$$ LANGUAGE plpgsql;`
+
+func fingerprintPlPgSql(t *testing.T, input string) pg_query.PlPgSqlFunctionFingerprint {
+	result, err := pg_query.FingerprintPlPgSql(input)
+	if err != nil {
+		t.Fatalf("FingerprintPlPgSql(%s)\nerror %s\n\n", input, err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("FingerprintPlPgSql(%s)\nexpected 1 function\nactual %d\n\n", input, len(result))
+	}
+	return result[0]
+}
+
+func TestFingerprintPlPgSql(t *testing.T) {
+	function := fingerprintPlPgSql(t, plpgsqlFunction)
+	renamed := fingerprintPlPgSql(t, plpgsqlFunctionRenamed)
+	changed := fingerprintPlPgSql(t, plpgsqlFunctionChanged)
+
+	if function.Name != "add_item" {
+		t.Errorf("FingerprintPlPgSql\nexpected name add_item\nactual %s\n\n", function.Name)
+	}
+	if function.Fingerprint != renamed.Fingerprint {
+		t.Errorf("FingerprintPlPgSql\nexpected formatting and variable names to be ignored\nactual %s and %s\n\n", function.Fingerprint, renamed.Fingerprint)
+	}
+	if function.Fingerprint == changed.Fingerprint {
+		t.Errorf("FingerprintPlPgSql\nexpected changed constant to be significant\nactual %s\n\n", function.Fingerprint)
+	}
+
+	expectedQueries := []string{
+		"INSERT INTO items (owner, name) VALUES ($1, $2)",
+		"SELECT count(*)            FROM items WHERE owner = $1",
+		"UPDATE owners SET item_count = $4 WHERE id = $1",
+	}
+	actualQueries := []string{}
+	for _, stmt := range function.Statements {
+		if stmt.Kind != "PLpgSQL_stmt_execsql" {
+			continue
+		}
+		actualQueries = append(actualQueries, stmt.Query)
+
+		tree, err := pg_query.Parse(stmt.Query)
+		if err != nil {
+			t.Errorf("Parse(%s)\nerror %s\n\n", stmt.Query, err)
+			continue
+		}
+		if stmt.Fingerprint != tree.Fingerprint() {
+			t.Errorf("FingerprintPlPgSql\nexpected statement fingerprint %s\nactual %s\n\n", tree.Fingerprint(), stmt.Fingerprint)
+		}
+	}
+	if !reflect.DeepEqual(actualQueries, expectedQueries) {
+		t.Errorf("FingerprintPlPgSql\nexpected queries %#v\nactual %#v\n\n", expectedQueries, actualQueries)
+	}
+
+	for i := range function.Statements {
+		if function.Statements[i].Fingerprint != renamed.Statements[i].Fingerprint {
+			t.Errorf("FingerprintPlPgSql\nexpected statement fingerprints to ignore variable names\nactual %#v\n\n", renamed.Statements[i])
+		}
+	}
+
+	runtime, err := pg_query.Parse("UPDATE owners SET item_count = $1 WHERE id = $2")
+	if err != nil {
+		t.Fatalf("Parse error %s\n\n", err)
+	}
+	if function.Statements[2].Fingerprint != runtime.Fingerprint() {
+		t.Errorf("FingerprintPlPgSql\nexpected runtime fingerprint %s\nactual %s\n\n", runtime.Fingerprint(), function.Statements[2].Fingerprint)
+	}
+}
+
+func TestFingerprintPlPgSqlSignature(t *testing.T) {
+	body := ` AS $$ BEGIN RETURN a; END; $$ LANGUAGE plpgsql`
+	functions := []string{
+		`CREATE FUNCTION f(a int) RETURNS int` + body,
+		`CREATE FUNCTION f(a text) RETURNS text` + body,
+		`CREATE FUNCTION f(a bigint) RETURNS bigint` + body,
+		`CREATE FUNCTION f(INOUT a int) RETURNS int` + body,
+		`CREATE FUNCTION f(a int) RETURNS bigint` + body,
+	}
+	fingerprints := map[string]string{}
+	for _, function := range functions {
+		fingerprint := fingerprintPlPgSql(t, function).Fingerprint
+		if other, ok := fingerprints[fingerprint]; ok {
+			t.Errorf("FingerprintPlPgSql\nexpected different fingerprints for %s and %s\nactual %s\n\n", other, function, fingerprint)
+		}
+		fingerprints[fingerprint] = function
+	}
+
+	same := fingerprintPlPgSql(t, `CREATE FUNCTION f(b integer) RETURNS INT AS $$ BEGIN RETURN b; END; $$ LANGUAGE plpgsql`)
+	if _, ok := fingerprints[same.Fingerprint]; !ok {
+		t.Errorf("FingerprintPlPgSql\nexpected synonymous type names to be ignored\nactual %s\n\n", same.Fingerprint)
+	}
+}
+
+func TestFingerprintPlPgSqlMixedLanguages(t *testing.T) {
+	sqlFunction := `CREATE FUNCTION item_count(int) RETURNS bigint AS $$ SELECT count(*) FROM items WHERE owner = $1 $$ LANGUAGE sql`
+	result, err := pg_query.FingerprintPlPgSql(sqlFunction + ";\n" + plpgsqlFunction + "\nSELECT 1;")
+	if err != nil {
+		t.Fatalf("FingerprintPlPgSql\nerror %s\n\n", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("FingerprintPlPgSql\nexpected 2 functions\nactual %d\n\n", len(result))
+	}
+
+	sqlTree, err := pg_query.Parse(sqlFunction)
+	if err != nil {
+		t.Fatalf("Parse error %s\n\n", err)
+	}
+	expected := pg_query.PlPgSqlFunctionFingerprint{Name: "item_count", Fingerprint: sqlTree.Fingerprint()}
+	if !reflect.DeepEqual(result[0], expected) {
+		t.Errorf("FingerprintPlPgSql\nexpected %#v\nactual %#v\n\n", expected, result[0])
+	}
+	if !reflect.DeepEqual(result[1], fingerprintPlPgSql(t, plpgsqlFunction)) {
+		t.Errorf("FingerprintPlPgSql\nexpected the PL/pgSQL function to be fingerprinted on its own\nactual %#v\n\n", result[1])
+	}
+}
@@ -0,0 +1,681 @@
+package privileges
+
+import (
+	"strings"
+
+	pg_query "github.com/tomaszjonak/pg_query_go"
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+	"github.com/tomaszjonak/pg_query_go/resolve"
+)
+
+// Privileges the sequence functions need on their sequence
+var sequenceFunctions = map[string]Privilege{
+	"nextval": PrivilegeUsage,
+	"currval": PrivilegeUsage,
+	"setval":  PrivilegeUpdate,
+}
+
+type analyzer struct {
+	catalog      *catalog.Catalog
+	requirements []Requirement
+}
+
+func (a *analyzer) require(privilege Privilege, object Object, columns ...string) {
+	a.requirements = append(a.requirements, Requirement{Privilege: privilege, Object: object, Columns: columns})
+}
+
+func tableObject(table *catalog.Table) Object {
+	return Object{Kind: ObjectTable, Schema: table.Schema, Name: table.Name}
+}
+
+func functionObject(function *catalog.Function) Object {
+	object := Object{Kind: ObjectFunction, Schema: function.Schema, Name: function.Name}
+	for _, arg := range function.InArgs() {
+		object.Args = append(object.Args, arg.Type)
+	}
+	return object
+}
+
+func stringList(list nodes.List) []string {
+	strs := []string{}
+	for _, item := range list.Items {
+		if str, ok := item.(nodes.String); ok {
+			strs = append(strs, str.Str)
+		}
+	}
+	return strs
+}
+
+// splitName splits a possibly qualified name into its schema, empty if it
+// isn't qualified, and its name
+func splitName(names []string) (string, string) {
+	switch len(names) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", names[0]
+	default:
+		return names[len(names)-2], names[len(names)-1]
+	}
+}
+
+func rangeVarNames(rangeVar *nodes.RangeVar) (string, string) {
+	if rangeVar == nil || rangeVar.Relname == nil {
+		return "", ""
+	}
+	if rangeVar.Schemaname != nil {
+		return *rangeVar.Schemaname, *rangeVar.Relname
+	}
+	return "", *rangeVar.Relname
+}
+
+func (a *analyzer) table(rangeVar *nodes.RangeVar) *catalog.Table {
+	schema, name := rangeVarNames(rangeVar)
+	if name == "" {
+		return nil
+	}
+	return a.catalog.Table(schema, name)
+}
+
+func (a *analyzer) statement(node nodes.Node) {
+	switch node := node.(type) {
+	case nodes.SelectStmt:
+		a.query(node, node)
+		if node.IntoClause != nil {
+			a.create(node.IntoClause.Rel)
+		}
+	case nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt:
+		a.query(node, node)
+	case nodes.ExplainStmt:
+		a.query(node, node.Query)
+	case nodes.CreateTableAsStmt:
+		a.query(node, node.Query)
+		if node.Into != nil {
+			a.create(node.Into.Rel)
+		}
+	case nodes.ViewStmt:
+		a.query(node, node.Query)
+		if table := a.table(node.View); node.Replace && table != nil {
+			a.require(PrivilegeOwnership, tableObject(table))
+		} else {
+			a.create(node.View)
+		}
+	case nodes.CopyStmt:
+		a.copyStmt(node)
+	case nodes.TruncateStmt:
+		for _, item := range node.Relations.Items {
+			if rangeVar, ok := item.(nodes.RangeVar); ok {
+				if table := a.table(&rangeVar); table != nil {
+					a.require(PrivilegeTruncate, tableObject(table))
+				}
+			}
+		}
+	case nodes.CreateStmt:
+		a.create(node.Relation)
+		for _, item := range node.InhRelations.Items {
+			if rangeVar, ok := item.(nodes.RangeVar); ok {
+				if table := a.table(&rangeVar); table != nil {
+					a.require(PrivilegeOwnership, tableObject(table))
+				}
+			}
+		}
+		for _, item := range node.TableElts.Items {
+			a.tableElement(item)
+		}
+	case nodes.CreateSeqStmt:
+		a.create(node.Sequence)
+	case nodes.CompositeTypeStmt:
+		a.create(node.Typevar)
+		for _, item := range node.Coldeflist.Items {
+			a.tableElement(item)
+		}
+	case nodes.CreateEnumStmt:
+		schema, _ := splitName(stringList(node.TypeName))
+		a.createIn(schema)
+	case nodes.CreateDomainStmt:
+		schema, _ := splitName(stringList(node.Domainname))
+		a.createIn(schema)
+		a.typeUsage(node.TypeName)
+	case nodes.CreateFunctionStmt:
+		a.createFunction(node)
+	case nodes.CreateSchemaStmt:
+		a.require(PrivilegeCreate, Object{Kind: ObjectDatabase})
+	case nodes.IndexStmt:
+		if table := a.table(node.Relation); table != nil {
+			a.require(PrivilegeOwnership, tableObject(table))
+		}
+	case nodes.AlterTableStmt:
+		a.owned(node.Relkind, node.Relation, nil)
+		for _, item := range node.Cmds.Items {
+			if cmd, ok := item.(nodes.AlterTableCmd); ok {
+				a.tableElement(cmd.Def)
+			}
+		}
+	case nodes.DropStmt:
+		for _, object := range node.Objects.Items {
+			a.owned(node.RemoveType, nil, object)
+		}
+	case nodes.RenameStmt:
+		if node.RenameType == nodes.OBJECT_SCHEMA && node.Subname != nil {
+			a.owned(nodes.OBJECT_SCHEMA, nil, nodes.String{Str: *node.Subname})
+		} else if node.Relation != nil && (node.RenameType == nodes.OBJECT_COLUMN || node.RenameType == nodes.OBJECT_TABCONSTRAINT) {
+			a.owned(node.RelationType, node.Relation, nil)
+		} else {
+			a.owned(node.RenameType, node.Relation, node.Object)
+		}
+	case nodes.AlterObjectSchemaStmt:
+		a.owned(node.ObjectType, node.Relation, node.Object)
+		if node.Newschema != nil {
+			a.createIn(*node.Newschema)
+		}
+	case nodes.AlterOwnerStmt:
+		a.owned(node.ObjectType, node.Relation, node.Object)
+	case nodes.CommentStmt:
+		a.owned(node.Objtype, nil, node.Object)
+	}
+}
+
+// query adds the privileges of a SELECT, INSERT, UPDATE or DELETE statement,
+// which is resolved as the given statement that contains it
+func (a *analyzer) query(stmt nodes.Node, query nodes.Node) {
+	if query == nil {
+		return
+	}
+	result := resolve.ResolveStatement(a.catalog, stmt)
+
+	ctes := map[string]bool{}
+	// Locations of the target columns of INSERT, UPDATE and ON CONFLICT,
+	// which are written instead of read
+	targets := map[int]bool{}
+	inserts := map[int]bool{}
+	var insertTables, readTables []*catalog.Table
+	nodes.Walk(query, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		switch node := node.(type) {
+		case nodes.CommonTableExpr:
+			if node.Ctename != nil {
+				ctes[*node.Ctename] = true
+			}
+		case nodes.InsertStmt:
+			if table := a.table(node.Relation); table != nil {
+				insertTables = append(insertTables, table)
+			}
+			for _, item := range node.Cols.Items {
+				if resTarget, ok := item.(nodes.ResTarget); ok {
+					targets[resTarget.Location] = true
+					inserts[resTarget.Location] = true
+				}
+			}
+			if node.OnConflictClause != nil {
+				for _, item := range node.OnConflictClause.TargetList.Items {
+					if resTarget, ok := item.(nodes.ResTarget); ok {
+						targets[resTarget.Location] = true
+					}
+				}
+			}
+		case nodes.UpdateStmt:
+			for _, item := range node.TargetList.Items {
+				if resTarget, ok := item.(nodes.ResTarget); ok {
+					targets[resTarget.Location] = true
+				}
+			}
+		case nodes.DeleteStmt:
+			if table := a.table(node.Relation); table != nil {
+				a.require(PrivilegeDelete, tableObject(table))
+			}
+		case nodes.SelectStmt:
+			if len(node.LockingClause.Items) > 0 {
+				a.lockedTables(node, ctes)
+			}
+		case nodes.RangeVar:
+			if isTarget(parentNode, parentFieldName) {
+				return false
+			}
+			if schema, name := rangeVarNames(&node); schema != "" || !ctes[name] {
+				if table := a.table(&node); table != nil {
+					readTables = append(readTables, table)
+				}
+			}
+		case nodes.FuncCall:
+			a.funcCall(node, "")
+		}
+		return true
+	})
+
+	selected := map[string]bool{}
+	for _, reference := range result.References {
+		if targets[reference.Location] {
+			continue
+		}
+		for _, binding := range reference.Bindings {
+			if source := binding.Source; !source.IsZero() {
+				a.require(PrivilegeSelect, Object{Kind: ObjectTable, Schema: source.Schema, Name: source.Table}, source.Column)
+				selected[source.Schema+"."+source.Table] = true
+			}
+		}
+	}
+	// A table none of whose columns are read still needs SELECT, e.g. for
+	// SELECT count(*)
+	for _, table := range readTables {
+		if !selected[table.Schema+"."+table.Name] {
+			a.require(PrivilegeSelect, tableObject(table))
+		}
+	}
+
+	inserted := map[string][]string{}
+	for _, assignment := range result.Assignments {
+		target := assignment.Target
+		object := Object{Kind: ObjectTable, Schema: target.Schema, Name: target.Table}
+		if assignment.Location == -1 || inserts[assignment.Location] {
+			a.require(PrivilegeInsert, object, target.Column)
+			key := target.Schema + "." + target.Table
+			inserted[key] = append(inserted[key], target.Column)
+		} else {
+			a.require(PrivilegeUpdate, object, target.Column)
+		}
+	}
+	for _, table := range insertTables {
+		columns, ok := inserted[table.Schema+"."+table.Name]
+		if !ok {
+			// INSERT ... DEFAULT VALUES
+			a.require(PrivilegeInsert, tableObject(table))
+		}
+		a.defaults(table, columns)
+	}
+}
+
+// lockedTables adds UPDATE on the tables of a SELECT statement that FOR
+// UPDATE or FOR SHARE locks: the ones its locking clauses name, or all of
+// the tables of the FROM clause for a clause without a list of relations.
+// Locking a subquery locks the tables it reads.
+func (a *analyzer) lockedTables(node nodes.SelectStmt, ctes map[string]bool) {
+	for _, item := range node.LockingClause.Items {
+		lockingClause, ok := item.(nodes.LockingClause)
+		if !ok {
+			continue
+		}
+		var lockedNames map[string]bool
+		if len(lockingClause.LockedRels.Items) > 0 {
+			lockedNames = map[string]bool{}
+			for _, lockedRel := range lockingClause.LockedRels.Items {
+				if rangeVar, ok := lockedRel.(nodes.RangeVar); ok && rangeVar.Relname != nil {
+					lockedNames[*rangeVar.Relname] = true
+				}
+			}
+		}
+		for _, fromItem := range node.FromClause.Items {
+			a.lockedFromItem(fromItem, lockedNames, ctes)
+		}
+	}
+}
+
+// lockedFromItem adds UPDATE on the tables of a FROM clause item that are
+// named in lockedNames, or on all of them if lockedNames is nil
+func (a *analyzer) lockedFromItem(node nodes.Node, lockedNames map[string]bool, ctes map[string]bool) {
+	switch node := node.(type) {
+	case nodes.RangeVar:
+		schema, name := rangeVarNames(&node)
+		if name == "" || (schema == "" && ctes[name]) {
+			return
+		}
+		if node.Alias != nil && node.Alias.Aliasname != nil {
+			name = *node.Alias.Aliasname
+		}
+		if lockedNames == nil || lockedNames[name] {
+			if table := a.table(&node); table != nil {
+				a.require(PrivilegeUpdate, tableObject(table))
+			}
+		}
+	case nodes.JoinExpr:
+		a.lockedFromItem(node.Larg, lockedNames, ctes)
+		a.lockedFromItem(node.Rarg, lockedNames, ctes)
+	case nodes.RangeSubselect:
+		if lockedNames != nil && (node.Alias == nil || node.Alias.Aliasname == nil || !lockedNames[*node.Alias.Aliasname]) {
+			return
+		}
+		nodes.Walk(node.Subquery, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+			if rangeVar, ok := node.(nodes.RangeVar); ok {
+				a.lockedFromItem(rangeVar, nil, ctes)
+			}
+			return true
+		})
+	}
+}
+
+// isTarget returns whether a relation is the target of INSERT, UPDATE or
+// DELETE, or the new table of SELECT INTO, which aren't read
+func isTarget(parentNode nodes.Node, parentFieldName string) bool {
+	switch parentNode.(type) {
+	case nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt:
+		return parentFieldName == "Relation"
+	case nodes.IntoClause:
+		return parentFieldName == "Rel"
+	}
+	return false
+}
+
+// defaults adds the privileges needed to evaluate the defaults of the
+// columns INSERT or COPY doesn't assign to
+func (a *analyzer) defaults(table *catalog.Table, assigned []string) {
+	for _, column := range table.Columns {
+		if column.Default == "" || containsString(assigned, column.Name) {
+			continue
+		}
+		tree, err := pg_query.Parse("SELECT " + column.Default)
+		if err != nil {
+			continue
+		}
+		for _, stmt := range tree.Statements {
+			nodes.Walk(stmt, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+				if funcCall, ok := node.(nodes.FuncCall); ok {
+					a.funcCall(funcCall, table.Schema)
+				}
+				return true
+			})
+		}
+	}
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// funcCall adds EXECUTE on the functions of the catalog a call may refer to,
+// and the privileges a sequence function needs on its sequence, which is
+// looked up in the given schema first if it isn't qualified
+func (a *analyzer) funcCall(funcCall nodes.FuncCall, schema string) {
+	functionSchema, name := splitName(stringList(funcCall.Funcname))
+	if privilege, ok := sequenceFunctions[name]; ok && (functionSchema == "" || functionSchema == "pg_catalog") && len(funcCall.Args.Items) > 0 {
+		if sequence := a.sequenceArg(funcCall.Args.Items[0], schema); sequence != nil {
+			a.require(privilege, Object{Kind: ObjectSequence, Schema: sequence.Schema, Name: sequence.Name})
+		}
+	}
+	for _, function := range a.catalog.Functions(functionSchema, name) {
+		if acceptsArgs(function, len(funcCall.Args.Items)) {
+			a.require(PrivilegeExecute, functionObject(function))
+		}
+	}
+}
+
+// acceptsArgs returns whether a function can be called with the given number
+// of arguments, taking defaults into account
+func acceptsArgs(function *catalog.Function, count int) bool {
+	args := function.InArgs()
+	if count > len(args) {
+		return false
+	}
+	for _, arg := range args[count:] {
+		if !arg.HasDefault {
+			return false
+		}
+	}
+	return true
+}
+
+// sequenceArg returns the sequence named by a constant argument, e.g.
+// 's.seq' or 'seq'::regclass
+func (a *analyzer) sequenceArg(node nodes.Node, schema string) *catalog.Sequence {
+	if typeCast, ok := node.(nodes.TypeCast); ok {
+		node = typeCast.Arg
+	}
+	constant, ok := node.(nodes.A_Const)
+	if !ok {
+		return nil
+	}
+	str, ok := constant.Val.(nodes.String)
+	if !ok {
+		return nil
+	}
+	// Unquoted names are folded to lower case, as for regclass
+	names := strings.Split(str.Str, ".")
+	for i, name := range names {
+		if len(name) > 1 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+			names[i] = name[1 : len(name)-1]
+		} else {
+			names[i] = strings.ToLower(name)
+		}
+	}
+	sequenceSchema, name := splitName(names)
+	if sequenceSchema == "" && schema != "" {
+		if s := a.catalog.Schema(schema); s != nil && s.Sequence(name) != nil {
+			return s.Sequence(name)
+		}
+	}
+	return a.catalog.Sequence(sequenceSchema, name)
+}
+
+func (a *analyzer) copyStmt(stmt nodes.CopyStmt) {
+	if stmt.Query != nil {
+		a.query(stmt.Query, stmt.Query)
+		return
+	}
+	table := a.table(stmt.Relation)
+	if table == nil {
+		return
+	}
+	columns := stringList(stmt.Attlist)
+	if len(columns) == 0 {
+		for _, column := range table.Columns {
+			columns = append(columns, column.Name)
+		}
+	}
+	if stmt.IsFrom {
+		a.require(PrivilegeInsert, tableObject(table), columns...)
+		a.defaults(table, columns)
+	} else {
+		a.require(PrivilegeSelect, tableObject(table), columns...)
+	}
+}
+
+// createIn adds CREATE on the schema objects with the given schema name are
+// created in, which is the first schema of the search path that exists if
+// it's empty
+func (a *analyzer) createIn(schema string) {
+	if schema == "" {
+		for _, name := range a.catalog.SearchPath {
+			if a.catalog.Schema(name) != nil {
+				schema = name
+				break
+			}
+		}
+	}
+	if schema != "" {
+		a.require(PrivilegeCreate, Object{Kind: ObjectSchema, Name: schema})
+	}
+}
+
+func (a *analyzer) create(rangeVar *nodes.RangeVar) {
+	schema, _ := rangeVarNames(rangeVar)
+	a.createIn(schema)
+}
+
+// tableElement adds the privileges a column definition or constraint of a
+// new or altered table needs: USAGE on the type of a column and REFERENCES on
+// the columns a foreign key refers to
+func (a *analyzer) tableElement(node nodes.Node) {
+	switch node := node.(type) {
+	case nodes.ColumnDef:
+		a.typeUsage(node.TypeName)
+		for _, constraint := range node.Constraints.Items {
+			a.tableElement(constraint)
+		}
+	case nodes.Constraint:
+		if node.Contype != nodes.CONSTR_FOREIGN {
+			return
+		}
+		table := a.table(node.Pktable)
+		if table == nil {
+			return
+		}
+		columns := stringList(node.PkAttrs)
+		if len(columns) == 0 {
+			if primaryKey := table.PrimaryKey(); primaryKey != nil {
+				columns = primaryKey.Columns
+			}
+		}
+		a.require(PrivilegeReferences, tableObject(table), columns...)
+	}
+}
+
+// typeUsage adds USAGE on a type of the catalog
+func (a *analyzer) typeUsage(node *nodes.TypeName) {
+	if node == nil {
+		return
+	}
+	typ, err := a.catalog.ResolveType(*node)
+	if err != nil || typ.IsBuiltin() || a.catalog.Type(typ.Schema, typ.Name) == nil {
+		return
+	}
+	a.require(PrivilegeUsage, Object{Kind: ObjectType, Schema: typ.Schema, Name: typ.Name})
+}
+
+func (a *analyzer) createFunction(stmt nodes.CreateFunctionStmt) {
+	schema, name := splitName(stringList(stmt.Funcname))
+	args := []catalog.TypeName{}
+	for _, item := range stmt.Parameters.Items {
+		parameter, ok := item.(nodes.FunctionParameter)
+		if !ok || parameter.ArgType == nil {
+			continue
+		}
+		a.typeUsage(parameter.ArgType)
+		if parameter.Mode == nodes.FUNC_PARAM_OUT || parameter.Mode == nodes.FUNC_PARAM_TABLE {
+			continue
+		}
+		typ, _ := a.catalog.ResolveType(*parameter.ArgType)
+		args = append(args, typ)
+	}
+	a.typeUsage(stmt.ReturnType)
+	if function := a.function(schema, name, args); stmt.Replace && function != nil {
+		a.require(PrivilegeOwnership, functionObject(function))
+		return
+	}
+	a.createIn(schema)
+}
+
+// function returns the function of the catalog with the given argument
+// types, or nil
+func (a *analyzer) function(schema string, name string, args []catalog.TypeName) *catalog.Function {
+	for _, function := range a.catalog.Functions(schema, name) {
+		inArgs := function.InArgs()
+		if len(inArgs) != len(args) {
+			continue
+		}
+		same := true
+		for i, arg := range inArgs {
+			same = same && arg.Type.Equal(args[i])
+		}
+		if same {
+			return function
+		}
+	}
+	return nil
+}
+
+// owned adds OWNERSHIP of an object DDL changes, given as a relation or as
+// the names of another object
+func (a *analyzer) owned(objectType nodes.ObjectType, relation *nodes.RangeVar, object nodes.Node) {
+	var names []string
+	if relation != nil {
+		schema, name := rangeVarNames(relation)
+		names = []string{name}
+		if schema != "" {
+			names = []string{schema, name}
+		}
+	} else {
+		switch node := object.(type) {
+		case nodes.List:
+			names = stringList(node)
+		case nodes.String:
+			names = []string{node.Str}
+		case nodes.TypeName:
+			names = stringList(node.Names)
+		case nodes.ObjectWithArgs:
+			names = stringList(node.Objname)
+		}
+	}
+
+	switch objectType {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW, nodes.OBJECT_FOREIGN_TABLE:
+		schema, name := splitName(names)
+		if table := a.catalog.Table(schema, name); table != nil {
+			a.require(PrivilegeOwnership, tableObject(table))
+		}
+	case nodes.OBJECT_COLUMN:
+		// The last name is the column's
+		if len(names) > 1 {
+			a.owned(nodes.OBJECT_TABLE, nil, listOf(names[:len(names)-1]))
+		}
+	case nodes.OBJECT_INDEX:
+		schema, name := splitName(names)
+		for _, s := range a.searchSchemas(schema) {
+			if index, table := s.Index(name); index != nil {
+				a.require(PrivilegeOwnership, tableObject(table))
+				break
+			}
+		}
+	case nodes.OBJECT_SEQUENCE:
+		schema, name := splitName(names)
+		if sequence := a.catalog.Sequence(schema, name); sequence != nil {
+			a.require(PrivilegeOwnership, Object{Kind: ObjectSequence, Schema: sequence.Schema, Name: sequence.Name})
+		}
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		schema, name := splitName(names)
+		if typ := a.catalog.Type(schema, name); typ != nil {
+			a.require(PrivilegeOwnership, Object{Kind: ObjectType, Schema: typ.Schema, Name: typ.Name})
+		}
+	case nodes.OBJECT_SCHEMA:
+		if len(names) == 1 && a.catalog.Schema(names[0]) != nil {
+			a.require(PrivilegeOwnership, Object{Kind: ObjectSchema, Name: names[0]})
+		}
+	case nodes.OBJECT_FUNCTION:
+		schema, name := splitName(names)
+		objectWithArgs, _ := object.(nodes.ObjectWithArgs)
+		if objectWithArgs.ArgsUnspecified {
+			if functions := a.catalog.Functions(schema, name); len(functions) == 1 {
+				a.require(PrivilegeOwnership, functionObject(functions[0]))
+			}
+			return
+		}
+		args := []catalog.TypeName{}
+		for _, item := range objectWithArgs.Objargs.Items {
+			if typeName, ok := item.(nodes.TypeName); ok {
+				typ, _ := a.catalog.ResolveType(typeName)
+				args = append(args, typ)
+			}
+		}
+		if function := a.function(schema, name, args); function != nil {
+			a.require(PrivilegeOwnership, functionObject(function))
+		}
+	}
+}
+
+func listOf(names []string) nodes.List {
+	list := nodes.List{}
+	for _, name := range names {
+		list.Items = append(list.Items, nodes.String{Str: name})
+	}
+	return list
+}
+
+// searchSchemas returns the given schema, or the schemas of the search path
+// if it's empty
+func (a *analyzer) searchSchemas(schema string) []*catalog.Schema {
+	if schema != "" {
+		if s := a.catalog.Schema(schema); s != nil {
+			return []*catalog.Schema{s}
+		}
+		return nil
+	}
+	schemas := []*catalog.Schema{}
+	for _, name := range a.catalog.SearchPath {
+		if s := a.catalog.Schema(name); s != nil {
+			schemas = append(schemas, s)
+		}
+	}
+	return schemas
+}
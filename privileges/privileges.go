@@ -0,0 +1,309 @@
+// Package privileges computes the privileges a role needs to run statements,
+// e.g. to generate the minimal GRANT statements for the queries of a service.
+package privileges
+
+import (
+	"sort"
+	"strings"
+
+	pg_query "github.com/tomaszjonak/pg_query_go"
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// Privilege is a privilege on an object, or the ownership of it
+type Privilege int
+
+const (
+	PrivilegeSelect Privilege = iota
+	PrivilegeInsert
+	PrivilegeUpdate
+	PrivilegeDelete
+	PrivilegeTruncate
+	PrivilegeReferences
+	PrivilegeUsage
+	PrivilegeExecute
+	PrivilegeCreate
+	// Owning the object, or being a member of the role that owns it, which
+	// can't be granted as a privilege
+	PrivilegeOwnership
+)
+
+var privilegeNames = map[Privilege]string{
+	PrivilegeSelect:     "SELECT",
+	PrivilegeInsert:     "INSERT",
+	PrivilegeUpdate:     "UPDATE",
+	PrivilegeDelete:     "DELETE",
+	PrivilegeTruncate:   "TRUNCATE",
+	PrivilegeReferences: "REFERENCES",
+	PrivilegeUsage:      "USAGE",
+	PrivilegeExecute:    "EXECUTE",
+	PrivilegeCreate:     "CREATE",
+	PrivilegeOwnership:  "OWNERSHIP",
+}
+
+func (privilege Privilege) String() string {
+	return privilegeNames[privilege]
+}
+
+// ObjectKind is the kind of object a privilege is on
+type ObjectKind int
+
+const (
+	// A table, view or materialized view
+	ObjectTable ObjectKind = iota
+	ObjectSequence
+	ObjectFunction
+	// A type or domain
+	ObjectType
+	ObjectSchema
+	// The current database
+	ObjectDatabase
+)
+
+var objectKindNames = map[ObjectKind]string{
+	ObjectTable:    "TABLE",
+	ObjectSequence: "SEQUENCE",
+	ObjectFunction: "FUNCTION",
+	ObjectType:     "TYPE",
+	ObjectSchema:   "SCHEMA",
+	ObjectDatabase: "DATABASE",
+}
+
+func (kind ObjectKind) String() string {
+	return objectKindNames[kind]
+}
+
+// Object is an object of the catalog
+type Object struct {
+	Kind ObjectKind
+
+	// Schema the object is in, empty for schemas and the database
+	Schema string
+
+	// Name of the object, empty for the database
+	Name string
+
+	// Argument types of a function
+	Args []catalog.TypeName
+}
+
+func (object Object) String() string {
+	name := object.Name
+	if object.Schema != "" {
+		name = object.Schema + "." + name
+	}
+	if object.Kind == ObjectFunction {
+		args := make([]string, len(object.Args))
+		for i, arg := range object.Args {
+			args[i] = arg.String()
+		}
+		name += "(" + strings.Join(args, ", ") + ")"
+	}
+	if name == "" {
+		return object.Kind.String()
+	}
+	return object.Kind.String() + " " + name
+}
+
+// Requirement is a privilege a role needs on an object
+type Requirement struct {
+	Privilege Privilege
+	Object    Object
+
+	// Columns of a table the privilege is needed on, in alphabetical order,
+	// empty if it's needed on the table itself
+	Columns []string
+}
+
+func (requirement Requirement) String() string {
+	privilege := requirement.Privilege.String()
+	if len(requirement.Columns) > 0 {
+		privilege += " (" + strings.Join(requirement.Columns, ", ") + ")"
+	}
+	return privilege + " ON " + requirement.Object.String()
+}
+
+// Required - Parses the given SQL and returns the privileges each statement
+// requires
+func Required(c *catalog.Catalog, input string) ([][]Requirement, error) {
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return RequiredTree(c, tree), nil
+}
+
+// RequiredTree returns the privileges each statement of a parse tree
+// requires
+func RequiredTree(c *catalog.Catalog, tree pg_query.ParsetreeList) [][]Requirement {
+	requirements := [][]Requirement{}
+	for _, item := range tree.Statements {
+		stmt, _ := nodes.UnwrapRawStmt(item)
+		requirements = append(requirements, RequiredStatement(c, stmt))
+	}
+	return requirements
+}
+
+// RequiredStatement returns the privileges a statement requires, merged and
+// sorted by object, as PostgreSQL checks them against the objects of the
+// catalog:
+//
+// - SELECT on the columns a query reads, or on the table if it reads none of
+// them, e.g. for count(*), and UPDATE on the tables of FOR UPDATE
+//
+// - INSERT, UPDATE and DELETE on the target table of DML, and SELECT on the
+// columns its conditions, SET clauses and RETURNING read
+//
+// - SELECT or INSERT on the columns COPY reads or writes, and TRUNCATE on the
+// tables of TRUNCATE
+//
+// - EXECUTE on the functions of the catalog a statement calls, including the
+// defaults INSERT evaluates, and USAGE or UPDATE on the sequences passed to
+// nextval(), currval() and setval()
+//
+// - CREATE on the schema of new objects, OWNERSHIP of the objects DDL alters,
+// drops, renames, comments on or indexes, REFERENCES on the columns foreign
+// keys refer to and USAGE on the types of new columns
+//
+// - USAGE on the schema of each of these objects
+//
+// Objects that aren't in the catalog, such as built-in functions, are
+// skipped.
+func RequiredStatement(c *catalog.Catalog, node nodes.Node) []Requirement {
+	a := &analyzer{catalog: c}
+	a.statement(node)
+	for _, requirement := range a.requirements {
+		if requirement.Object.Schema != "" && requirement.Object.Schema != "pg_catalog" {
+			a.require(PrivilegeUsage, Object{Kind: ObjectSchema, Name: requirement.Object.Schema})
+		}
+	}
+	return Merge(a.requirements)
+}
+
+// Merge combines the requirements for the same privilege on the same object,
+// e.g. of the statements of a query log, and sorts them by object
+func Merge(requirements []Requirement) []Requirement {
+	merged := []Requirement{}
+	indexes := map[string]int{}
+	for _, requirement := range requirements {
+		key := requirement.Privilege.String() + " " + requirement.Object.String()
+		i, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(merged)
+			requirement.Columns = mergeColumns(nil, requirement.Columns)
+			merged = append(merged, requirement)
+			continue
+		}
+		// A privilege on the table covers all of its columns
+		if len(merged[i].Columns) == 0 || len(requirement.Columns) == 0 {
+			merged[i].Columns = nil
+		} else {
+			merged[i].Columns = mergeColumns(merged[i].Columns, requirement.Columns)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Object.Kind != merged[j].Object.Kind {
+			return merged[i].Object.Kind < merged[j].Object.Kind
+		}
+		if key, other := merged[i].Object.String(), merged[j].Object.String(); key != other {
+			return key < other
+		}
+		return merged[i].Privilege < merged[j].Privilege
+	})
+	return merged
+}
+
+func mergeColumns(columns []string, others []string) []string {
+	for _, other := range others {
+		found := false
+		for _, column := range columns {
+			if column == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			columns = append(columns, other)
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// Grants returns the GRANT statements that give a role the required
+// privileges, one per object. OWNERSHIP and privileges on the database can't
+// be granted this way and are left out.
+func Grants(requirements []Requirement, role string) ([]string, error) {
+	statements := []string{}
+	requirements = Merge(requirements)
+	for i := 0; i < len(requirements); {
+		object := requirements[i].Object
+		stmt := nodes.GrantStmt{
+			IsGrant:  true,
+			Targtype: nodes.ACL_TARGET_OBJECT,
+			Grantees: nodes.List{Items: []nodes.Node{nodes.RoleSpec{Roletype: nodes.ROLESPEC_CSTRING, Rolename: &role, Location: -1}}},
+		}
+		for ; i < len(requirements) && requirements[i].Object.String() == object.String(); i++ {
+			requirement := requirements[i]
+			if requirement.Privilege == PrivilegeOwnership {
+				continue
+			}
+			name := strings.ToLower(requirement.Privilege.String())
+			privilege := nodes.AccessPriv{PrivName: &name}
+			for _, column := range requirement.Columns {
+				privilege.Cols.Items = append(privilege.Cols.Items, nodes.String{Str: column})
+			}
+			stmt.Privileges.Items = append(stmt.Privileges.Items, privilege)
+		}
+		if len(stmt.Privileges.Items) == 0 || !grantObject(&stmt, object) {
+			continue
+		}
+		statement, err := pg_query.DeparseItem(stmt)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+// grantObject sets the object of a GRANT statement, returning false for
+// objects privileges can't be granted on
+func grantObject(stmt *nodes.GrantStmt, object Object) bool {
+	schema, name := object.Schema, object.Name
+	switch object.Kind {
+	case ObjectTable, ObjectSequence:
+		stmt.Objtype = nodes.ACL_OBJECT_RELATION
+		if object.Kind == ObjectSequence {
+			stmt.Objtype = nodes.ACL_OBJECT_SEQUENCE
+		}
+		stmt.Objects.Items = []nodes.Node{nodes.RangeVar{Schemaname: &schema, Relname: &name, Inh: true, Location: -1}}
+	case ObjectFunction:
+		stmt.Objtype = nodes.ACL_OBJECT_FUNCTION
+		function := nodes.ObjectWithArgs{Objname: nodes.List{Items: []nodes.Node{nodes.String{Str: schema}, nodes.String{Str: name}}}}
+		for _, arg := range object.Args {
+			function.Objargs.Items = append(function.Objargs.Items, typeName(arg))
+		}
+		stmt.Objects.Items = []nodes.Node{function}
+	case ObjectType:
+		stmt.Objtype = nodes.ACL_OBJECT_TYPE
+		stmt.Objects.Items = []nodes.Node{typeName(catalog.TypeName{Schema: schema, Name: name})}
+	case ObjectSchema:
+		stmt.Objtype = nodes.ACL_OBJECT_NAMESPACE
+		stmt.Objects.Items = []nodes.Node{nodes.String{Str: name}}
+	default:
+		return false
+	}
+	return true
+}
+
+// typeName returns the parse tree node of a type name, without type
+// modifiers
+func typeName(typ catalog.TypeName) nodes.TypeName {
+	node := nodes.TypeName{Names: nodes.List{Items: []nodes.Node{nodes.String{Str: typ.Schema}, nodes.String{Str: typ.Name}}}, Typemod: -1, Location: -1}
+	for i := 0; i < typ.ArrayDims; i++ {
+		node.ArrayBounds.Items = append(node.ArrayBounds.Items, nodes.Integer{Ival: -1})
+	}
+	return node
+}
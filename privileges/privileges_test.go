@@ -0,0 +1,275 @@
+package privileges_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	"github.com/tomaszjonak/pg_query_go/privileges"
+)
+
+const schema = `
+CREATE TABLE users (id serial PRIMARY KEY, name text NOT NULL, email text);
+CREATE TABLE orders (id serial PRIMARY KEY, user_id int REFERENCES users, total numeric);
+CREATE VIEW big_orders AS SELECT * FROM orders WHERE total > 100;
+CREATE SCHEMA app;
+CREATE TYPE app.mood AS ENUM ('ok', 'sad');
+CREATE TABLE app.events (id bigserial, at timestamptz);
+CREATE SEQUENCE app.counter;
+CREATE FUNCTION app.score(int) RETURNS int AS 'SELECT 1' LANGUAGE sql;
+`
+
+func newCatalog(t *testing.T) *catalog.Catalog {
+	c := catalog.New()
+	if err := c.Apply(schema); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+var requiredTests = []struct {
+	input        string
+	requirements []string
+}{
+	{
+		"SELECT u.name, count(*) FROM users u JOIN orders o ON o.user_id = u.id GROUP BY 1",
+		[]string{
+			"SELECT (user_id) ON TABLE public.orders",
+			"SELECT (id, name) ON TABLE public.users",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"SELECT count(*) FROM users",
+		[]string{"SELECT ON TABLE public.users", "USAGE ON SCHEMA public"},
+	},
+	{
+		"SELECT * FROM big_orders",
+		[]string{"SELECT (id, total, user_id) ON TABLE public.big_orders", "USAGE ON SCHEMA public"},
+	},
+	{
+		"SELECT app.score(id), nextval('app.counter'), lower(name) FROM users WHERE id IN (SELECT user_id FROM orders)",
+		[]string{
+			"SELECT (user_id) ON TABLE public.orders",
+			"SELECT (id, name) ON TABLE public.users",
+			"USAGE ON SEQUENCE app.counter",
+			"EXECUTE ON FUNCTION app.score(integer)",
+			"USAGE ON SCHEMA app",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"SELECT * FROM orders FOR UPDATE",
+		[]string{
+			"SELECT (id, total, user_id) ON TABLE public.orders",
+			"UPDATE ON TABLE public.orders",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"SELECT u.name FROM users u JOIN orders o ON o.user_id = u.id FOR UPDATE OF u",
+		[]string{
+			"SELECT (user_id) ON TABLE public.orders",
+			"SELECT (id, name) ON TABLE public.users",
+			"UPDATE ON TABLE public.users",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"SELECT count(*) FROM users TABLESAMPLE system (10)",
+		[]string{"SELECT ON TABLE public.users", "USAGE ON SCHEMA public"},
+	},
+	{
+		// The default of id calls nextval()
+		"INSERT INTO users (name) VALUES ('x') RETURNING id",
+		[]string{
+			"SELECT (id) ON TABLE public.users",
+			"INSERT (name) ON TABLE public.users",
+			"USAGE ON SEQUENCE public.users_id_seq",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"INSERT INTO app.events SELECT id, now() FROM users",
+		[]string{
+			"INSERT (at, id) ON TABLE app.events",
+			"SELECT (id) ON TABLE public.users",
+			"USAGE ON SCHEMA app",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"INSERT INTO users DEFAULT VALUES",
+		[]string{
+			"INSERT ON TABLE public.users",
+			"USAGE ON SEQUENCE public.users_id_seq",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"INSERT INTO users (id, name) VALUES (1, 'a') ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE users.email IS NULL",
+		[]string{
+			"SELECT (email, id) ON TABLE public.users",
+			"INSERT (id, name) ON TABLE public.users",
+			"UPDATE (name) ON TABLE public.users",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"UPDATE orders SET total = total * 2 WHERE user_id = 1",
+		[]string{
+			"SELECT (total, user_id) ON TABLE public.orders",
+			"UPDATE (total) ON TABLE public.orders",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"DELETE FROM orders",
+		[]string{"DELETE ON TABLE public.orders", "USAGE ON SCHEMA public"},
+	},
+	{
+		"WITH deleted AS (DELETE FROM orders WHERE total < 0 RETURNING user_id) SELECT name FROM users WHERE id IN (SELECT user_id FROM deleted)",
+		[]string{
+			"SELECT (total, user_id) ON TABLE public.orders",
+			"DELETE ON TABLE public.orders",
+			"SELECT (id, name) ON TABLE public.users",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"COPY users (name) FROM STDIN",
+		[]string{
+			"INSERT (name) ON TABLE public.users",
+			"USAGE ON SEQUENCE public.users_id_seq",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"COPY orders TO STDOUT",
+		[]string{"SELECT (id, total, user_id) ON TABLE public.orders", "USAGE ON SCHEMA public"},
+	},
+	{
+		"COPY (SELECT email FROM users) TO STDOUT",
+		[]string{"SELECT (email) ON TABLE public.users", "USAGE ON SCHEMA public"},
+	},
+	{
+		"TRUNCATE users, orders",
+		[]string{
+			"TRUNCATE ON TABLE public.orders",
+			"TRUNCATE ON TABLE public.users",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"CREATE TABLE app.visits (user_id int REFERENCES users, mood app.mood)",
+		[]string{
+			"REFERENCES (id) ON TABLE public.users",
+			"USAGE ON TYPE app.mood",
+			"USAGE ON SCHEMA app",
+			"CREATE ON SCHEMA app",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"CREATE TABLE names AS SELECT name FROM users",
+		[]string{
+			"SELECT (name) ON TABLE public.users",
+			"USAGE ON SCHEMA public",
+			"CREATE ON SCHEMA public",
+		},
+	},
+	{
+		"CREATE OR REPLACE VIEW big_orders AS SELECT * FROM orders",
+		[]string{
+			"OWNERSHIP ON TABLE public.big_orders",
+			"SELECT (id, total, user_id) ON TABLE public.orders",
+			"USAGE ON SCHEMA public",
+		},
+	},
+	{
+		"CREATE INDEX ON orders (total)",
+		[]string{"OWNERSHIP ON TABLE public.orders", "USAGE ON SCHEMA public"},
+	},
+	{
+		"DROP TABLE orders, missing",
+		[]string{"OWNERSHIP ON TABLE public.orders", "USAGE ON SCHEMA public"},
+	},
+	{
+		"DROP INDEX orders_pkey",
+		[]string{"OWNERSHIP ON TABLE public.orders", "USAGE ON SCHEMA public"},
+	},
+	{
+		"DROP FUNCTION app.score(int)",
+		[]string{"OWNERSHIP ON FUNCTION app.score(integer)", "USAGE ON SCHEMA app"},
+	},
+	{
+		"COMMENT ON COLUMN users.name IS 'Full name'",
+		[]string{"OWNERSHIP ON TABLE public.users", "USAGE ON SCHEMA public"},
+	},
+	{
+		"ALTER SEQUENCE app.counter SET SCHEMA public",
+		[]string{
+			"OWNERSHIP ON SEQUENCE app.counter",
+			"USAGE ON SCHEMA app",
+			"CREATE ON SCHEMA public",
+		},
+	},
+	{
+		"ALTER SCHEMA app RENAME TO application",
+		[]string{"OWNERSHIP ON SCHEMA app"},
+	},
+	{
+		"CREATE SCHEMA reporting",
+		[]string{"CREATE ON DATABASE"},
+	},
+}
+
+func TestRequired(t *testing.T) {
+	c := newCatalog(t)
+	for _, test := range requiredTests {
+		requirements, err := privileges.Required(c, test.input)
+		if err != nil {
+			t.Errorf("Required(%s)\nerror %s\n\n", test.input, err)
+			continue
+		}
+		actual := []string{}
+		for _, requirement := range requirements[0] {
+			actual = append(actual, requirement.String())
+		}
+		if !reflect.DeepEqual(actual, test.requirements) {
+			t.Errorf("Required(%s)\ngot %#v\nexpected %#v\n\n", test.input, actual, test.requirements)
+		}
+	}
+}
+
+func TestGrants(t *testing.T) {
+	c := newCatalog(t)
+	requirements, err := privileges.Required(c, `
+		SELECT u.name FROM users u;
+		INSERT INTO app.events (at) VALUES (now());
+		SELECT app.score(id) FROM users;
+		DROP TABLE orders;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := []privileges.Requirement{}
+	for _, statement := range requirements {
+		all = append(all, statement...)
+	}
+	actual, err := privileges.Grants(all, "reporting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`GRANT INSERT ("at") ON TABLE "app"."events" TO reporting`,
+		`GRANT SELECT ("id", "name") ON TABLE "public"."users" TO reporting`,
+		`GRANT USAGE ON SEQUENCE "app"."events_id_seq" TO reporting`,
+		`GRANT EXECUTE ON FUNCTION app.score(int) TO reporting`,
+		`GRANT USAGE ON SCHEMA app TO reporting`,
+		`GRANT USAGE ON SCHEMA public TO reporting`,
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Grants\ngot %#v\nexpected %#v\n\n", actual, expected)
+	}
+}
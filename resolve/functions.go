@@ -0,0 +1,227 @@
+package resolve
+
+import (
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// nullability tells when the result of a function is NULL
+type nullability int
+
+const (
+	// The result is NULL if any argument is, as for strict functions
+	nullIfAnyArg nullability = iota
+	// The result is never NULL
+	neverNull
+	// The result can be NULL even for non-NULL arguments, e.g. for
+	// aggregates over no rows
+	mayBeNull
+)
+
+// builtinFunction describes the arguments and the result of a built-in
+// function
+type builtinFunction struct {
+	// Internal name of the result type, or one of the polymorphic types
+	// below that depend on the type of the first argument
+	result      string
+	nullability nullability
+
+	// Internal names of the types of the leading arguments, "" for arguments
+	// whose type depends on the call. Overloaded arguments have the type
+	// PostgreSQL prefers for arguments of unknown type, e.g. text for
+	// strings.
+	args []string
+}
+
+const (
+	// The type of the first argument
+	anyElement = "anyelement"
+	// The array type of the first argument
+	anyArray = "anyarray"
+	// The element type of the first argument, which is an array
+	anyArrayElem = "anyarrayelem"
+	// The type sum() returns for the first argument
+	sumResult = "sumresult"
+	// The type avg() returns for the first argument
+	avgResult = "avgresult"
+)
+
+// Built-in functions by name
+var builtinFunctions = map[string]builtinFunction{
+	// Aggregates
+	"array_agg":        {anyArray, mayBeNull, nil},
+	"avg":              {avgResult, mayBeNull, nil},
+	"bit_and":          {anyElement, mayBeNull, nil},
+	"bit_or":           {anyElement, mayBeNull, nil},
+	"bool_and":         {"bool", mayBeNull, nil},
+	"bool_or":          {"bool", mayBeNull, nil},
+	"count":            {"int8", neverNull, nil},
+	"every":            {"bool", mayBeNull, nil},
+	"json_agg":         {"json", mayBeNull, nil},
+	"json_object_agg":  {"json", mayBeNull, nil},
+	"jsonb_agg":        {"jsonb", mayBeNull, nil},
+	"jsonb_object_agg": {"jsonb", mayBeNull, nil},
+	"max":              {anyElement, mayBeNull, nil},
+	"min":              {anyElement, mayBeNull, nil},
+	"stddev":           {avgResult, mayBeNull, nil},
+	"string_agg":       {"text", mayBeNull, []string{"text", "text"}},
+	"sum":              {sumResult, mayBeNull, nil},
+	"variance":         {avgResult, mayBeNull, nil},
+	"xmlagg":           {"xml", mayBeNull, nil},
+
+	// Window functions
+	"cume_dist":    {"float8", neverNull, nil},
+	"dense_rank":   {"int8", neverNull, nil},
+	"first_value":  {anyElement, mayBeNull, nil},
+	"lag":          {anyElement, mayBeNull, []string{"", "int4"}},
+	"last_value":   {anyElement, mayBeNull, nil},
+	"lead":         {anyElement, mayBeNull, []string{"", "int4"}},
+	"nth_value":    {anyElement, mayBeNull, []string{"", "int4"}},
+	"ntile":        {"int4", mayBeNull, []string{"int4"}},
+	"percent_rank": {"float8", neverNull, nil},
+	"rank":         {"int8", neverNull, nil},
+	"row_number":   {"int8", neverNull, nil},
+
+	// Math
+	"abs":    {anyElement, nullIfAnyArg, nil},
+	"ceil":   {anyElement, nullIfAnyArg, nil},
+	"floor":  {anyElement, nullIfAnyArg, nil},
+	"mod":    {anyElement, nullIfAnyArg, nil},
+	"power":  {"float8", nullIfAnyArg, []string{"float8", "float8"}},
+	"random": {"float8", neverNull, nil},
+	"round":  {anyElement, nullIfAnyArg, []string{"", "int4"}},
+	"sqrt":   {"float8", nullIfAnyArg, []string{"float8"}},
+	"trunc":  {anyElement, nullIfAnyArg, []string{"", "int4"}},
+
+	// Strings
+	"btrim":          {"text", nullIfAnyArg, []string{"text", "text"}},
+	"char_length":    {"int4", nullIfAnyArg, []string{"text"}},
+	"concat":         {"text", neverNull, nil},
+	"concat_ws":      {"text", mayBeNull, []string{"text"}},
+	"format":         {"text", mayBeNull, []string{"text"}},
+	"initcap":        {"text", nullIfAnyArg, []string{"text"}},
+	"left":           {"text", nullIfAnyArg, []string{"text", "int4"}},
+	"length":         {"int4", nullIfAnyArg, []string{"text"}},
+	"lower":          {"text", nullIfAnyArg, []string{"text"}},
+	"lpad":           {"text", nullIfAnyArg, []string{"text", "int4", "text"}},
+	"ltrim":          {"text", nullIfAnyArg, []string{"text", "text"}},
+	"md5":            {"text", nullIfAnyArg, []string{"text"}},
+	"position":       {"int4", nullIfAnyArg, []string{"text", "text"}},
+	"regexp_replace": {"text", nullIfAnyArg, []string{"text", "text", "text", "text"}},
+	"repeat":         {"text", nullIfAnyArg, []string{"text", "int4"}},
+	"replace":        {"text", nullIfAnyArg, []string{"text", "text", "text"}},
+	"right":          {"text", nullIfAnyArg, []string{"text", "int4"}},
+	"rpad":           {"text", nullIfAnyArg, []string{"text", "int4", "text"}},
+	"rtrim":          {"text", nullIfAnyArg, []string{"text", "text"}},
+	"split_part":     {"text", nullIfAnyArg, []string{"text", "text", "int4"}},
+	"strpos":         {"int4", nullIfAnyArg, []string{"text", "text"}},
+	"substr":         {"text", nullIfAnyArg, []string{"text", "int4", "int4"}},
+	"substring":      {"text", nullIfAnyArg, []string{"text"}},
+	"to_char":        {"text", nullIfAnyArg, []string{"", "text"}},
+	"translate":      {"text", nullIfAnyArg, []string{"text", "text", "text"}},
+	"trim":           {"text", nullIfAnyArg, []string{"text", "text"}},
+	"upper":          {"text", nullIfAnyArg, []string{"text"}},
+
+	// Date and time
+	"age":                   {"interval", nullIfAnyArg, []string{"timestamptz", "timestamptz"}},
+	"clock_timestamp":       {"timestamptz", neverNull, nil},
+	"date_part":             {"float8", nullIfAnyArg, []string{"text", "timestamptz"}},
+	"date_trunc":            {"timestamptz", nullIfAnyArg, []string{"text", "timestamptz"}},
+	"make_date":             {"date", nullIfAnyArg, []string{"int4", "int4", "int4"}},
+	"make_interval":         {"interval", nullIfAnyArg, []string{"int4", "int4", "int4", "int4", "int4", "int4", "float8"}},
+	"now":                   {"timestamptz", neverNull, nil},
+	"statement_timestamp":   {"timestamptz", neverNull, nil},
+	"timezone":              {"timestamp", nullIfAnyArg, []string{"text", "timestamptz"}},
+	"to_date":               {"date", nullIfAnyArg, []string{"text", "text"}},
+	"to_timestamp":          {"timestamptz", nullIfAnyArg, nil},
+	"transaction_timestamp": {"timestamptz", neverNull, nil},
+
+	// Arrays and JSON
+	"array_length":       {"int4", mayBeNull, []string{"", "int4"}},
+	"array_to_string":    {"text", nullIfAnyArg, []string{"", "text", "text"}},
+	"cardinality":        {"int4", nullIfAnyArg, nil},
+	"json_build_object":  {"json", neverNull, nil},
+	"jsonb_build_object": {"jsonb", neverNull, nil},
+	"json_build_array":   {"json", neverNull, nil},
+	"jsonb_build_array":  {"jsonb", neverNull, nil},
+	"row_to_json":        {"json", nullIfAnyArg, nil},
+	"to_json":            {"json", nullIfAnyArg, nil},
+	"to_jsonb":           {"jsonb", nullIfAnyArg, nil},
+	"unnest":             {anyArrayElem, mayBeNull, nil},
+
+	// Others
+	"currval":          {"int8", neverNull, []string{"regclass"}},
+	"gen_random_uuid":  {"uuid", neverNull, nil},
+	"generate_series":  {anyElement, neverNull, nil},
+	"lastval":          {"int8", neverNull, nil},
+	"nextval":          {"int8", neverNull, []string{"regclass"}},
+	"pg_typeof":        {"regtype", neverNull, nil},
+	"setval":           {"int8", neverNull, []string{"regclass", "int8", "bool"}},
+	"txid_current":     {"int8", neverNull, nil},
+	"uuid_generate_v4": {"uuid", neverNull, nil},
+	"version":          {"text", neverNull, nil},
+}
+
+// lookupBuiltinFunction returns the built-in function a call refers to, if
+// its name is unqualified or qualified with pg_catalog
+func lookupBuiltinFunction(funcCall nodes.FuncCall) (builtinFunction, bool) {
+	names := []string{}
+	for _, item := range funcCall.Funcname.Items {
+		if str, ok := item.(nodes.String); ok {
+			names = append(names, str.Str)
+		}
+	}
+	if len(names) == 0 || (len(names) > 1 && names[0] != "pg_catalog") {
+		return builtinFunction{}, false
+	}
+	function, ok := builtinFunctions[names[len(names)-1]]
+	return function, ok
+}
+
+// argType returns the type of the argument at the given position, unknown
+// if it depends on the call
+func (f builtinFunction) argType(position int) catalog.TypeName {
+	if position >= len(f.args) || f.args[position] == "" {
+		return catalog.TypeName{}
+	}
+	return catalog.BuiltinType(f.args[position])
+}
+
+// resultType returns the result type of a built-in function for the given
+// argument types
+func (f builtinFunction) resultType(args []catalog.TypeName) catalog.TypeName {
+	first := catalog.TypeName{}
+	if len(args) > 0 {
+		first = args[0]
+	}
+	switch f.result {
+	case anyElement:
+		return first
+	case anyArray:
+		if first.IsUnknown() {
+			return first
+		}
+		return first.Array()
+	case anyArrayElem:
+		return first.Elem()
+	case sumResult:
+		switch first.Name {
+		case "int2", "int4":
+			return catalog.BuiltinType("int8")
+		case "int8":
+			return catalog.BuiltinType("numeric")
+		}
+		return first
+	case avgResult:
+		switch first.Name {
+		case "float4", "float8":
+			return catalog.BuiltinType("float8")
+		case "interval":
+			return first
+		case "":
+			return first
+		}
+		return catalog.BuiltinType("numeric")
+	}
+	return catalog.BuiltinType(f.result)
+}
@@ -0,0 +1,92 @@
+package resolve
+
+import nodes "github.com/tomaszjonak/pg_query_go/nodes"
+
+// addOrigins returns the origins with the other ones appended, skipping
+// duplicates. The other ones become indirect unless direct is set.
+func addOrigins(origins []Origin, others []Origin, direct bool) []Origin {
+	for _, other := range others {
+		other.Direct = other.Direct && direct
+		found := false
+		for _, origin := range origins {
+			if origin == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			origins = append(origins, other)
+		}
+	}
+	return origins
+}
+
+// exprOrigins returns the catalog columns the value of an expression whose
+// column references have already been resolved in the scope is computed
+// from. A bare column reference or scalar subquery copies the origins of its
+// column, anything else transforms the origins of all the columns and
+// subqueries it contains.
+func (a *analyzer) exprOrigins(node nodes.Node, s *scope) []Origin {
+	switch node := node.(type) {
+	case nil:
+		return nil
+	case nodes.ColumnRef:
+		if columns := a.findColumns(node, s); len(columns) == 1 && !isStar(node) {
+			return addOrigins(nil, columns[0].origins, true)
+		}
+	case nodes.SubLink:
+		if columns := a.sublinks[node.Location]; len(columns) == 1 && node.SubLinkType == nodes.EXPR_SUBLINK {
+			return addOrigins(nil, columns[0].origins, true)
+		}
+	case nodes.MultiAssignRef:
+		// One column of UPDATE ... SET (a, b) = (SELECT ...) or of a row
+		i := node.Colno - 1
+		switch source := node.Source.(type) {
+		case nodes.SubLink:
+			if columns := a.sublinks[source.Location]; i >= 0 && i < len(columns) {
+				return addOrigins(nil, columns[i].origins, true)
+			}
+		case nodes.RowExpr:
+			if i >= 0 && i < len(source.Args.Items) {
+				return a.exprOrigins(source.Args.Items[i], s)
+			}
+		}
+		return nil
+	}
+
+	var origins []Origin
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		switch node := node.(type) {
+		case nodes.ColumnRef:
+			for _, c := range a.findColumns(node, s) {
+				origins = addOrigins(origins, c.origins, false)
+			}
+			return false
+		case nodes.SubLink:
+			origins = addOrigins(origins, a.exprOrigins(node.Testexpr, s), false)
+			for _, c := range a.sublinks[node.Location] {
+				origins = addOrigins(origins, c.origins, false)
+			}
+			return false
+		case nodes.SelectStmt, nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt:
+			return false
+		}
+		return true
+	})
+	return origins
+}
+
+// assign records the values stored into the target columns, which are
+// written at the given locations, or at none if locations is nil
+func (a *analyzer) assign(targets []*column, values [][]Origin, locations []int) {
+	for i, target := range targets {
+		if target == nil || target.source.IsZero() || i >= len(values) {
+			continue
+		}
+		location := -1
+		if i < len(locations) {
+			location = locations[i]
+		}
+		a.result.Assignments = append(a.result.Assignments, Assignment{Target: target.source, Origins: values[i], Location: location})
+	}
+}
@@ -0,0 +1,252 @@
+package resolve
+
+import (
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// Param is a parameter placeholder of a statement, e.g. $1
+type Param struct {
+	Number int
+
+	// Type the parameter is expected to have, without type modifiers,
+	// unknown if it couldn't be inferred
+	Type catalog.TypeName
+
+	// Locations of the uses of the parameter, none for a number that's
+	// skipped, e.g. $2 in a statement using $1 and $3
+	Locations []int
+}
+
+// param records a use of a parameter
+func (a *analyzer) param(ref nodes.ParamRef) {
+	p := a.paramNumber(ref.Number)
+	p.Locations = append(p.Locations, ref.Location)
+}
+
+func (a *analyzer) paramNumber(number int) *Param {
+	p, ok := a.params[number]
+	if !ok {
+		p = &Param{Number: number}
+		a.params[number] = p
+	}
+	return p
+}
+
+// bindParam sets the type of a parameter if the node is one, reporting
+// types that conflict with one deduced before
+func (a *analyzer) bindParam(node nodes.Node, typ catalog.TypeName) {
+	ref, ok := node.(nodes.ParamRef)
+	if !ok || typ.IsUnknown() {
+		return
+	}
+	typ.Modifiers = nil
+	p := a.paramNumber(ref.Number)
+	if p.Type.IsUnknown() {
+		p.Type = typ
+	} else if !p.Type.Equal(typ) {
+		a.error(ref.Location, "inconsistent types deduced for parameter $%d: %s versus %s", ref.Number, p.Type, typ)
+	}
+}
+
+func isParam(node nodes.Node) bool {
+	_, ok := node.(nodes.ParamRef)
+	return ok
+}
+
+// inferParams deduces the types of the parameters that are direct operands
+// of an expression from the other operands
+func (a *analyzer) inferParams(node nodes.Node, s *scope) {
+	switch node := node.(type) {
+	case nodes.A_Expr:
+		a.inferOperands(node, s)
+	case nodes.TypeCast:
+		if isParam(node.Arg) && node.TypeName != nil {
+			if typ, err := a.catalog.ResolveType(*node.TypeName); err == nil {
+				a.bindParam(node.Arg, typ)
+			}
+		}
+	case nodes.FuncCall:
+		if function, ok := lookupBuiltinFunction(node); ok {
+			for i, arg := range node.Args.Items {
+				a.bindParam(arg, function.argType(i))
+			}
+			return
+		}
+		functions := a.functions(node)
+		var matching []*catalog.Function
+		for _, function := range functions {
+			if acceptsArgs(function, len(node.Args.Items)) {
+				matching = append(matching, function)
+			}
+		}
+		if len(matching) == 1 {
+			args := matching[0].InArgs()
+			for i, arg := range node.Args.Items {
+				a.bindParam(arg, args[i].Type)
+			}
+		}
+	case nodes.CoalesceExpr:
+		a.inferCommonType(node.Args.Items, s)
+	case nodes.MinMaxExpr:
+		a.inferCommonType(node.Args.Items, s)
+	case nodes.CaseExpr:
+		results := []nodes.Node{}
+		conditions := []nodes.Node{node.Arg}
+		for _, item := range node.Args.Items {
+			if caseWhen, ok := item.(nodes.CaseWhen); ok {
+				results = append(results, caseWhen.Result)
+				if node.Arg != nil {
+					conditions = append(conditions, caseWhen.Expr)
+				} else {
+					a.bindParam(caseWhen.Expr, catalog.BuiltinType("bool"))
+				}
+			}
+		}
+		if node.Defresult != nil {
+			results = append(results, node.Defresult)
+		}
+		a.inferCommonType(results, s)
+		if node.Arg != nil {
+			a.inferCommonType(conditions, s)
+		}
+	case nodes.BoolExpr:
+		for _, arg := range node.Args.Items {
+			a.bindParam(arg, catalog.BuiltinType("bool"))
+		}
+	case nodes.SubLink:
+		if columns := a.sublinks[node.Location]; len(columns) == 1 && node.Testexpr != nil {
+			a.bindParam(node.Testexpr, columns[0].typ)
+		}
+	}
+}
+
+// acceptsArgs returns whether a function can be called with the given number
+// of arguments, taking defaults into account
+func acceptsArgs(function *catalog.Function, count int) bool {
+	args := function.InArgs()
+	if count > len(args) {
+		return false
+	}
+	for _, arg := range args[count:] {
+		if !arg.HasDefault {
+			return false
+		}
+	}
+	return true
+}
+
+// inferCommonType gives the parameters among the nodes the type of the
+// first of the other nodes whose type is known, as their values must have a
+// common type
+func (a *analyzer) inferCommonType(items []nodes.Node, s *scope) {
+	var typ catalog.TypeName
+	for _, item := range items {
+		if item == nil || isParam(item) {
+			continue
+		}
+		if itemType, _ := a.exprType(item, s); !itemType.IsUnknown() {
+			typ = itemType
+			break
+		}
+	}
+	for _, item := range items {
+		a.bindParam(item, typ)
+	}
+}
+
+func (a *analyzer) inferOperands(expr nodes.A_Expr, s *scope) {
+	operator := ""
+	if len(expr.Name.Items) > 0 {
+		if str, ok := expr.Name.Items[len(expr.Name.Items)-1].(nodes.String); ok {
+			operator = str.Str
+		}
+	}
+
+	switch expr.Kind {
+	case nodes.AEXPR_OP_ANY, nodes.AEXPR_OP_ALL:
+		// x = ANY (array)
+		left, _ := a.exprType(expr.Lexpr, s)
+		right, _ := a.exprType(expr.Rexpr, s)
+		if !right.IsUnknown() {
+			a.bindParam(expr.Lexpr, right.Elem())
+		}
+		if !left.IsUnknown() {
+			a.bindParam(expr.Rexpr, left.Array())
+		}
+		return
+	case nodes.AEXPR_LIKE, nodes.AEXPR_ILIKE, nodes.AEXPR_SIMILAR:
+		a.bindParam(expr.Lexpr, catalog.BuiltinType("text"))
+		a.bindParam(expr.Rexpr, catalog.BuiltinType("text"))
+		return
+	}
+
+	// The items of IN and BETWEEN lists are compared to the left side
+	operands := []nodes.Node{expr.Lexpr}
+	if list, ok := expr.Rexpr.(nodes.List); ok {
+		operands = append(operands, list.Items...)
+	} else {
+		operands = append(operands, expr.Rexpr)
+	}
+
+	switch operator {
+	case "~~", "~~*", "!~~", "!~~*", "~", "~*", "!~", "!~*", "||":
+		for _, operand := range operands {
+			if typ, _ := a.exprType(operand, s); typ.ArrayDims > 0 || typ.Name == "jsonb" {
+				return
+			}
+		}
+		for _, operand := range operands {
+			a.bindParam(operand, catalog.BuiltinType("text"))
+		}
+	case "->", "->>", "#>", "#>>", "?", "?|", "?&", "@>", "<@", "&&", "@@":
+		// Operators whose operands have different types
+	default:
+		a.inferCommonType(operands, s)
+	}
+}
+
+// inferTargets gives the parameters that are assigned to target columns the
+// type of the column
+func (a *analyzer) inferTargets(values []nodes.Node, targets []*column) {
+	for i, value := range values {
+		if i < len(targets) && targets[i] != nil {
+			a.bindParam(value, targets[i].typ)
+		}
+	}
+}
+
+// inferInsertSource gives the parameters of the VALUES lists or the target
+// list of the source query of INSERT the types of the target columns
+func (a *analyzer) inferInsertSource(node nodes.Node, targets []*column) {
+	stmt, ok := node.(nodes.SelectStmt)
+	if !ok || stmt.Op != nodes.SETOP_NONE {
+		return
+	}
+	for _, row := range stmt.ValuesLists {
+		a.inferTargets(row, targets)
+	}
+	values := []nodes.Node{}
+	for _, item := range stmt.TargetList.Items {
+		if resTarget, ok := item.(nodes.ResTarget); ok {
+			values = append(values, resTarget.Val)
+		}
+	}
+	a.inferTargets(values, targets)
+}
+
+// resultParams returns the parameters numbered from 1 to the highest number
+// used
+func (a *analyzer) resultParams() []Param {
+	max := 0
+	for number := range a.params {
+		if number > max {
+			max = number
+		}
+	}
+	params := []Param{}
+	for number := 1; number <= max; number++ {
+		params = append(params, *a.paramNumber(number))
+	}
+	return params
+}
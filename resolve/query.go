@@ -0,0 +1,761 @@
+package resolve
+
+import (
+	"fmt"
+
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+type analyzer struct {
+	catalog *catalog.Catalog
+	result  *Result
+
+	// Output columns of the subqueries of SubLink nodes, by location
+	sublinks map[int][]*column
+
+	// Parameters by number
+	params map[int]*Param
+}
+
+func newAnalyzer(c *catalog.Catalog) *analyzer {
+	return &analyzer{catalog: c, result: &Result{}, sublinks: map[int][]*column{}, params: map[int]*Param{}}
+}
+
+// query analyzes a SELECT, INSERT, UPDATE or DELETE statement within the
+// given outer scope, and returns its output columns
+func (a *analyzer) query(node nodes.Node, parent *scope) []*column {
+	switch node := node.(type) {
+	case nodes.SelectStmt:
+		return a.selectStmt(node, parent)
+	case nodes.InsertStmt:
+		return a.insertStmt(node, parent)
+	case nodes.UpdateStmt:
+		return a.updateStmt(node, parent)
+	case nodes.DeleteStmt:
+		return a.deleteStmt(node, parent)
+	}
+	return nil
+}
+
+// withClause adds the CTEs of a WITH clause to the scope. Each CTE can refer
+// to the ones before it, and to itself if it's recursive.
+func (a *analyzer) withClause(withClause *nodes.WithClause, s *scope) {
+	if withClause == nil {
+		return
+	}
+	for _, item := range withClause.Ctes.Items {
+		commonTableExpr, ok := item.(nodes.CommonTableExpr)
+		if !ok || commonTableExpr.Ctename == nil {
+			continue
+		}
+		cte := &cte{name: *commonTableExpr.Ctename}
+		query := commonTableExpr.Ctequery
+		selectStmt, isSelect := query.(nodes.SelectStmt)
+		if withClause.Recursive && isSelect && selectStmt.Op == nodes.SETOP_UNION && selectStmt.Larg != nil && selectStmt.Rarg != nil {
+			// The non-recursive term determines the columns the recursive
+			// term sees
+			cteScope := &scope{parent: s}
+			a.withClause(selectStmt.WithClause, cteScope)
+			cte.columns = renamed(a.selectStmt(*selectStmt.Larg, cteScope), commonTableExpr.Aliascolnames)
+			s.ctes = append(s.ctes, cte)
+			a.selectStmt(*selectStmt.Rarg, cteScope)
+			a.sortAndLimit(selectStmt, &scope{parent: cteScope, items: []*rangeItem{outputItem(cte.columns)}})
+			continue
+		}
+		cte.columns = renamed(a.query(query, s), commonTableExpr.Aliascolnames)
+		s.ctes = append(s.ctes, cte)
+	}
+}
+
+// renamed returns the columns with the first ones renamed to the given
+// aliases
+func renamed(columns []*column, aliases nodes.List) []*column {
+	result := make([]*column, len(columns))
+	for i, c := range columns {
+		copied := *c
+		if i < len(aliases.Items) {
+			if str, ok := aliases.Items[i].(nodes.String); ok {
+				copied.name = str.Str
+			}
+		}
+		result[i] = &copied
+	}
+	return result
+}
+
+// outputItem returns an unnamed FROM item with the output columns of a set
+// operation, which is what its ORDER BY can refer to
+func outputItem(columns []*column) *rangeItem {
+	return &rangeItem{columns: columns, colsVisible: true}
+}
+
+func (a *analyzer) selectStmt(stmt nodes.SelectStmt, parent *scope) []*column {
+	s := &scope{parent: parent}
+	a.withClause(stmt.WithClause, s)
+
+	if stmt.Op != nodes.SETOP_NONE && stmt.Larg != nil && stmt.Rarg != nil {
+		columns := a.setOperation(stmt, s)
+		a.sortAndLimit(stmt, &scope{parent: s, items: []*rangeItem{outputItem(columns)}})
+		return columns
+	}
+
+	if len(stmt.ValuesLists) > 0 {
+		return a.valuesLists(stmt.ValuesLists, s)
+	}
+
+	a.fromClause(stmt.FromClause, s)
+	columns := a.targetList(stmt.TargetList, s)
+	s.outputs = columns
+	a.expr(stmt.WhereClause, s)
+	for _, item := range stmt.GroupClause.Items {
+		a.groupBy(item, s)
+	}
+	a.expr(stmt.HavingClause, s)
+	a.expr(stmt.WindowClause, s)
+	a.expr(stmt.DistinctClause, s)
+	a.sortAndLimit(stmt, s)
+	return columns
+}
+
+// setOperation analyzes both sides of UNION, INTERSECT or EXCEPT and returns
+// the output columns, which are named after the left side
+func (a *analyzer) setOperation(stmt nodes.SelectStmt, s *scope) []*column {
+	left := a.selectStmt(*stmt.Larg, s)
+	right := a.selectStmt(*stmt.Rarg, s)
+	columns := make([]*column, len(left))
+	for i, l := range left {
+		c := &column{name: l.name, source: l.source, origins: l.origins, typ: l.typ, notNull: l.notNull}
+		if i < len(right) {
+			r := right[i]
+			if r.source != l.source {
+				c.source = Column{}
+			}
+			c.origins = addOrigins(addOrigins(nil, l.origins, true), r.origins, true)
+			if c.typ.IsUnknown() {
+				c.typ = r.typ
+			}
+			c.notNull = c.notNull && r.notNull
+		}
+		columns[i] = c
+	}
+	return columns
+}
+
+func (a *analyzer) valuesLists(valuesLists [][]nodes.Node, s *scope) []*column {
+	var columns []*column
+	for _, row := range valuesLists {
+		for i, value := range row {
+			a.expr(value, s)
+			typ, notNull := a.exprType(value, s)
+			origins := a.exprOrigins(value, s)
+			if i >= len(columns) {
+				columns = append(columns, &column{name: fmt.Sprintf("column%d", i+1), origins: origins, typ: typ, notNull: notNull})
+				continue
+			}
+			columns[i].origins = addOrigins(columns[i].origins, origins, true)
+			if columns[i].typ.IsUnknown() {
+				columns[i].typ = typ
+			}
+			columns[i].notNull = columns[i].notNull && notNull
+		}
+	}
+	return columns
+}
+
+func (a *analyzer) sortAndLimit(stmt nodes.SelectStmt, s *scope) {
+	for _, item := range stmt.SortClause.Items {
+		sortBy, ok := item.(nodes.SortBy)
+		if !ok {
+			continue
+		}
+		// A bare name refers to an output column before an input column
+		if name, ref, ok := bareName(sortBy.Node); ok {
+			if output := outputColumn(s.outputs, name); output != nil {
+				a.result.References = append(a.result.References, Reference{
+					Fields:   []string{name},
+					Location: ref.Location,
+					Bindings: []Binding{output.binding()},
+				})
+				continue
+			}
+		}
+		a.expr(sortBy.Node, s)
+	}
+	a.expr(stmt.LimitOffset, s)
+	a.expr(stmt.LimitCount, s)
+	a.bindParam(stmt.LimitOffset, catalog.BuiltinType("int8"))
+	a.bindParam(stmt.LimitCount, catalog.BuiltinType("int8"))
+}
+
+// groupBy analyzes a GROUP BY item, which refers to an output column if it's
+// a bare name that isn't an input column
+func (a *analyzer) groupBy(node nodes.Node, s *scope) {
+	if name, ref, ok := bareName(node); ok && len(a.findColumn(name, &scope{items: s.items})) == 0 {
+		if output := outputColumn(s.outputs, name); output != nil {
+			a.result.References = append(a.result.References, Reference{
+				Fields:   []string{name},
+				Location: ref.Location,
+				Bindings: []Binding{output.binding()},
+			})
+			return
+		}
+	}
+	a.expr(node, s)
+}
+
+func bareName(node nodes.Node) (string, nodes.ColumnRef, bool) {
+	ref, ok := node.(nodes.ColumnRef)
+	if !ok || len(ref.Fields.Items) != 1 {
+		return "", ref, false
+	}
+	str, ok := ref.Fields.Items[0].(nodes.String)
+	return str.Str, ref, ok
+}
+
+func outputColumn(columns []*column, name string) *column {
+	for _, c := range columns {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// targetList analyzes the target list of a SELECT or RETURNING clause and
+// returns the output columns, with stars expanded
+func (a *analyzer) targetList(targetList nodes.List, s *scope) []*column {
+	columns := []*column{}
+	for _, item := range targetList.Items {
+		resTarget, ok := item.(nodes.ResTarget)
+		if !ok {
+			continue
+		}
+		if ref, ok := resTarget.Val.(nodes.ColumnRef); ok {
+			refColumns := a.columnRef(ref, s)
+			if isStar(ref) {
+				columns = append(columns, refColumns...)
+				continue
+			}
+			c := &column{name: outputName(resTarget)}
+			if len(refColumns) == 1 {
+				c.source, c.origins, c.typ, c.notNull = refColumns[0].source, refColumns[0].origins, refColumns[0].typ, refColumns[0].notNull
+			}
+			columns = append(columns, c)
+			continue
+		}
+		a.expr(resTarget.Val, s)
+		c := &column{name: outputName(resTarget)}
+		c.typ, c.notNull = a.exprType(resTarget.Val, s)
+		c.origins = a.exprOrigins(resTarget.Val, s)
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+func outputName(resTarget nodes.ResTarget) string {
+	if resTarget.Name != nil {
+		return *resTarget.Name
+	}
+	return catalog.FigureColumnName(resTarget.Val)
+}
+
+func isStar(ref nodes.ColumnRef) bool {
+	if len(ref.Fields.Items) == 0 {
+		return false
+	}
+	_, ok := ref.Fields.Items[len(ref.Fields.Items)-1].(nodes.A_Star)
+	return ok
+}
+
+// expr resolves the column references of an expression, analyzing the
+// subqueries it contains as inner query levels
+func (a *analyzer) expr(node nodes.Node, s *scope) {
+	if node == nil {
+		return
+	}
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		switch node := node.(type) {
+		case nodes.ColumnRef:
+			a.columnRef(node, s)
+			return false
+		case nodes.ParamRef:
+			a.param(node)
+		case nodes.SubLink:
+			a.expr(node.Testexpr, s)
+			a.sublinks[node.Location] = a.query(node.Subselect, s)
+			return false
+		case nodes.SelectStmt, nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt:
+			a.query(node, s)
+			return false
+		}
+		return true
+	})
+	a.inferExpr(node, s)
+}
+
+// inferExpr deduces the types of the parameters of an expression once its
+// column references and subqueries have been analyzed
+func (a *analyzer) inferExpr(node nodes.Node, s *scope) {
+	nodes.Walk(node, func(node nodes.Node, parentNode nodes.Node, parentFieldName string) bool {
+		switch node := node.(type) {
+		case nodes.SubLink:
+			a.inferParams(node, s)
+			a.inferExpr(node.Testexpr, s)
+			return false
+		case nodes.SelectStmt, nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt:
+			return false
+		}
+		a.inferParams(node, s)
+		return true
+	})
+}
+
+// fromClause analyzes the items of a FROM clause and adds them to the scope.
+// Each item can refer to the ones before it if it's LATERAL.
+func (a *analyzer) fromClause(fromClause nodes.List, s *scope) {
+	for _, item := range fromClause.Items {
+		namespace, _ := a.fromItem(item, s)
+		a.checkNames(s.items, namespace)
+		s.items = append(s.items, namespace...)
+	}
+}
+
+// checkNames reports FROM items of the same query level that have the same
+// name
+func (a *analyzer) checkNames(items []*rangeItem, added []*rangeItem) {
+	for _, item := range added {
+		for _, other := range items {
+			if item.relVisible && other.relVisible && item.name == other.name && item.schema == other.schema {
+				a.error(item.location, "table name \"%s\" specified more than once", item.name)
+			}
+		}
+	}
+}
+
+// fromItem analyzes a FROM item and returns the items it makes visible,
+// together with the item that has its columns. The items of the scope are
+// those a LATERAL item can refer to.
+func (a *analyzer) fromItem(node nodes.Node, s *scope) ([]*rangeItem, *rangeItem) {
+	var item *rangeItem
+	switch node := node.(type) {
+	case nodes.RangeVar:
+		item = a.relation(node, s)
+	case nodes.RangeSubselect:
+		subScope := s.sibling(nil)
+		if node.Lateral {
+			subScope = s
+		}
+		item = &rangeItem{relVisible: true, colsVisible: true, location: -1}
+		for _, c := range a.query(node.Subquery, subScope) {
+			copied := *c
+			item.columns = append(item.columns, &copied)
+		}
+		a.alias(item, node.Alias)
+	case nodes.RangeFunction:
+		item = a.rangeFunction(node, s)
+	case nodes.RangeTableSample:
+		a.expr(node.Args, s)
+		a.expr(node.Repeatable, s)
+		return a.fromItem(node.Relation, s)
+	case nodes.JoinExpr:
+		return a.joinExpr(node, s)
+	default:
+		return nil, nil
+	}
+	return []*rangeItem{item}, item
+}
+
+// alias applies an alias and its column names to a FROM item
+func (a *analyzer) alias(item *rangeItem, alias *nodes.Alias) {
+	if alias == nil {
+		return
+	}
+	if alias.Aliasname != nil {
+		item.name, item.schema = *alias.Aliasname, ""
+	}
+	item.columns = renamed(item.columns, alias.Colnames)
+	for _, c := range item.columns {
+		c.relation = item.name
+	}
+}
+
+// relation returns the FROM item of a table, view or CTE
+func (a *analyzer) relation(rangeVar nodes.RangeVar, s *scope) *rangeItem {
+	item := &rangeItem{relVisible: true, colsVisible: true}
+	if rangeVar.Relname == nil {
+		return item
+	}
+	name := *rangeVar.Relname
+	schema := ""
+	if rangeVar.Schemaname != nil {
+		schema = *rangeVar.Schemaname
+	}
+	item.name = name
+	item.location = rangeVar.Location
+
+	if cte := s.cte(name); cte != nil && schema == "" {
+		for _, c := range cte.columns {
+			copied := *c
+			item.columns = append(item.columns, &copied)
+		}
+	} else if table := a.catalog.Table(schema, name); table != nil {
+		item.schema = table.Schema
+		for _, c := range table.Columns {
+			source := Column{Schema: table.Schema, Table: table.Name, Column: c.Name}
+			origins := []Origin{{Column: source, Direct: true}}
+			if table.Query != nil {
+				origins = viewOrigins(c)
+			}
+			item.columns = append(item.columns, &column{
+				name:    c.Name,
+				source:  source,
+				origins: origins,
+				typ:     c.Type,
+				notNull: c.NotNull,
+			})
+		}
+	} else {
+		if schema != "" {
+			name = schema + "." + name
+		}
+		a.error(rangeVar.Location, "relation \"%s\" does not exist", name)
+		item.schema = schema
+		item.unknown = true
+	}
+	for _, c := range item.columns {
+		c.relation = item.name
+	}
+	a.alias(item, rangeVar.Alias)
+	return item
+}
+
+// viewOrigins returns the origins of a column of a view or materialized
+// view, which the catalog records when it's created
+func viewOrigins(c *catalog.Column) []Origin {
+	origins := []Origin{}
+	for _, origin := range c.Origins {
+		origins = append(origins, Origin{
+			Column: Column{Schema: origin.Table.Schema, Table: origin.Table.Name, Column: origin.Column.Name},
+			Direct: origin.Direct,
+		})
+	}
+	return origins
+}
+
+// rangeFunction returns the FROM item of a function call, whose columns are
+// the OUT arguments of a function in the catalog, the column definition list
+// or a single column named after the function
+func (a *analyzer) rangeFunction(rangeFunction nodes.RangeFunction, s *scope) *rangeItem {
+	item := &rangeItem{relVisible: true, colsVisible: true, location: -1}
+	for _, function := range rangeFunction.Functions.Items {
+		// Each function is a list of the call and its column definitions
+		list, ok := function.(nodes.List)
+		if !ok || len(list.Items) == 0 {
+			continue
+		}
+		a.expr(list.Items[0], s)
+		funcCall, ok := list.Items[0].(nodes.FuncCall)
+		if !ok {
+			continue
+		}
+		name := catalog.FigureColumnName(funcCall)
+		// The columns are computed from the arguments
+		origins := a.exprOrigins(funcCall, s)
+		if item.name == "" {
+			item.name = name
+		}
+
+		coldeflist := rangeFunction.Coldeflist
+		if len(list.Items) > 1 {
+			if columnDefs, ok := list.Items[1].(nodes.List); ok && len(columnDefs.Items) > 0 {
+				coldeflist = columnDefs
+			}
+		}
+		if len(coldeflist.Items) > 0 {
+			for _, columnDef := range coldeflist.Items {
+				if columnDef, ok := columnDef.(nodes.ColumnDef); ok && columnDef.Colname != nil {
+					c := &column{name: *columnDef.Colname, origins: origins}
+					if columnDef.TypeName != nil {
+						c.typ, _ = a.catalog.ResolveType(*columnDef.TypeName)
+					}
+					item.columns = append(item.columns, c)
+				}
+			}
+			continue
+		}
+
+		var outArgs []catalog.FunctionArg
+		if functions := a.functions(funcCall); len(functions) == 1 {
+			outArgs = functions[0].OutArgs()
+		}
+		if len(outArgs) == 0 {
+			typ, notNull := a.funcCallType(funcCall, s)
+			item.columns = append(item.columns, &column{name: name, origins: origins, typ: typ, notNull: notNull})
+		}
+		for _, arg := range outArgs {
+			item.columns = append(item.columns, &column{name: arg.Name, origins: origins, typ: arg.Type})
+		}
+	}
+	if rangeFunction.Ordinality {
+		item.columns = append(item.columns, &column{name: "ordinality", typ: catalog.BuiltinType("int8"), notNull: true})
+	}
+
+	// The alias of a function returning a single value names its column
+	if rangeFunction.Alias != nil && rangeFunction.Alias.Aliasname != nil && len(rangeFunction.Alias.Colnames.Items) == 0 &&
+		len(rangeFunction.Functions.Items) == 1 && len(item.columns) == 1 && item.columns[0].name == item.name {
+		item.columns[0].name = *rangeFunction.Alias.Aliasname
+	}
+	for _, c := range item.columns {
+		c.relation = item.name
+	}
+	a.alias(item, rangeFunction.Alias)
+	return item
+}
+
+// functions returns the functions of the catalog a call may refer to
+func (a *analyzer) functions(funcCall nodes.FuncCall) []*catalog.Function {
+	names := []string{}
+	for _, item := range funcCall.Funcname.Items {
+		if str, ok := item.(nodes.String); ok {
+			names = append(names, str.Str)
+		}
+	}
+	switch len(names) {
+	case 0:
+		return nil
+	case 1:
+		return a.catalog.Functions("", names[0])
+	default:
+		return a.catalog.Functions(names[len(names)-2], names[len(names)-1])
+	}
+}
+
+// joinExpr analyzes a join. Its columns are the merged USING or NATURAL
+// columns followed by the other columns of the left and right side.
+func (a *analyzer) joinExpr(join nodes.JoinExpr, s *scope) ([]*rangeItem, *rangeItem) {
+	leftNamespace, left := a.fromItem(join.Larg, s)
+	rightScope := &scope{parent: s.parent, ctes: s.ctes, items: append(append([]*rangeItem{}, s.items...), leftNamespace...)}
+	rightNamespace, right := a.fromItem(join.Rarg, rightScope)
+	a.checkNames(leftNamespace, rightNamespace)
+	namespace := append(leftNamespace, rightNamespace...)
+	if left == nil || right == nil {
+		return namespace, nil
+	}
+
+	var using []string
+	for _, item := range join.UsingClause.Items {
+		if str, ok := item.(nodes.String); ok {
+			using = append(using, str.Str)
+		}
+	}
+	if join.IsNatural {
+		for _, l := range left.columns {
+			if len(right.column(l.name)) > 0 && !right.unknown {
+				using = append(using, l.name)
+			}
+		}
+	}
+
+	item := &rangeItem{colsVisible: true, location: -1}
+	merged := map[string]bool{}
+	for _, name := range using {
+		l, r := left.column(name), right.column(name)
+		if len(l) != 1 {
+			a.error(-1, "column \"%s\" specified in USING clause does not exist in left table", name)
+			continue
+		}
+		if len(r) != 1 {
+			a.error(-1, "column \"%s\" specified in USING clause does not exist in right table", name)
+			continue
+		}
+		c := &column{name: name}
+		switch join.Jointype {
+		case nodes.JOIN_RIGHT:
+			c.source, c.origins, c.typ, c.notNull = r[0].source, r[0].origins, r[0].typ, r[0].notNull
+		case nodes.JOIN_FULL:
+			// The value is COALESCE(l, r)
+			c.origins = addOrigins(addOrigins(nil, l[0].origins, false), r[0].origins, false)
+			c.typ, c.notNull = l[0].typ, l[0].notNull || r[0].notNull
+		default:
+			c.source, c.origins, c.typ, c.notNull = l[0].source, l[0].origins, l[0].typ, l[0].notNull
+		}
+		item.columns = append(item.columns, c)
+		merged[name] = true
+	}
+
+	// The columns of the outer side of an outer join become nullable, but
+	// not the merged columns, which take the value of a side that has a row
+	switch join.Jointype {
+	case nodes.JOIN_LEFT:
+		nullable(rightNamespace)
+	case nodes.JOIN_RIGHT:
+		nullable(leftNamespace)
+	case nodes.JOIN_FULL:
+		nullable(namespace)
+	}
+
+	for _, side := range []*rangeItem{left, right} {
+		for _, c := range side.columns {
+			if !merged[c.name] {
+				item.columns = append(item.columns, c)
+			}
+		}
+	}
+
+	a.expr(join.Quals, s.sibling(namespace))
+
+	for _, other := range namespace {
+		other.colsVisible = false
+	}
+	if join.Alias != nil && join.Alias.Aliasname != nil {
+		a.alias(item, join.Alias)
+		item.relVisible = true
+		return []*rangeItem{item}, item
+	}
+	return append(namespace, item), item
+}
+
+// nullable replaces the columns of FROM items with nullable copies
+func nullable(items []*rangeItem) {
+	for _, item := range items {
+		columns := make([]*column, len(item.columns))
+		for i, c := range item.columns {
+			copied := *c
+			copied.notNull = false
+			columns[i] = &copied
+		}
+		item.columns = columns
+	}
+}
+
+// target returns the FROM item of the target table of INSERT, UPDATE or
+// DELETE
+func (a *analyzer) target(rangeVar *nodes.RangeVar) *rangeItem {
+	if rangeVar == nil {
+		return &rangeItem{unknown: true}
+	}
+	// The target is always a table, never a CTE
+	return a.relation(*rangeVar, &scope{})
+}
+
+// targetColumn resolves a target column of INSERT, UPDATE or ON CONFLICT DO
+// UPDATE, which must be a column of the target table
+func (a *analyzer) targetColumn(resTarget nodes.ResTarget, target *rangeItem, table string) *column {
+	if resTarget.Name == nil {
+		return nil
+	}
+	name := *resTarget.Name
+	reference := Reference{Fields: []string{name}, Location: resTarget.Location}
+	columns := target.column(name)
+	if len(columns) == 0 {
+		a.error(resTarget.Location, "column \"%s\" of relation \"%s\" does not exist", name, table)
+	} else {
+		reference.Bindings = []Binding{columns[0].binding()}
+	}
+	a.result.References = append(a.result.References, reference)
+	if len(columns) == 0 {
+		return nil
+	}
+	return columns[0]
+}
+
+func tableName(rangeVar *nodes.RangeVar) string {
+	if rangeVar == nil || rangeVar.Relname == nil {
+		return ""
+	}
+	return *rangeVar.Relname
+}
+
+func (a *analyzer) insertStmt(stmt nodes.InsertStmt, parent *scope) []*column {
+	s := &scope{parent: parent}
+	a.withClause(stmt.WithClause, s)
+	target := a.target(stmt.Relation)
+	targets := target.columns
+	var locations []int
+	if len(stmt.Cols.Items) > 0 {
+		targets = nil
+		for _, item := range stmt.Cols.Items {
+			if resTarget, ok := item.(nodes.ResTarget); ok {
+				targets = append(targets, a.targetColumn(resTarget, target, tableName(stmt.Relation)))
+				locations = append(locations, resTarget.Location)
+			}
+		}
+	}
+	// The source query can't refer to the target table
+	sources := a.query(stmt.SelectStmt, s)
+	a.inferInsertSource(stmt.SelectStmt, targets)
+	values := make([][]Origin, len(sources))
+	for i, source := range sources {
+		values[i] = source.origins
+	}
+	a.assign(targets, values, locations)
+
+	targetScope := s.sibling([]*rangeItem{target})
+	if onConflict := stmt.OnConflictClause; onConflict != nil {
+		if onConflict.Infer != nil {
+			for _, item := range onConflict.Infer.IndexElems.Items {
+				if indexElem, ok := item.(nodes.IndexElem); ok {
+					if indexElem.Name != nil {
+						a.targetColumn(nodes.ResTarget{Name: indexElem.Name, Location: onConflict.Infer.Location}, target, tableName(stmt.Relation))
+					}
+					a.expr(indexElem.Expr, targetScope)
+				}
+			}
+			a.expr(onConflict.Infer.WhereClause, targetScope)
+		}
+
+		// DO UPDATE can refer to the row proposed for insertion as excluded,
+		// whose values come from the source query
+		excluded := &rangeItem{name: "excluded", relVisible: true, unknown: target.unknown}
+		for _, c := range target.columns {
+			copied := *c
+			copied.relation, copied.source, copied.origins = "excluded", Column{}, nil
+			for i, t := range targets {
+				if t != nil && t.name == c.name && i < len(values) {
+					copied.origins = values[i]
+				}
+			}
+			excluded.columns = append(excluded.columns, &copied)
+		}
+		conflictScope := s.sibling([]*rangeItem{target, excluded})
+		for _, item := range onConflict.TargetList.Items {
+			if resTarget, ok := item.(nodes.ResTarget); ok {
+				c := a.targetColumn(resTarget, target, tableName(stmt.Relation))
+				a.expr(resTarget.Val, conflictScope)
+				a.inferTargets([]nodes.Node{resTarget.Val}, []*column{c})
+				a.assign([]*column{c}, [][]Origin{a.exprOrigins(resTarget.Val, conflictScope)}, []int{resTarget.Location})
+			}
+		}
+		a.expr(onConflict.WhereClause, conflictScope)
+	}
+	return a.targetList(stmt.ReturningList, targetScope)
+}
+
+func (a *analyzer) updateStmt(stmt nodes.UpdateStmt, parent *scope) []*column {
+	s := &scope{parent: parent}
+	a.withClause(stmt.WithClause, s)
+	target := a.target(stmt.Relation)
+	s.items = append(s.items, target)
+	a.fromClause(stmt.FromClause, s)
+	for _, item := range stmt.TargetList.Items {
+		if resTarget, ok := item.(nodes.ResTarget); ok {
+			c := a.targetColumn(resTarget, target, tableName(stmt.Relation))
+			a.expr(resTarget.Val, s)
+			a.inferTargets([]nodes.Node{resTarget.Val}, []*column{c})
+			a.assign([]*column{c}, [][]Origin{a.exprOrigins(resTarget.Val, s)}, []int{resTarget.Location})
+		}
+	}
+	a.expr(stmt.WhereClause, s)
+	return a.targetList(stmt.ReturningList, s)
+}
+
+func (a *analyzer) deleteStmt(stmt nodes.DeleteStmt, parent *scope) []*column {
+	s := &scope{parent: parent}
+	a.withClause(stmt.WithClause, s)
+	target := a.target(stmt.Relation)
+	s.items = append(s.items, target)
+	a.fromClause(stmt.UsingClause, s)
+	a.expr(stmt.WhereClause, s)
+	return a.targetList(stmt.ReturningList, s)
+}
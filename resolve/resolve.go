@@ -0,0 +1,150 @@
+// Package resolve binds the column references of queries to the columns of a
+// schema catalog, as PostgreSQL does during parse analysis.
+package resolve
+
+import (
+	pg_query "github.com/tomaszjonak/pg_query_go"
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// Column identifies a column of a table, view or materialized view in the
+// catalog
+type Column struct {
+	Schema string
+	Table  string
+	Column string
+}
+
+// IsZero returns whether the column is the zero value, which stands for a
+// value that doesn't come from a single catalog column
+func (c Column) IsZero() bool {
+	return c == Column{}
+}
+
+func (c Column) String() string {
+	return c.Schema + "." + c.Table + "." + c.Column
+}
+
+// Origin is a column of a table a value is computed from. Columns of views
+// are traced through their queries to the tables they read.
+type Origin struct {
+	Column Column
+
+	// Whether the value is a copy of the column, as opposed to being
+	// computed from it, e.g. by a function or an aggregate
+	Direct bool
+}
+
+// Assignment is a value INSERT, UPDATE or ON CONFLICT DO UPDATE stores into a
+// column
+type Assignment struct {
+	Target  Column
+	Origins []Origin
+
+	// Location of the target column, -1 for the columns INSERT without a
+	// column list assigns to
+	Location int
+}
+
+// Binding is a column a reference refers to
+type Binding struct {
+	// Name of the FROM item that provides the column within the query, e.g.
+	// a table alias, empty for the merged columns of JOIN ... USING
+	Relation string
+
+	// Name of the column within the FROM item
+	Name string
+
+	// The catalog column the value is taken from, looking through CTEs,
+	// subqueries and views' FROM items. It's the zero value for computed
+	// values.
+	Source Column
+}
+
+// Reference is a column reference of a query: a ColumnRef, including
+// SELECT * and t.*, or a target column of INSERT, UPDATE or ON CONFLICT
+type Reference struct {
+	// Names as written, "*" for a star
+	Fields   []string
+	Location int
+
+	// One binding for a column, one per column for a star, and none if the
+	// reference couldn't be resolved
+	Bindings []Binding
+}
+
+// Error is a reference to a relation or column that doesn't exist or is
+// ambiguous
+type Error struct {
+	Message  string
+	Location int
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+// Result is the outcome of resolving a statement
+type Result struct {
+	References []Reference
+	Errors     []Error
+
+	// Columns of the result of a query, including the RETURNING clause of
+	// INSERT, UPDATE and DELETE
+	Columns []ResultColumn
+
+	// Parameters of the statement, with the types deduced from where
+	// they're used
+	Params []Param
+
+	// Values stored into catalog columns by the statement, including the
+	// ones of data-modifying CTEs
+	Assignments []Assignment
+}
+
+// Resolve - Parses the given SQL and resolves the column references of each
+// statement against the catalog
+func Resolve(c *catalog.Catalog, input string) ([]*Result, error) {
+	tree, err := pg_query.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveTree(c, tree), nil
+}
+
+// ResolveTree resolves the column references of each statement of a parse
+// tree against the catalog
+func ResolveTree(c *catalog.Catalog, tree pg_query.ParsetreeList) []*Result {
+	results := []*Result{}
+	for _, item := range tree.Statements {
+		stmt, _ := nodes.UnwrapRawStmt(item)
+		results = append(results, ResolveStatement(c, stmt))
+	}
+	return results
+}
+
+// ResolveStatement resolves the column references of a SELECT, INSERT,
+// UPDATE or DELETE statement, or of the query of CREATE TABLE AS, CREATE VIEW
+// or EXPLAIN, and infers the columns of its result. Other statements have no
+// references.
+func ResolveStatement(c *catalog.Catalog, node nodes.Node) *Result {
+	a := newAnalyzer(c)
+	var columns []*column
+	switch node := node.(type) {
+	case nodes.CreateTableAsStmt:
+		columns = a.query(node.Query, nil)
+		if node.Into != nil {
+			columns = renamed(columns, node.Into.ColNames)
+		}
+	case nodes.ViewStmt:
+		columns = renamed(a.query(node.Query, nil), node.Aliases)
+	case nodes.ExplainStmt:
+		a.query(node.Query, nil)
+	default:
+		columns = a.query(node, nil)
+	}
+	a.result.Columns = resultColumns(columns)
+	a.result.Params = a.resultParams()
+	return a.result
+}
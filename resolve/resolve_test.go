@@ -0,0 +1,271 @@
+package resolve_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	"github.com/tomaszjonak/pg_query_go/resolve"
+)
+
+const schema = `
+CREATE TABLE users (id serial PRIMARY KEY, name text NOT NULL, email text);
+CREATE TABLE orders (id serial PRIMARY KEY, user_id int REFERENCES users, total numeric, created_at timestamptz);
+CREATE SCHEMA s;
+CREATE TABLE s.sensors (id int, collected_at timestamptz);
+CREATE FUNCTION user_orders(int, OUT order_id int, OUT total numeric) RETURNS SETOF record AS 'SELECT 1' LANGUAGE sql;
+`
+
+func newCatalog(t *testing.T) *catalog.Catalog {
+	c := catalog.New()
+	if err := c.Apply(schema); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// describeReferences returns the references of a result as "fields=bindings"
+// strings, where a binding is written as its source column, or as the FROM
+// item and column name for a computed value
+func describeReferences(result *resolve.Result) []string {
+	descriptions := []string{}
+	for _, reference := range result.References {
+		bindings := []string{}
+		for _, binding := range reference.Bindings {
+			if binding.Source.IsZero() {
+				bindings = append(bindings, "("+binding.Relation+")."+binding.Name)
+			} else {
+				bindings = append(bindings, binding.Source.String())
+			}
+		}
+		descriptions = append(descriptions, strings.Join(reference.Fields, ".")+"="+strings.Join(bindings, ","))
+	}
+	return descriptions
+}
+
+var resolveTests = []struct {
+	input      string
+	references []string
+}{
+	{
+		"SELECT id, u.name FROM users u WHERE email IS NOT NULL",
+		[]string{"id=public.users.id", "u.name=public.users.name", "email=public.users.email"},
+	},
+	{
+		"SELECT * FROM users",
+		[]string{"*=public.users.id,public.users.name,public.users.email"},
+	},
+	{
+		"SELECT s.sensors.collected_at FROM s.sensors",
+		[]string{"s.sensors.collected_at=s.sensors.collected_at"},
+	},
+	{
+		"SELECT id, total FROM users JOIN orders USING (id)",
+		[]string{"id=public.users.id", "total=public.orders.total"},
+	},
+	{
+		"SELECT id FROM users RIGHT JOIN orders USING (id)",
+		[]string{"id=public.orders.id"},
+	},
+	{
+		"SELECT j.name, j.total FROM (users JOIN orders ON users.id = orders.user_id) j",
+		[]string{"users.id=public.users.id", "orders.user_id=public.orders.user_id", "j.name=public.users.name", "j.total=public.orders.total"},
+	},
+	{
+		"WITH big AS (SELECT user_id AS uid, total * 2 AS double FROM orders) SELECT uid, double FROM big",
+		[]string{"user_id=public.orders.user_id", "total=public.orders.total", "uid=public.orders.user_id", "double=(big).double"},
+	},
+	{
+		"SELECT x.n FROM (SELECT name FROM users) x (n)",
+		[]string{"name=public.users.name", "x.n=public.users.name"},
+	},
+	{
+		"SELECT u.name, o.total FROM users u, LATERAL (SELECT total FROM orders WHERE user_id = u.id) o",
+		[]string{"total=public.orders.total", "user_id=public.orders.user_id", "u.id=public.users.id", "u.name=public.users.name", "o.total=public.orders.total"},
+	},
+	{
+		"SELECT name FROM users WHERE EXISTS (SELECT 1 FROM orders WHERE user_id = users.id)",
+		[]string{"name=public.users.name", "user_id=public.orders.user_id", "users.id=public.users.id"},
+	},
+	{
+		"SELECT f.total FROM users, user_orders(users.id) f",
+		[]string{"users.id=public.users.id", "f.total=(f).total"},
+	},
+	{
+		"WITH RECURSIVE r (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r) SELECT n FROM r",
+		[]string{"n=(r).n", "n=(r).n"},
+	},
+	{
+		"SELECT name AS n FROM users ORDER BY n",
+		[]string{"name=public.users.name", "n=public.users.name"},
+	},
+	{
+		"INSERT INTO orders (user_id, total) SELECT id, 0 FROM users ON CONFLICT (id) DO UPDATE SET total = excluded.total RETURNING id",
+		[]string{"user_id=public.orders.user_id", "total=public.orders.total", "id=public.users.id", "id=public.orders.id", "total=public.orders.total", "excluded.total=(excluded).total", "id=public.orders.id"},
+	},
+	{
+		"UPDATE orders o SET total = 0 FROM users WHERE users.id = o.user_id AND users.email IS NULL",
+		[]string{"total=public.orders.total", "users.id=public.users.id", "o.user_id=public.orders.user_id", "users.email=public.users.email"},
+	},
+	{
+		"DELETE FROM orders USING users WHERE user_id = users.id RETURNING orders.*",
+		[]string{"user_id=public.orders.user_id", "users.id=public.users.id", "orders.*=public.orders.id,public.orders.user_id,public.orders.total,public.orders.created_at"},
+	},
+}
+
+func TestResolve(t *testing.T) {
+	c := newCatalog(t)
+	for _, test := range resolveTests {
+		results, err := resolve.Resolve(c, test.input)
+		if err != nil {
+			t.Errorf("Resolve(%s): %s", test.input, err)
+			continue
+		}
+		if len(results[0].Errors) > 0 {
+			t.Errorf("Resolve(%s): unexpected errors %v", test.input, results[0].Errors)
+		}
+		if actual := describeReferences(results[0]); !reflect.DeepEqual(actual, test.references) {
+			t.Errorf("Resolve(%s):\nexpected %v\n     got %v", test.input, test.references, actual)
+		}
+	}
+}
+
+var resolveErrorTests = []struct {
+	input  string
+	errors []resolve.Error
+}{
+	{"SELECT id FROM users, orders", []resolve.Error{{`column reference "id" is ambiguous`, 7}}},
+	{"SELECT nme FROM users", []resolve.Error{{`column "nme" does not exist`, 7}}},
+	{"SELECT u.nme FROM users u", []resolve.Error{{`column u.nme does not exist`, 7}}},
+	{"SELECT users.id FROM users u", []resolve.Error{{`missing FROM-clause entry for table "users"`, 7}}},
+	{"SELECT a, b FROM missing", []resolve.Error{{`relation "missing" does not exist`, 17}}},
+	{"SELECT 1 FROM users u, (SELECT u.id) x", []resolve.Error{{`missing FROM-clause entry for table "u"`, 31}}},
+	{"SELECT 1 FROM users u, orders u", []resolve.Error{{`table name "u" specified more than once`, 23}}},
+	{"SELECT 1 FROM users JOIN users USING (id)", []resolve.Error{{`table name "users" specified more than once`, 25}}},
+	{"SELECT name FROM users JOIN orders ON users.id = orders.user_id JOIN s.sensors ON orders.nope = id", []resolve.Error{
+		{`column orders.nope does not exist`, 82},
+		{`column reference "id" is ambiguous`, 96},
+	}},
+	{"INSERT INTO users (nme) VALUES ('x')", []resolve.Error{{`column "nme" of relation "users" does not exist`, 19}}},
+}
+
+func TestResolveErrors(t *testing.T) {
+	c := newCatalog(t)
+	for _, test := range resolveErrorTests {
+		results, err := resolve.Resolve(c, test.input)
+		if err != nil {
+			t.Errorf("Resolve(%s): %s", test.input, err)
+			continue
+		}
+		errors := results[0].Errors
+		if errors == nil {
+			errors = []resolve.Error{}
+		}
+		if !reflect.DeepEqual(errors, test.errors) {
+			t.Errorf("Resolve(%s):\nexpected %v\n     got %v", test.input, test.errors, errors)
+		}
+	}
+}
+
+var resultColumnTests = []struct {
+	input   string
+	columns []string
+}{
+	{"SELECT id, name, email FROM users", []string{"id integer NOT NULL", "name text NOT NULL", "email text"}},
+	{"SELECT 1, 'a', 1.5, NULL, $1", []string{"?column? integer NOT NULL", "?column? text NOT NULL", "?column? numeric NOT NULL", "?column? unknown", "?column? unknown"}},
+	{"SELECT id::text AS key, email::varchar(100) FROM users", []string{"key text NOT NULL", "email character varying(100)"}},
+	{"SELECT count(*), sum(user_id), max(created_at), lower(name), now() FROM orders, users", []string{
+		"count bigint NOT NULL", "sum bigint", "max timestamp with time zone", "lower text NOT NULL", "now timestamp with time zone NOT NULL",
+	}},
+	{"SELECT coalesce(email, name), coalesce(email, NULL) FROM users", []string{"coalesce text NOT NULL", "coalesce text"}},
+	{"SELECT CASE WHEN id > 1 THEN 'big' ELSE 'small' END, CASE WHEN id > 1 THEN 1 END FROM users", []string{"case text NOT NULL", "case integer"}},
+	{"SELECT (SELECT max(total) FROM orders) AS top, EXISTS (SELECT 1 FROM orders), ARRAY(SELECT id FROM orders)", []string{
+		"top numeric", "exists boolean NOT NULL", "array integer[] NOT NULL",
+	}},
+	{"SELECT u.name, o.total, o.id FROM users u LEFT JOIN orders o ON o.user_id = u.id", []string{"name text NOT NULL", "total numeric", "id integer"}},
+	{"SELECT u.name, o.id FROM users u RIGHT JOIN orders o ON o.user_id = u.id", []string{"name text", "id integer NOT NULL"}},
+	{"SELECT id FROM users FULL JOIN orders USING (id)", []string{"id integer NOT NULL"}},
+	{"SELECT id + 1, total * 2, id > 3, created_at - now() FROM orders", []string{
+		"?column? integer NOT NULL", "?column? numeric", "?column? boolean NOT NULL", "?column? interval",
+	}},
+	{"WITH t AS (SELECT id, name FROM users) SELECT * FROM t", []string{"id integer NOT NULL", "name text NOT NULL"}},
+	{"SELECT id FROM users UNION SELECT user_id FROM orders", []string{"id integer"}},
+	{"VALUES (1, 'a'), (2, NULL)", []string{"column1 integer NOT NULL", "column2 text"}},
+	{"SELECT * FROM user_orders(1)", []string{"order_id integer", "total numeric"}},
+	{"SELECT * FROM generate_series(1, 3) g, unnest(ARRAY[now()]) u", []string{"g integer NOT NULL", "u timestamp with time zone"}},
+	{"DELETE FROM orders RETURNING id, total", []string{"id integer NOT NULL", "total numeric"}},
+	{"CREATE VIEW v (a) AS SELECT name, CURRENT_DATE FROM users", []string{"a text NOT NULL", "current_date date NOT NULL"}},
+}
+
+func TestResultColumns(t *testing.T) {
+	c := newCatalog(t)
+	for _, test := range resultColumnTests {
+		results, err := resolve.Resolve(c, test.input)
+		if err != nil {
+			t.Errorf("Resolve(%s): %s", test.input, err)
+			continue
+		}
+		columns := []string{}
+		for _, column := range results[0].Columns {
+			str := column.Name + " " + column.Type.String()
+			if column.NotNull {
+				str += " NOT NULL"
+			}
+			columns = append(columns, str)
+		}
+		if !reflect.DeepEqual(columns, test.columns) {
+			t.Errorf("Resolve(%s):\nexpected %v\n     got %v", test.input, test.columns, columns)
+		}
+	}
+}
+
+var paramTests = []struct {
+	input  string
+	params []string
+	errors []string
+}{
+	{"SELECT * FROM users WHERE id = $1 AND $2 < name", []string{"integer", "text"}, nil},
+	{"SELECT * FROM users WHERE email LIKE $1 LIMIT $2 OFFSET $3", []string{"text", "bigint", "bigint"}, nil},
+	{"SELECT $1::uuid, $2::varchar(10)", []string{"uuid", "character varying"}, nil},
+	{"SELECT * FROM orders WHERE user_id IN ($1, $2) OR total BETWEEN $3 AND 10", []string{"integer", "integer", "numeric"}, nil},
+	{"SELECT * FROM orders WHERE user_id = ANY ($1)", []string{"integer[]"}, nil},
+	{"SELECT * FROM orders WHERE $1 IN (SELECT id FROM users)", []string{"integer"}, nil},
+	{"SELECT coalesce(email, $1) FROM users", []string{"text"}, nil},
+	{"SELECT * FROM user_orders($1)", []string{"integer"}, nil},
+	{"SELECT lower($1), length($2), substr($3, $4)", []string{"text", "text", "text", "integer"}, nil},
+	{"SELECT * FROM orders WHERE date_trunc($1, created_at) = $2 AND round(total, $3) > 0", []string{"text", "timestamp with time zone", "integer"}, nil},
+	{"SELECT make_date($1, 1, 1), power($2, 2), nextval($3)", []string{"integer", "double precision", "regclass"}, nil},
+	{"SELECT position($1 IN name), trim(BOTH $2 FROM email) FROM users", []string{"text", "text"}, nil},
+	{"INSERT INTO orders (total, user_id) VALUES ($1, $2), ($3, 4)", []string{"numeric", "integer", "numeric"}, nil},
+	{"INSERT INTO users VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET email = $3", []string{"integer", "text", "text"}, nil},
+	{"INSERT INTO orders (user_id) SELECT $1", []string{"integer"}, nil},
+	{"UPDATE orders SET total = $1 WHERE created_at > $2", []string{"numeric", "timestamp with time zone"}, nil},
+	{"SELECT * FROM users WHERE id = $3", []string{"unknown", "unknown", "integer"}, nil},
+	{"SELECT * FROM users WHERE id = $1 OR name = $1", []string{"integer"}, []string{"inconsistent types deduced for parameter $1: integer versus text"}},
+}
+
+func TestParams(t *testing.T) {
+	c := newCatalog(t)
+	for _, test := range paramTests {
+		results, err := resolve.Resolve(c, test.input)
+		if err != nil {
+			t.Errorf("Resolve(%s): %s", test.input, err)
+			continue
+		}
+		var params []string
+		for i, param := range results[0].Params {
+			if param.Number != i+1 {
+				t.Errorf("Resolve(%s): expected parameter %d, got %d", test.input, i+1, param.Number)
+			}
+			params = append(params, param.Type.String())
+		}
+		var errors []string
+		for _, err := range results[0].Errors {
+			errors = append(errors, err.Message)
+		}
+		if !reflect.DeepEqual(params, test.params) || !reflect.DeepEqual(errors, test.errors) {
+			t.Errorf("Resolve(%s):\nexpected %v %v\n     got %v %v", test.input, test.params, test.errors, params, errors)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package resolve
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// column is a column of a FROM item or a query result
+type column struct {
+	name string
+
+	// Name of the FROM item the column comes from, which differs from the
+	// item it's looked up in for the columns of a join
+	relation string
+
+	source  Column
+	origins []Origin
+	typ     catalog.TypeName
+	notNull bool
+}
+
+// rangeItem is an item of the FROM clause: a table, CTE, subquery, function
+// or join
+type rangeItem struct {
+	// Name and schema the item can be referred to by, e.g. "t" or "s.t" for
+	// a table, empty for a join without an alias
+	name   string
+	schema string
+
+	columns []*column
+
+	// Whether the item can be referred to by name, and whether its columns
+	// can be referred to without qualification. Both are cleared for the
+	// items of a join once it has been analyzed, as in PostgreSQL.
+	relVisible  bool
+	colsVisible bool
+
+	// Location of the table name, -1 for other items
+	location int
+
+	// Set for a relation that doesn't exist, to accept any column of it
+	// instead of reporting an error for each one
+	unknown bool
+}
+
+// cte is a common table expression of a WITH clause
+type cte struct {
+	name    string
+	columns []*column
+}
+
+// scope is the name space of one query level
+type scope struct {
+	parent *scope
+	items  []*rangeItem
+	ctes   []*cte
+
+	// Output columns of the query, which ORDER BY and GROUP BY can refer to
+	outputs []*column
+}
+
+// sibling returns a scope on the same query level with the same CTEs, but
+// other FROM items, e.g. for a subquery in FROM that isn't LATERAL
+func (s *scope) sibling(items []*rangeItem) *scope {
+	return &scope{parent: s.parent, ctes: s.ctes, items: items}
+}
+
+// cte returns the CTE with the given name visible in the scope, or nil
+func (s *scope) cte(name string) *cte {
+	for ; s != nil; s = s.parent {
+		for i := len(s.ctes) - 1; i >= 0; i-- {
+			if s.ctes[i].name == name {
+				return s.ctes[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (item *rangeItem) column(name string) []*column {
+	var columns []*column
+	for _, column := range item.columns {
+		if column.name == name {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 && item.unknown {
+		columns = append(columns, &column{name: name, relation: item.name})
+	}
+	return columns
+}
+
+func (c *column) binding() Binding {
+	return Binding{Relation: c.relation, Name: c.name, Source: c.source}
+}
+
+func (a *analyzer) error(location int, format string, args ...interface{}) {
+	a.result.Errors = append(a.result.Errors, Error{Message: fmt.Sprintf(format, args...), Location: location})
+}
+
+// columnRef resolves a column reference and returns the columns it refers
+// to, or nil if it can't be resolved
+func (a *analyzer) columnRef(ref nodes.ColumnRef, s *scope) []*column {
+	fields := []string{}
+	star := false
+	for _, item := range ref.Fields.Items {
+		switch item := item.(type) {
+		case nodes.String:
+			fields = append(fields, item.Str)
+		case nodes.A_Star:
+			fields = append(fields, "*")
+			star = true
+		}
+	}
+	columns := a.lookup(fields, star, ref.Location, s)
+	reference := Reference{Fields: fields, Location: ref.Location}
+	for _, column := range columns {
+		reference.Bindings = append(reference.Bindings, column.binding())
+	}
+	a.result.References = append(a.result.References, reference)
+	return columns
+}
+
+func (a *analyzer) lookup(fields []string, star bool, location int, s *scope) []*column {
+	if len(fields) == 0 {
+		return nil
+	}
+	if len(fields) == 1 {
+		if star {
+			columns := []*column{}
+			for _, item := range s.items {
+				if item.colsVisible {
+					columns = append(columns, item.columns...)
+				}
+			}
+			if len(s.items) == 0 {
+				a.error(location, "SELECT * with no tables specified is not valid")
+			}
+			return columns
+		}
+		return a.lookupColumn(fields[0], location, s)
+	}
+
+	// A qualified name is a column of a FROM item, or a field of a composite
+	// column if there's no such item
+	name := fields[len(fields)-1]
+	relation := fields[len(fields)-2]
+	schema := ""
+	if len(fields) > 2 {
+		schema = fields[len(fields)-3]
+	}
+	item, ok := a.lookupItem(schema, relation, location, s)
+	if !ok {
+		return nil
+	}
+	if item == nil {
+		if len(fields) == 2 && !star {
+			if columns := a.findColumn(relation, s); len(columns) == 1 {
+				return columns
+			}
+		}
+		a.error(location, "missing FROM-clause entry for table \"%s\"", relation)
+		return nil
+	}
+	if star {
+		return item.columns
+	}
+	columns := item.column(name)
+	switch len(columns) {
+	case 0:
+		a.error(location, "column %s.%s does not exist", relation, name)
+		return nil
+	case 1:
+		return columns
+	default:
+		a.error(location, "column reference \"%s\" is ambiguous", strings.Join(fields, "."))
+		return nil
+	}
+}
+
+// lookupColumn resolves an unqualified column name, looking in the FROM
+// items of the innermost query level that has a column of that name
+func (a *analyzer) lookupColumn(name string, location int, s *scope) []*column {
+	for ; s != nil; s = s.parent {
+		var columns []*column
+		for _, item := range s.items {
+			if item.colsVisible {
+				columns = append(columns, item.column(name)...)
+			}
+		}
+		switch {
+		case len(columns) == 1:
+			return columns
+		case len(columns) > 1:
+			a.error(location, "column reference \"%s\" is ambiguous", name)
+			return nil
+		}
+
+		// A bare table name refers to the whole row
+		for _, item := range s.items {
+			if item.relVisible && item.name == name {
+				return []*column{{name: name, relation: name}}
+			}
+		}
+	}
+	a.error(location, "column \"%s\" does not exist", name)
+	return nil
+}
+
+// findColumn is lookupColumn without reporting errors
+func (a *analyzer) findColumn(name string, s *scope) []*column {
+	errors := a.result.Errors
+	columns := a.lookupColumn(name, -1, s)
+	a.result.Errors = errors
+	return columns
+}
+
+// lookupItem returns the FROM item with the given name in the innermost
+// query level that has one, or nil. It reports an error and returns false if
+// the name is ambiguous.
+func (a *analyzer) lookupItem(schema string, name string, location int, s *scope) (*rangeItem, bool) {
+	for ; s != nil; s = s.parent {
+		var found *rangeItem
+		for _, item := range s.items {
+			if !item.relVisible || item.name != name || (schema != "" && item.schema != schema) {
+				continue
+			}
+			if found != nil {
+				a.error(location, "table reference \"%s\" is ambiguous", name)
+				return nil, false
+			}
+			found = item
+		}
+		if found != nil {
+			return found, true
+		}
+	}
+	return nil, true
+}
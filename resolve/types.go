@@ -0,0 +1,324 @@
+package resolve
+
+import (
+	"github.com/tomaszjonak/pg_query_go/catalog"
+	nodes "github.com/tomaszjonak/pg_query_go/nodes"
+)
+
+// ResultColumn is a column of the result of a query
+type ResultColumn struct {
+	// Name of the column, following PostgreSQL's rules for expressions
+	// without an alias, e.g. "?column?" or the name of a function
+	Name string
+
+	// Type of the column, unknown if it couldn't be inferred
+	Type catalog.TypeName
+
+	// Whether the column is known never to be NULL
+	NotNull bool
+
+	// The catalog column the value is taken from, the zero value for
+	// computed values
+	Source Column
+
+	// The catalog columns the value is copied or computed from
+	Origins []Origin
+}
+
+// Ranks of the numeric types, to find the type arithmetic on two of them
+// results in
+var numericRanks = map[string]int{
+	"int2":    1,
+	"int4":    2,
+	"int8":    3,
+	"numeric": 4,
+	"float4":  5,
+	"float8":  6,
+}
+
+// Types of values of SQLValueFunction nodes, e.g. CURRENT_DATE
+var sqlValueFunctionTypes = map[nodes.SQLValueFunctionOp]string{
+	nodes.SVFOP_CURRENT_DATE:        "date",
+	nodes.SVFOP_CURRENT_TIME:        "timetz",
+	nodes.SVFOP_CURRENT_TIME_N:      "timetz",
+	nodes.SVFOP_CURRENT_TIMESTAMP:   "timestamptz",
+	nodes.SVFOP_CURRENT_TIMESTAMP_N: "timestamptz",
+	nodes.SVFOP_LOCALTIME:           "time",
+	nodes.SVFOP_LOCALTIME_N:         "time",
+	nodes.SVFOP_LOCALTIMESTAMP:      "timestamp",
+	nodes.SVFOP_LOCALTIMESTAMP_N:    "timestamp",
+	nodes.SVFOP_CURRENT_ROLE:        "name",
+	nodes.SVFOP_CURRENT_USER:        "name",
+	nodes.SVFOP_USER:                "name",
+	nodes.SVFOP_SESSION_USER:        "name",
+	nodes.SVFOP_CURRENT_CATALOG:     "name",
+	nodes.SVFOP_CURRENT_SCHEMA:      "name",
+}
+
+// exprType returns the type of an expression whose column references have
+// already been resolved in the scope, and whether it's known never to be
+// NULL
+func (a *analyzer) exprType(node nodes.Node, s *scope) (catalog.TypeName, bool) {
+	switch node := node.(type) {
+	case nodes.ColumnRef:
+		if columns := a.findColumns(node, s); len(columns) == 1 {
+			return columns[0].typ, columns[0].notNull
+		}
+	case nodes.A_Const:
+		switch node.Val.(type) {
+		case nodes.Integer:
+			return catalog.BuiltinType("int4"), true
+		case nodes.Float:
+			return catalog.BuiltinType("numeric"), true
+		case nodes.String:
+			return catalog.BuiltinType("text"), true
+		case nodes.BitString:
+			return catalog.BuiltinType("bit"), true
+		}
+	case nodes.TypeCast:
+		_, notNull := a.exprType(node.Arg, s)
+		if node.TypeName != nil {
+			if typ, err := a.catalog.ResolveType(*node.TypeName); err == nil {
+				return typ, notNull
+			}
+		}
+		return catalog.TypeName{}, notNull
+	case nodes.CollateClause:
+		return a.exprType(node.Arg, s)
+	case nodes.FuncCall:
+		return a.funcCallType(node, s)
+	case nodes.CoalesceExpr:
+		var typ catalog.TypeName
+		notNull := false
+		for _, arg := range node.Args.Items {
+			argType, argNotNull := a.exprType(arg, s)
+			if typ.IsUnknown() {
+				typ = argType
+			}
+			notNull = notNull || argNotNull
+		}
+		return typ, notNull
+	case nodes.MinMaxExpr:
+		var typ catalog.TypeName
+		notNull := false
+		for _, arg := range node.Args.Items {
+			argType, argNotNull := a.exprType(arg, s)
+			if typ.IsUnknown() {
+				typ = argType
+			}
+			notNull = notNull || argNotNull
+		}
+		return typ, notNull
+	case nodes.CaseExpr:
+		var typ catalog.TypeName
+		notNull := node.Defresult != nil
+		results := []nodes.Node{}
+		for _, item := range node.Args.Items {
+			if caseWhen, ok := item.(nodes.CaseWhen); ok {
+				results = append(results, caseWhen.Result)
+			}
+		}
+		if node.Defresult != nil {
+			results = append(results, node.Defresult)
+		}
+		for _, result := range results {
+			resultType, resultNotNull := a.exprType(result, s)
+			if typ.IsUnknown() {
+				typ = resultType
+			}
+			notNull = notNull && resultNotNull
+		}
+		return typ, notNull
+	case nodes.SubLink:
+		switch node.SubLinkType {
+		case nodes.EXISTS_SUBLINK:
+			return catalog.BuiltinType("bool"), true
+		case nodes.EXPR_SUBLINK:
+			// The subquery may return no rows
+			if columns := a.sublinks[node.Location]; len(columns) == 1 {
+				return columns[0].typ, false
+			}
+		case nodes.ARRAY_SUBLINK:
+			if columns := a.sublinks[node.Location]; len(columns) == 1 && !columns[0].typ.IsUnknown() {
+				return columns[0].typ.Array(), true
+			}
+			return catalog.TypeName{}, true
+		default:
+			return catalog.BuiltinType("bool"), false
+		}
+	case nodes.A_Expr:
+		return a.aExprType(node, s)
+	case nodes.BoolExpr:
+		notNull := true
+		for _, arg := range node.Args.Items {
+			_, argNotNull := a.exprType(arg, s)
+			notNull = notNull && argNotNull
+		}
+		return catalog.BuiltinType("bool"), notNull
+	case nodes.NullTest, nodes.BooleanTest:
+		return catalog.BuiltinType("bool"), true
+	case nodes.SQLValueFunction:
+		return catalog.BuiltinType(sqlValueFunctionTypes[node.Op]), true
+	case nodes.A_ArrayExpr:
+		for _, element := range node.Elements.Items {
+			if typ, _ := a.exprType(element, s); !typ.IsUnknown() {
+				return typ.Array(), true
+			}
+		}
+		return catalog.TypeName{}, true
+	case nodes.RowExpr:
+		return catalog.BuiltinType("record"), true
+	case nodes.ParamRef:
+		if p, ok := a.params[node.Number]; ok {
+			return p.Type, false
+		}
+	}
+	return catalog.TypeName{}, false
+}
+
+// findColumns returns the columns a reference refers to without recording
+// the reference or reporting errors
+func (a *analyzer) findColumns(ref nodes.ColumnRef, s *scope) []*column {
+	errors := a.result.Errors
+	fields := []string{}
+	for _, item := range ref.Fields.Items {
+		if str, ok := item.(nodes.String); ok {
+			fields = append(fields, str.Str)
+		}
+	}
+	columns := a.lookup(fields, isStar(ref), -1, s)
+	a.result.Errors = errors
+	return columns
+}
+
+func (a *analyzer) funcCallType(funcCall nodes.FuncCall, s *scope) (catalog.TypeName, bool) {
+	args := []catalog.TypeName{}
+	argsNotNull := true
+	for _, arg := range funcCall.Args.Items {
+		argType, argNotNull := a.exprType(arg, s)
+		args = append(args, argType)
+		argsNotNull = argsNotNull && argNotNull
+	}
+
+	if function, ok := lookupBuiltinFunction(funcCall); ok {
+		switch function.nullability {
+		case neverNull:
+			return function.resultType(args), true
+		case nullIfAnyArg:
+			return function.resultType(args), argsNotNull
+		default:
+			return function.resultType(args), false
+		}
+	}
+	if functions := a.functions(funcCall); len(functions) == 1 {
+		return functions[0].ReturnType, false
+	}
+	return catalog.TypeName{}, false
+}
+
+func (a *analyzer) aExprType(expr nodes.A_Expr, s *scope) (catalog.TypeName, bool) {
+	left, leftNotNull := a.exprType(expr.Lexpr, s)
+	right, rightNotNull := a.exprType(expr.Rexpr, s)
+	if expr.Lexpr == nil {
+		left, leftNotNull = right, rightNotNull
+	}
+	notNull := leftNotNull && rightNotNull
+
+	switch expr.Kind {
+	case nodes.AEXPR_PAREN:
+		return left, leftNotNull
+	case nodes.AEXPR_NULLIF:
+		return left, false
+	case nodes.AEXPR_DISTINCT, nodes.AEXPR_NOT_DISTINCT:
+		return catalog.BuiltinType("bool"), true
+	case nodes.AEXPR_OP:
+	default:
+		// IN, LIKE, BETWEEN, ANY and ALL, whose right side may be a list
+		if list, ok := expr.Rexpr.(nodes.List); ok {
+			notNull = leftNotNull
+			for _, item := range list.Items {
+				_, itemNotNull := a.exprType(item, s)
+				notNull = notNull && itemNotNull
+			}
+		}
+		return catalog.BuiltinType("bool"), notNull
+	}
+
+	operator := ""
+	if len(expr.Name.Items) > 0 {
+		if str, ok := expr.Name.Items[len(expr.Name.Items)-1].(nodes.String); ok {
+			operator = str.Str
+		}
+	}
+	switch operator {
+	case "=", "<>", "!=", "<", ">", "<=", ">=", "~", "~*", "!~", "!~*", "~~", "~~*", "!~~", "!~~*",
+		"@>", "<@", "&&", "?", "?|", "?&", "@@":
+		return catalog.BuiltinType("bool"), notNull
+	case "||":
+		if left.ArrayDims > 0 {
+			return left, notNull
+		}
+		if right.ArrayDims > 0 {
+			return right, notNull
+		}
+		if left.Name == "jsonb" {
+			return left, notNull
+		}
+		return catalog.BuiltinType("text"), notNull
+	case "->", "#>":
+		return left, notNull
+	case "->>", "#>>":
+		return catalog.BuiltinType("text"), notNull
+	case "+", "-", "*", "/", "%", "^":
+		return arithmeticType(operator, left, right), notNull
+	}
+	return catalog.TypeName{}, notNull
+}
+
+// arithmeticType returns the type of an arithmetic operation on values of
+// the given types
+func arithmeticType(operator string, left catalog.TypeName, right catalog.TypeName) catalog.TypeName {
+	if left.IsUnknown() {
+		return right
+	}
+	if right.IsUnknown() {
+		return left
+	}
+	leftRank, leftNumeric := numericRanks[left.Name]
+	rightRank, rightNumeric := numericRanks[right.Name]
+	if leftNumeric && rightNumeric && left.IsBuiltin() && right.IsBuiltin() {
+		if operator == "^" {
+			return catalog.BuiltinType("float8")
+		}
+		if leftRank >= rightRank {
+			return left
+		}
+		return right
+	}
+
+	// Date and time arithmetic
+	if operator == "-" && left.Name == right.Name {
+		switch left.Name {
+		case "date":
+			return catalog.BuiltinType("int4")
+		case "timestamp", "timestamptz", "time":
+			return catalog.BuiltinType("interval")
+		}
+	}
+	if left.Name == "interval" && (right.Name == "date" || right.Name == "timestamp" || right.Name == "timestamptz") {
+		left, right = right, left
+	}
+	if left.Name == "date" && right.Name == "interval" {
+		return catalog.BuiltinType("timestamp")
+	}
+	return left
+}
+
+// resultColumns returns the exported form of the output columns of a query
+func resultColumns(columns []*column) []ResultColumn {
+	result := []ResultColumn{}
+	for _, c := range columns {
+		result = append(result, ResultColumn{Name: c.name, Type: c.typ, NotNull: c.notNull, Source: c.source, Origins: c.origins})
+	}
+	return result
+}
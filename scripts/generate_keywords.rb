@@ -0,0 +1,36 @@
+#!/usr/bin/env ruby
+
+# rubocop:disable Style/StringLiterals, Style/FormatStringToken
+
+# Generates keywords.go from the keyword list of the PostgreSQL grammar
+
+CATEGORIES = {
+  'UNRESERVED_KEYWORD' => 'unreservedKeyword',
+  'COL_NAME_KEYWORD' => 'colNameKeyword',
+  'TYPE_FUNC_NAME_KEYWORD' => 'typeFuncNameKeyword',
+  'RESERVED_KEYWORD' => 'reservedKeyword'
+}.freeze
+
+source_file = 'parser/include/parser/kwlist.h'
+path = './keywords.go'
+
+keywords = File.read(source_file).scan(/^PG_KEYWORD\("([^"]+)", \w+, (\w+)\)/)
+
+content = "// Auto-generated from #{source_file} - DO NOT EDIT\n\n"
+content += "package pg_query\n\n"
+content += "type keywordCategory int\n\n"
+content += "const (\n"
+content += "\tunreservedKeyword keywordCategory = iota\n"
+content += "\tcolNameKeyword\n"
+content += "\ttypeFuncNameKeyword\n"
+content += "\treservedKeyword\n"
+content += ")\n\n"
+content += "// keywords maps every SQL keyword known to the parser to its category\n"
+content += "var keywords = map[string]keywordCategory{\n"
+keywords.each do |name, category|
+  content += format("\t%s: %s,\n", name.inspect, CATEGORIES.fetch(category))
+end
+content += "}\n"
+
+File.write(path, content)
+system format('go fmt %s', path)
@@ -35,6 +35,26 @@ class Generator
     'sig_atomic_t', 'size_t', 'varatt_indirect', 'varatt_expanded'
   ]
 
+  # Enums whose values are bit flags (1 << 0, 1 << 1, ...) in the C source
+  BIT_FLAG_ENUMS = ['VacuumOption']
+
+  # Enums whose values are explicitly assigned characters in the C source
+  CHAR_ENUM_VALUES = {
+    'FunctionParameterMode' => {
+      'FUNC_PARAM_IN' => "'i'",
+      'FUNC_PARAM_OUT' => "'o'",
+      'FUNC_PARAM_INOUT' => "'b'",
+      'FUNC_PARAM_VARIADIC' => "'v'",
+      'FUNC_PARAM_TABLE' => "'t'",
+    },
+  }
+
+  # Enum values missing from the source data, because the C source declares
+  # them on the same line as an earlier value
+  EXTRA_ENUM_VALUES = {
+    'BoolExprType' => ['NOT_EXPR'],
+  }
+
   GO_TYPE_OVERRIDES = {
     ['SelectStmt', 'valuesLists'] => '[][]Node',
     ['Float', 'str'] => 'string',
@@ -89,12 +109,12 @@ class Generator
   end
 
   LIST_FINGERPRINT = '''
-  if parentFieldName == "FromClause" || parentFieldName == "TargetList" || parentFieldName == "Cols" || parentFieldName == "Rexpr" {
+  if parentFieldName == "FromClause" || (parentFieldName == "TargetList" && !fingerprintOptions(ctx).TargetListOrder) || parentFieldName == "Cols" || parentFieldName == "Rexpr" || parentFieldName == "ValuesLists" {
 		var itemsFingerprints FingerprintSubContextSlice
 
 		for _, subNode := range node.Items {
       if subNode != nil {
-			  subCtx := FingerprintSubContext{}
+			  subCtx := newFingerprintSubContext(ctx)
 			  subNode.Fingerprint(&subCtx, parentNode, parentFieldName)
 			  itemsFingerprints.AddIfUnique(subCtx)
       }
@@ -108,46 +128,90 @@ class Generator
 			}
 		}
 	} else {
+    subCtx := newFingerprintSubContext(ctx)
     for _, subNode := range node.Items {
       if subNode != nil {
-        subNode.Fingerprint(ctx, parentNode, parentFieldName)
+        subNode.Fingerprint(&subCtx, parentNode, parentFieldName)
       }
     }
+
+    for _, part := range subCtx.parts {
+      ctx.WriteString(part)
+    }
   }
   '''
 
+  # Each row is fingerprinted as its own (sorted) List, just like the C implementation does
   VALUES_LIST_FINGERPRINT = '''
   if len(node.ValuesLists) > 0 {
-		var itemsFingerprints FingerprintSubContextSlice
+    valuesLists := List{}
+    for _, nodeList := range node.ValuesLists {
+      valuesLists.Items = append(valuesLists.Items, List{Items: nodeList})
+    }
 
-		for _, nodeList := range node.ValuesLists {
-			subCtx := FingerprintSubContext{}
-			for _, subNode := range nodeList {
-				subNode.Fingerprint(&subCtx, node, "ValuesLists")
-			}
-			itemsFingerprints.AddIfUnique(subCtx)
-		}
+    subCtx := newFingerprintSubContext(ctx)
+    valuesLists.Fingerprint(&subCtx, node, "ValuesLists")
 
-		sort.Sort(itemsFingerprints)
+    if len(subCtx.parts) > 0 {
+      ctx.WriteString("valuesLists")
+      for _, part := range subCtx.parts {
+        ctx.WriteString(part)
+      }
+    }
+  }
+  '''
 
-    if len(itemsFingerprints) > 0 {
-			firstItem := true
-			for _, fingerprint := range itemsFingerprints {
-				for _, part := range fingerprint.parts {
-					if firstItem {
-						ctx.WriteString("valuesLists")
-						firstItem = false
-					}
-					ctx.WriteString(part)
-				}
-			}
-		}
-	}
+  A_CONST_FINGERPRINT = '''
+  // Constants are ignored, unless LIMIT/OFFSET values are significant
+  if node.Val != nil && fingerprintOptions(ctx).LimitConstants && (parentFieldName == "LimitCount" || parentFieldName == "LimitOffset") {
+    ctx.WriteString("A_Const")
+
+    subCtx := newFingerprintSubContext(ctx)
+    node.Val.Fingerprint(&subCtx, node, "Val")
+
+    if len(subCtx.parts) > 0 {
+      ctx.WriteString("val")
+      for _, part := range subCtx.parts {
+        ctx.WriteString(part)
+      }
+    }
+  }
+  '''
+
+  # Value nodes need to match _fingerprintInteger and friends in pg_query_fingerprint.c
+  INTEGER_FINGERPRINT = '''
+  if node.Ival != 0 {
+    ctx.WriteString("Integer")
+    ctx.WriteString("ival")
+    ctx.WriteString(strconv.Itoa(int(node.Ival)))
+  }
+  '''
+
+  STRING_FINGERPRINT = '''
+  ctx.WriteString("String")
+  ctx.WriteString("str")
+  ctx.WriteString(node.Str)
+  '''
+
+  FLOAT_FINGERPRINT = '''
+  if len(node.Str) > 0 {
+    ctx.WriteString("Float")
+    ctx.WriteString("str")
+    ctx.WriteString(node.Str)
+  }
+  '''
+
+  BIT_STRING_FINGERPRINT = '''
+  if len(node.Str) > 0 {
+    ctx.WriteString("BitString")
+    ctx.WriteString("str")
+    ctx.WriteString(node.Str)
+  }
   '''
 
   # Fingerprinting additional code to be inserted
   FINGERPRINT_OVERRIDE_NODES = {
-    'A_Const' => :skip,
+    'A_Const' => A_CONST_FINGERPRINT,
     'Alias' => :skip,
     'ParamRef' => :skip,
     'SetToDefault' => :skip,
@@ -155,6 +219,10 @@ class Generator
     'OidList' => :skip,
     'Null' => :skip,
     'List' => LIST_FINGERPRINT,
+    'Integer' => INTEGER_FINGERPRINT,
+    'String' => STRING_FINGERPRINT,
+    'Float' => FLOAT_FINGERPRINT,
+    'BitString' => BIT_STRING_FINGERPRINT,
   }
   FINGERPRINT_OVERRIDE_FIELDS = {
     [nil, 'location'] => :skip,
@@ -172,6 +240,36 @@ class Generator
       }
     }
     ),
+    ['RangeVar', 'schemaname'] => %(
+    if node.Schemaname != nil && !fingerprintOptions(ctx).IgnoreSchema {
+      ctx.WriteString("schemaname")
+      ctx.WriteString(*node.Schemaname)
+    }
+    ),
+    ['SortBy', 'sortby_dir'] => %(
+    if int(node.SortbyDir) != 0 && !fingerprintOptions(ctx).IgnoreSortDirection {
+      ctx.WriteString("sortby_dir")
+      ctx.WriteString(strconv.Itoa(int(node.SortbyDir)))
+    }
+    ),
+    ['A_Expr', 'rexpr'] => %(
+    if node.Rexpr != nil {
+      subCtx := newFingerprintSubContext(ctx)
+      node.Rexpr.Fingerprint(&subCtx, node, "Rexpr")
+
+      if len(subCtx.parts) > 0 {
+        ctx.WriteString("rexpr")
+        for _, part := range subCtx.parts {
+          ctx.WriteString(part)
+        }
+      }
+
+      if list, ok := node.Rexpr.(List); ok && node.Kind == AEXPR_IN && fingerprintOptions(ctx).InListLength {
+        ctx.WriteString("rexprLength")
+        ctx.WriteString(strconv.Itoa(len(list.Items)))
+      }
+    }
+    ),
     ['RangeVar', 'relname'] => %(
     if node.Relname != nil && node.Relpersistence != 't' {
   		ctx.WriteString("relname")
@@ -196,7 +294,7 @@ class Generator
 
   FINGERPRINT_NODE = '''
   if node.%<go_field_name>s != nil {
-    subCtx := FingerprintSubContext{}
+    subCtx := newFingerprintSubContext(ctx)
     node.%<go_field_name>s.Fingerprint(&subCtx, node, "%<go_field_name>s")
 
     if len(subCtx.parts) > 0 {
@@ -210,7 +308,7 @@ class Generator
 
   FINGERPRINT_LIST = '''
   if len(node.%<go_field_name>s.Items) > 0 {
-    subCtx := FingerprintSubContext{}
+    subCtx := newFingerprintSubContext(ctx)
     node.%<go_field_name>s.Fingerprint(&subCtx, node, "%<go_field_name>s")
 
     if len(subCtx.parts) > 0 {
@@ -224,7 +322,7 @@ class Generator
 
   FINGERPRINT_NODE_ARRAY = '''
   if len(node.%<go_field_name>s) > 0 {
-    subCtx := FingerprintSubContext{}
+    subCtx := newFingerprintSubContext(ctx)
     for _, subNode := range node.%<go_field_name>s {
       subNode.Fingerprint(&subCtx, node, "%<go_field_name>s")
     }
@@ -240,7 +338,7 @@ class Generator
 
   FINGERPRINT_NODE_ARRAY_ARRAY = '''
   if len(node.%<go_field_name>s) > 0 {
-    subCtx := FingerprintSubContext{}
+    subCtx := newFingerprintSubContext(ctx)
     for _, nodeList := range node.%<go_field_name>s {
       for _, subNode := range nodeList {
         subNode.Fingerprint(&subCtx, node, "%<go_field_name>s")
@@ -514,13 +612,19 @@ func UnmarshalNodeJSON(input json.RawMessage) (node Node, err error) {
             next
           end
 
-          if !output_first_type_field
-            go_enum_def += format("%s %s = iota %s\n", field['name'], type, field['comment'])
+          if CHAR_ENUM_VALUES.include?(type)
+            go_enum_def += format("%s %s = %s %s\n", field['name'], type, CHAR_ENUM_VALUES[type][field['name']], field['comment'])
+          elsif !output_first_type_field
+            first_value = BIT_FLAG_ENUMS.include?(type) ? '1 << iota' : 'iota'
+            go_enum_def += format("%s %s = %s %s\n", field['name'], type, first_value, field['comment'])
             output_first_type_field = true
           else
             go_enum_def += format("%s\t%s\n", field['name'], field['comment'])
           end
         end
+        EXTRA_ENUM_VALUES.fetch(type, []).each do |name|
+          go_enum_def += format("%s\n", name)
+        end
 
         write_nodes_file type, %(
           #{enum_def['comment'] && enum_def['comment'].strip}